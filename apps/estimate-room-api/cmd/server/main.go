@@ -22,6 +22,7 @@ import (
 	"github.com/master-bogdan/estimate-room-api/internal/infra/db/postgresql"
 	"github.com/master-bogdan/estimate-room-api/internal/infra/db/redis"
 	wsserver "github.com/master-bogdan/estimate-room-api/internal/infra/wsserver"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
 )
 
@@ -84,6 +85,22 @@ func main() {
 	}
 	logger.L().Info(logPrefix("BOOT", "WS", "WebSocket server initialized"))
 
+	wsEventLog, err := ws.NewRedisEventLog(ws.RedisEventLogDeps{
+		Client: redisClient,
+	})
+	if err != nil {
+		logger.L().Error(logPrefix("BOOT", "WS", "Failed to initialize WebSocket event log"), "err", err)
+		os.Exit(1)
+	}
+
+	wsPresence, err := ws.NewRedisPresence(ws.RedisPresenceDeps{
+		Client: redisClient,
+	})
+	if err != nil {
+		logger.L().Error(logPrefix("BOOT", "WS", "Failed to initialize WebSocket presence"), "err", err)
+		os.Exit(1)
+	}
+
 	router := chi.NewRouter()
 	logger.L().Info(logPrefix("BOOT", "HTTP", "HTTP router initialized"))
 
@@ -94,6 +111,8 @@ func main() {
 		Router:             router,
 		IsGracefulShutdown: &IsGracefulShutdown,
 		WsServer:           wsServer,
+		WsEventLog:         wsEventLog,
+		WsPresence:         wsPresence,
 	}
 
 	backgroundCtx, cancelBackground := context.WithCancel(context.Background())
@@ -32,10 +32,17 @@ Enum room_status {
   EXPIRED
 }
 
+Enum room_mode {
+  LIVE
+  ASYNC
+}
+
 Enum room_participant_role {
   ADMIN
   MEMBER
   GUEST
+  OBSERVER [note: 'follows the room without voting']
+  FACILITATOR [note: 'co-facilitator who can run the session']
 }
 
 Enum task_status {
@@ -50,6 +57,12 @@ Enum round_status {
   REVEALED
 }
 
+Enum vote_change_action {
+  CAST
+  CHANGED
+  RETRACTED
+}
+
 // ---------- Core ----------
 Table users {
   user_id        text        [pk]
@@ -108,10 +121,14 @@ Table rooms {
   admin_user_id      text        [not null, ref: > users.user_id]
   team_id            text        [ref: > teams.team_id]
   deck               jsonb       [not null, note: 'Object with name, kind, values[]']
+  options            jsonb       [not null, default: '{}', note: 'Object with autoReveal, votingTimerSeconds']
+  dimensions         jsonb       [not null, default: '[]', note: 'Array of {key, name, deck} for multi-criteria rooms']
+  mode               room_mode   [not null, default: 'LIVE', note: 'ASYNC rooms vote over REST against a deadline and never expire for inactivity']
   status             room_status [not null, default: 'ACTIVE']
   created_at         timestamptz [not null, default: `now()`]
   last_activity_at   timestamptz [not null, default: `now()`]
   finished_at        timestamptz
+  locked_at          timestamptz [note: 'set while the room admits no new participants']
 
   Indexes {
     (last_activity_at) [name: 'rooms_active_last_activity_idx', note: 'partial index where status = ACTIVE']
@@ -160,11 +177,14 @@ Table tasks {
   status              task_status [not null, default: 'PENDING']
   is_active           boolean     [not null, default: false]
   final_estimate_value text
+  final_dimension_estimates jsonb [note: 'Object mapping dimension keys to final estimates']
+  position            int         [not null, default: 0, note: 'backlog order within the room']
   created_at          timestamptz [not null, default: `now()`]
   updated_at          timestamptz [not null, default: `now()`]
 
   Indexes {
     (room_id) [name: 'tasks_one_active_per_room_idx', unique, note: 'partial unique index where is_active = true']
+    (room_id, position) [name: 'tasks_room_id_position_idx']
   }
 }
 
@@ -173,6 +193,9 @@ Table task_rounds {
   round_number  int         [not null]
   eligible_participant_ids jsonb [not null, default: '[]', note: 'JSONB array of participant ids eligible for the round']
   status        round_status [not null, default: 'ACTIVE']
+  timer_duration_seconds int
+  timer_ends_at timestamptz [note: 'deadline of the voting timer; the round is revealed on expiry']
+  reminder_sent_at timestamptz [note: 'when the async vote reminder went out']
   created_at    timestamptz [not null, default: `now()`]
   updated_at    timestamptz [not null, default: `now()`]
 
@@ -187,10 +210,55 @@ Table votes {
   participant_id text        [not null, ref: > room_participants.room_participants_id]
   value          text        [not null, note: 'must be in deck values']
   round_number   int         [not null, default: 1]
+  dimension      text        [not null, default: '', note: 'dimension key; blank in rooms without dimensions']
   created_at     timestamptz [not null, default: `now()`]
 
   Indexes {
-    (task_id, participant_id, round_number) [unique]
+    (task_id, participant_id, round_number, dimension) [unique]
+  }
+}
+
+Table vote_changes {
+  vote_change_id text               [pk]
+  task_id        text               [not null, ref: > tasks.task_id]
+  participant_id text               [not null, ref: > room_participants.room_participants_id]
+  round_number   int                [not null]
+  dimension      text               [not null, default: '']
+  action         vote_change_action [not null]
+  previous_value text
+  value          text
+  created_at     timestamptz        [not null, default: `now()`]
+
+  Indexes {
+    (task_id, round_number)
+  }
+}
+
+Table task_reestimations {
+  task_reestimation_id          text        [pk]
+  task_id                       text        [not null, ref: > tasks.task_id]
+  round_number                  int         [not null]
+  previous_status               text        [not null]
+  previous_final_estimate_value text
+  reopened_by_user_id           text        [not null, ref: > users.user_id]
+  created_at                    timestamptz [not null, default: `now()`]
+
+  Indexes {
+    task_id
+  }
+}
+
+Table chat_messages {
+  chat_message_id text        [pk]
+  room_id         text        [not null, ref: > rooms.room_id]
+  task_id         text        [ref: > tasks.task_id]
+  participant_id  text        [not null, ref: > room_participants.room_participants_id]
+  body            text        [not null]
+  created_at      timestamptz [not null, default: `now()`]
+
+  Indexes {
+    (room_id, created_at)
+    (task_id, created_at)
   }
 }
 
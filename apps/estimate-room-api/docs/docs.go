@@ -15,6 +15,58 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/v1/auth/change-password": {
+            "post": {
+                "description": "Verifies the current password, stores the new one, and revokes all active sessions and tokens for the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Change current user password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authdto.ChangePasswordDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/authdto.ChangePasswordResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/auth/forgot-password": {
             "post": {
                 "description": "Accepts an email and stores a one-time reset token when the account is eligible. The response is always generic.",
@@ -383,21 +435,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/gamification/me": {
+        "/api/v1/decks": {
             "get": {
-                "description": "Returns cumulative stats and unlocked achievements for the current authenticated user.",
+                "description": "Returns system decks, the caller's personal decks and decks of the caller's teams.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "gamification"
+                    "decks"
                 ],
-                "summary": "Current user gamification",
+                "summary": "List decks",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/gamificationdto.MeResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/decksdto.DeckResponse"
+                            }
                         }
                     },
                     "401": {
@@ -413,53 +468,63 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/health/healthz": {
-            "get": {
-                "description": "Reports service liveness status.",
+            },
+            "post": {
+                "description": "Creates a personal deck, or a team deck when teamId is set and the caller owns the team.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "decks"
+                ],
+                "summary": "Create deck",
+                "parameters": [
+                    {
+                        "description": "Deck",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/decksdto.CreateDeckDTO"
+                        }
+                    }
                 ],
-                "summary": "Liveness check",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/health.LivenessStatus"
+                            "$ref": "#/definitions/decksdto.DeckResponse"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/apperrors.HttpError"
                         }
-                    }
-                }
-            }
-        },
-        "/api/v1/health/readyz": {
-            "get": {
-                "description": "Checks database and Redis connectivity.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "health"
-                ],
-                "summary": "Readiness check",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/health.ReadinessStatus"
+                            "$ref": "#/definitions/apperrors.HttpError"
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/apperrors.HttpError"
                         }
@@ -467,68 +532,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/history/me/sessions": {
+        "/api/v1/decks/{id}": {
             "get": {
-                "description": "Returns paginated room history for the current authenticated user.",
+                "description": "Returns a deck visible to the caller.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "history"
+                    "decks"
                 ],
-                "summary": "Current user sessions",
+                "summary": "Get deck",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ALL",
-                            "ACTIVE",
-                            "FINISHED",
-                            "EXPIRED"
-                        ],
-                        "type": "string",
-                        "description": "Status filter",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ALL",
-                            "ADMIN",
-                            "PARTICIPANT"
-                        ],
                         "type": "string",
-                        "description": "Role filter",
-                        "name": "role",
-                        "in": "query"
+                        "description": "Deck ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/historydto.SessionListResponse"
+                            "$ref": "#/definitions/decksdto.DeckResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/apperrors.HttpError"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/apperrors.HttpError"
                         }
@@ -540,22 +583,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/history/rooms/{id}/summary": {
-            "get": {
-                "description": "Returns aggregated room history, participants, tasks, rounds, and revealed votes.",
+            },
+            "delete": {
+                "description": "Deletes a personal deck or, for team owners, a team deck. Rooms keep their own deck copy.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "history"
+                    "decks"
                 ],
-                "summary": "Room summary",
+                "summary": "Delete deck",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Room ID",
+                        "description": "Deck ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -565,13 +606,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/historydto.RoomSummaryResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/apperrors.HttpError"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
                         }
                     },
                     "401": {
@@ -599,56 +637,42 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/history/teams/{id}/sessions": {
-            "get": {
-                "description": "Returns paginated room history for a team. Team owner access is required.",
+            },
+            "patch": {
+                "description": "Updates a personal deck or, for team owners, a team deck. System decks are read-only.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "history"
+                    "decks"
                 ],
-                "summary": "Team sessions",
+                "summary": "Update deck",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Team ID",
+                        "description": "Deck ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Page size",
-                        "name": "pageSize",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "ALL",
-                            "ACTIVE",
-                            "FINISHED",
-                            "EXPIRED"
-                        ],
-                        "type": "string",
-                        "description": "Status filter",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Deck changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/decksdto.UpdateDeckDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/historydto.SessionListResponse"
+                            "$ref": "#/definitions/decksdto.DeckResponse"
                         }
                     },
                     "400": {
@@ -684,33 +708,466 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/oauth2/authorize": {
+        "/api/v1/gamification/me": {
             "get": {
-                "description": "Validates the authorization request and either issues an auth code for an authenticated browser session or redirects the user to the frontend login page.",
+                "description": "Returns cumulative stats and unlocked achievements for the current authenticated user.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "oauth2"
+                    "gamification"
                 ],
-                "summary": "OAuth2 authorize",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "OAuth client ID",
-                        "name": "client_id",
-                        "in": "query",
-                        "required": true
+                "summary": "Current user gamification",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gamificationdto.MeResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Registered redirect URI",
-                        "name": "redirect_uri",
-                        "in": "query",
-                        "required": true
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
                     },
-                    {
-                        "type": "string",
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/gamification/teams/{id}": {
+            "get": {
+                "description": "Returns cumulative stats and unlocked achievements for a team. Team membership is required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "gamification"
+                ],
+                "summary": "Team gamification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/gamificationdto.TeamResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/health/healthz": {
+            "get": {
+                "description": "Reports service liveness status.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.LivenessStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/health/readyz": {
+            "get": {
+                "description": "Checks database and Redis connectivity.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.ReadinessStatus"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/history/me/sessions": {
+            "get": {
+                "description": "Returns paginated room history for the current authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Current user sessions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ALL",
+                            "ACTIVE",
+                            "FINISHED",
+                            "EXPIRED"
+                        ],
+                        "type": "string",
+                        "description": "Status filter",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ALL",
+                            "ADMIN",
+                            "PARTICIPANT"
+                        ],
+                        "type": "string",
+                        "description": "Role filter",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/historydto.SessionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/history/rooms/{id}/export": {
+            "get": {
+                "description": "Downloads the room summary as CSV (one row per task), Markdown, or versioned JSON. Access follows the room summary.",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "text/markdown"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Room export",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "markdown",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/historydto.RoomExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/history/rooms/{id}/summary": {
+            "get": {
+                "description": "Returns aggregated room history, participants, tasks, rounds, and revealed votes.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Room summary",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/historydto.RoomSummaryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/history/teams/{id}/sessions": {
+            "get": {
+                "description": "Returns paginated room history for a team. Team owner access is required.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "history"
+                ],
+                "summary": "Team sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ALL",
+                            "ACTIVE",
+                            "FINISHED",
+                            "EXPIRED"
+                        ],
+                        "type": "string",
+                        "description": "Status filter",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/historydto.SessionListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/oauth2/authorize": {
+            "get": {
+                "description": "Validates the authorization request and either issues an auth code for an authenticated browser session or redirects the user to the frontend login page.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "oauth2"
+                ],
+                "summary": "OAuth2 authorize",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "OAuth client ID",
+                        "name": "client_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Registered redirect URI",
+                        "name": "redirect_uri",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
                         "description": "Must be code",
                         "name": "response_type",
                         "in": "query",
@@ -831,7 +1288,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/oauth2dto.TokenResponseDTO"
+                            "$ref": "#/definitions/oauth2dto.Oauth2TokenResponseDTO"
                         }
                     },
                     "400": {
@@ -891,6 +1348,180 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "delete": {
+                "description": "Soft-deletes the current user, anonymizes their profile in rooms and history, and revokes all of their sessions and tokens.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete current user account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.DeleteAccountResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Updates the current user's display name, organization, occupation, and avatar URL. Omitted fields are left unchanged; optional fields sent as an empty string are cleared.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update current user profile",
+                "parameters": [
+                    {
+                        "description": "Profile fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.UpdateProfileDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/users/me/settings": {
+            "get": {
+                "description": "Returns the current user's preferences and room defaults.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Current user settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.UserSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the current user's preferences. Omitted fields are cleared. The default deck and room options are applied when creating rooms.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Replace current user settings",
+                "parameters": [
+                    {
+                        "description": "Settings",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.UpdateUserSettingsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersdto.UserSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/apperrors.HttpError"
+                        }
+                    }
+                }
             }
         }
     },
@@ -932,6 +1563,32 @@ const docTemplate = `{
                 }
             }
         },
+        "authdto.ChangePasswordDTO": {
+            "type": "object",
+            "required": [
+                "currentPassword",
+                "newPassword"
+            ],
+            "properties": {
+                "currentPassword": {
+                    "type": "string",
+                    "maxLength": 128
+                },
+                "newPassword": {
+                    "type": "string",
+                    "maxLength": 128,
+                    "minLength": 8
+                }
+            }
+        },
+        "authdto.ChangePasswordResponse": {
+            "type": "object",
+            "properties": {
+                "changed": {
+                    "type": "boolean"
+                }
+            }
+        },
         "authdto.ForgotPasswordDTO": {
             "type": "object",
             "required": [
@@ -1048,123 +1705,430 @@ const docTemplate = `{
         "authdto.ResetPasswordValidationResponse": {
             "type": "object",
             "properties": {
-                "reason": {
+                "reason": {
+                    "type": "string"
+                },
+                "valid": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "authdto.SessionResponse": {
+            "type": "object",
+            "properties": {
+                "authenticated": {
+                    "type": "boolean"
+                },
+                "user": {
+                    "$ref": "#/definitions/authdto.SessionUserResponse"
+                }
+            }
+        },
+        "authdto.SessionUserResponse": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "type": "string"
+                },
+                "displayName": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "occupation": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                }
+            }
+        },
+        "decksdto.CreateDeckDTO": {
+            "type": "object",
+            "required": [
+                "kind",
+                "name",
+                "values"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "teamId": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "values": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "decksdto.DeckResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deckId": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "ownerUserId": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "teamId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/decksdto.DeckValueResponse"
+                    }
+                }
+            }
+        },
+        "decksdto.DeckValueResponse": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "decksdto.UpdateDeckDTO": {
+            "type": "object",
+            "required": [
+                "values"
+            ],
+            "properties": {
+                "kind": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 1
+                },
+                "values": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "gamificationdto.AchievementResponse": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "integer"
+                },
+                "unlockedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "gamificationdto.MeResponse": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gamificationdto.AchievementResponse"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/gamificationdto.StatsResponse"
+                }
+            }
+        },
+        "gamificationdto.StatsResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "nextLevelXp": {
+                    "type": "integer"
+                },
+                "sessionsAdmined": {
+                    "type": "integer"
+                },
+                "sessionsParticipated": {
+                    "type": "integer"
+                },
+                "tasksEstimated": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gamificationdto.TeamResponse": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/gamificationdto.AchievementResponse"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/gamificationdto.TeamStatsResponse"
+                },
+                "teamId": {
+                    "type": "string"
+                }
+            }
+        },
+        "gamificationdto.TeamStatsResponse": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "nextLevelXp": {
+                    "type": "integer"
+                },
+                "sessionsTotal": {
+                    "type": "integer"
+                },
+                "tasksEstimated": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "health.LivenessStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                },
+                "uptime": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.ReadinessStatus": {
+            "type": "object",
+            "properties": {
+                "db": {
+                    "type": "string"
+                },
+                "redis": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "uptime": {
+                    "type": "string"
+                }
+            }
+        },
+        "historydto.RoomExport": {
+            "type": "object",
+            "properties": {
+                "exportedAt": {
+                    "type": "string"
+                },
+                "participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomExportParticipant"
+                    }
+                },
+                "room": {
+                    "$ref": "#/definitions/historydto.RoomExportRoom"
+                },
+                "schemaVersion": {
+                    "type": "integer"
+                },
+                "tasks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomExportTask"
+                    }
+                }
+            }
+        },
+        "historydto.RoomExportDimension": {
+            "type": "object",
+            "properties": {
+                "key": {
                     "type": "string"
                 },
-                "valid": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "authdto.SessionResponse": {
+        "historydto.RoomExportParticipant": {
             "type": "object",
             "properties": {
-                "authenticated": {
+                "isGuest": {
                     "type": "boolean"
                 },
-                "user": {
-                    "$ref": "#/definitions/authdto.SessionUserResponse"
+                "name": {
+                    "type": "string"
+                },
+                "participantId": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "authdto.SessionUserResponse": {
+        "historydto.RoomExportRoom": {
             "type": "object",
             "properties": {
-                "avatarUrl": {
+                "adminName": {
                     "type": "string"
                 },
-                "displayName": {
+                "createdAt": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "dimensions": {
+                    "description": "Dimensions is empty unless the room estimated several criteria.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomExportDimension"
+                    }
                 },
-                "id": {
+                "estimatedTasksCount": {
+                    "type": "integer"
+                },
+                "finishedAt": {
                     "type": "string"
                 },
-                "occupation": {
+                "name": {
                     "type": "string"
                 },
-                "organization": {
+                "roomId": {
                     "type": "string"
-                }
-            }
-        },
-        "gamificationdto.AchievementResponse": {
-            "type": "object",
-            "properties": {
-                "key": {
+                },
+                "status": {
                     "type": "string"
                 },
-                "level": {
+                "tasksCount": {
                     "type": "integer"
                 },
-                "unlockedAt": {
+                "teamId": {
                     "type": "string"
                 }
             }
         },
-        "gamificationdto.MeResponse": {
+        "historydto.RoomExportRound": {
             "type": "object",
             "properties": {
-                "achievements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/gamificationdto.AchievementResponse"
+                "dimensionStats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/roomsmodels.VoteStats"
                     }
                 },
+                "roundNumber": {
+                    "type": "integer"
+                },
                 "stats": {
-                    "$ref": "#/definitions/gamificationdto.StatsResponse"
+                    "$ref": "#/definitions/roomsmodels.VoteStats"
+                },
+                "votes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomExportVote"
+                    }
                 }
             }
         },
-        "gamificationdto.StatsResponse": {
+        "historydto.RoomExportTask": {
             "type": "object",
             "properties": {
-                "level": {
-                    "type": "integer"
-                },
-                "nextLevelXp": {
-                    "type": "integer"
+                "externalKey": {
+                    "type": "string"
                 },
-                "sessionsAdmined": {
-                    "type": "integer"
+                "finalDimensionEstimates": {
+                    "description": "FinalDimensionEstimates maps dimension keys to their final estimate.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "sessionsParticipated": {
-                    "type": "integer"
+                "finalEstimate": {
+                    "type": "string"
                 },
-                "tasksEstimated": {
-                    "type": "integer"
+                "rounds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomExportRound"
+                    }
                 },
-                "xp": {
-                    "type": "integer"
-                }
-            }
-        },
-        "health.LivenessStatus": {
-            "type": "object",
-            "properties": {
                 "status": {
                     "type": "string"
                 },
-                "uptime": {
+                "taskId": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 }
             }
         },
-        "health.ReadinessStatus": {
+        "historydto.RoomExportVote": {
             "type": "object",
             "properties": {
-                "db": {
+                "dimension": {
                     "type": "string"
                 },
-                "redis": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "participantId": {
                     "type": "string"
                 },
-                "uptime": {
+                "value": {
                     "type": "string"
                 }
             }
@@ -1175,12 +2139,23 @@ const docTemplate = `{
                 "adminUser": {
                     "$ref": "#/definitions/historydto.RoomSummaryUserRef"
                 },
+                "anonymousVoting": {
+                    "description": "AnonymousVoting means votes are listed without their participants\nunless the viewer is the room admin and the room allows it.",
+                    "type": "boolean"
+                },
                 "approxDurationSeconds": {
                     "type": "integer"
                 },
                 "createdAt": {
                     "type": "string"
                 },
+                "dimensions": {
+                    "description": "Dimensions lists the criteria of a multi-criteria room.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/roomsmodels.RoomDimension"
+                    }
+                },
                 "estimatedTasksCount": {
                     "type": "integer"
                 },
@@ -1286,12 +2261,25 @@ const docTemplate = `{
                 "externalKey": {
                     "type": "string"
                 },
+                "finalDimensionEstimates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "finalEstimateValue": {
                     "type": "string"
                 },
                 "isActive": {
                     "type": "boolean"
                 },
+                "reestimations": {
+                    "description": "Reestimations lists the outcomes the task had before it was voted on\nagain, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/historydto.RoomSummaryTaskReestimation"
+                    }
+                },
                 "roundCount": {
                     "type": "integer"
                 },
@@ -1315,12 +2303,40 @@ const docTemplate = `{
                 }
             }
         },
+        "historydto.RoomSummaryTaskReestimation": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "previousFinalEstimateValue": {
+                    "type": "string"
+                },
+                "previousStatus": {
+                    "type": "string"
+                },
+                "roundNumber": {
+                    "type": "integer"
+                }
+            }
+        },
         "historydto.RoomSummaryTaskRound": {
             "type": "object",
             "properties": {
+                "changedVotesCount": {
+                    "description": "ChangedVotesCount is how many participants changed or retracted their\nvote before the round was revealed.",
+                    "type": "integer"
+                },
                 "createdAt": {
                     "type": "string"
                 },
+                "dimensionStats": {
+                    "description": "DimensionStats holds the stats of each dimension, keyed by dimension\nkey, in multi-criteria rooms. Stats stays empty there.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/roomsmodels.VoteStats"
+                    }
+                },
                 "eligibleParticipantIds": {
                     "type": "array",
                     "items": {
@@ -1330,6 +2346,9 @@ const docTemplate = `{
                 "roundNumber": {
                     "type": "integer"
                 },
+                "stats": {
+                    "$ref": "#/definitions/roomsmodels.VoteStats"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -1370,6 +2389,9 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "dimension": {
+                    "type": "string"
+                },
                 "displayName": {
                     "type": "string"
                 },
@@ -1451,7 +2473,7 @@ const docTemplate = `{
                 }
             }
         },
-        "oauth2dto.TokenResponseDTO": {
+        "oauth2dto.Oauth2TokenResponseDTO": {
             "type": "object",
             "properties": {
                 "access_token": {
@@ -1473,6 +2495,155 @@ const docTemplate = `{
                 }
             }
         },
+        "roomsmodels.RoomDeck": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "roomsmodels.RoomDimension": {
+            "type": "object",
+            "properties": {
+                "deck": {
+                    "$ref": "#/definitions/roomsmodels.RoomDeck"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "roomsmodels.RoomOptions": {
+            "type": "object",
+            "properties": {
+                "adminSeesVotes": {
+                    "description": "AdminSeesVotes lets the room admin still see who voted what in an\nanonymous room.",
+                    "type": "boolean"
+                },
+                "anonymousVoting": {
+                    "description": "AnonymousVoting reveals only the value distribution of a round; who\nvoted what stays hidden. It cannot be turned off again.",
+                    "type": "boolean"
+                },
+                "autoReveal": {
+                    "description": "AutoReveal reveals the round as soon as every eligible participant has\nvoted.",
+                    "type": "boolean"
+                },
+                "createShareLink": {
+                    "type": "boolean"
+                },
+                "votingTimerSeconds": {
+                    "description": "VotingTimerSeconds starts a countdown with every round that reveals it\non expiry. Zero disables the timer.",
+                    "type": "integer"
+                }
+            }
+        },
+        "roomsmodels.VoteStats": {
+            "type": "object",
+            "properties": {
+                "consensus": {
+                    "type": "boolean"
+                },
+                "consensusPercent": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "mean": {
+                    "type": "number"
+                },
+                "median": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "nearestDeckValue": {
+                    "type": "string"
+                },
+                "numericVotes": {
+                    "type": "integer"
+                },
+                "spread": {
+                    "description": "Spread is the number of deck steps between the lowest and highest card.",
+                    "type": "integer"
+                },
+                "stdDev": {
+                    "type": "number"
+                }
+            }
+        },
+        "usersdto.DeleteAccountResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "usersdto.UpdateProfileDTO": {
+            "type": "object",
+            "properties": {
+                "avatarUrl": {
+                    "type": "string",
+                    "maxLength": 2000
+                },
+                "displayName": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1
+                },
+                "occupation": {
+                    "type": "string",
+                    "maxLength": 120
+                },
+                "organization": {
+                    "type": "string",
+                    "maxLength": 120
+                }
+            }
+        },
+        "usersdto.UpdateUserSettingsDTO": {
+            "type": "object",
+            "properties": {
+                "defaultDeckId": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "defaultRoomOptions": {
+                    "$ref": "#/definitions/roomsmodels.RoomOptions"
+                },
+                "locale": {
+                    "type": "string",
+                    "maxLength": 35
+                },
+                "theme": {
+                    "type": "string",
+                    "enum": [
+                        "LIGHT",
+                        "DARK",
+                        "SYSTEM"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
         "usersdto.UserResponse": {
             "type": "object",
             "properties": {
@@ -1500,10 +2671,36 @@ const docTemplate = `{
                 "lastLoginAt": {
                     "type": "string"
                 },
+                "occupation": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
                 }
             }
+        },
+        "usersdto.UserSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "defaultDeckId": {
+                    "type": "string"
+                },
+                "defaultRoomOptions": {
+                    "$ref": "#/definitions/roomsmodels.RoomOptions"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -17,6 +17,7 @@ import (
 	_ "github.com/master-bogdan/estimate-room-api/docs"
 	"github.com/master-bogdan/estimate-room-api/internal/infra/email"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/auth"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/gamification"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/health"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/history"
@@ -42,6 +43,8 @@ type AppDeps struct {
 	Router             chi.Router
 	IsGracefulShutdown *atomic.Bool
 	WsServer           ws.PubSub
+	WsEventLog         ws.EventLog
+	WsPresence         ws.Presence
 }
 
 func (deps *AppDeps) SetupApp(ctx context.Context) error {
@@ -139,7 +142,7 @@ func (deps *AppDeps) SetupApp(ctx context.Context) error {
 			TrustProxyHeaders: deps.Cfg.Server.TrustProxyHeaders,
 		})
 
-		auth.NewAuthModule(auth.AuthModuleDeps{
+		authModule := auth.NewAuthModule(auth.AuthModuleDeps{
 			Router:            r,
 			DB:                deps.DB,
 			UserService:       userService,
@@ -162,14 +165,17 @@ func (deps *AppDeps) SetupApp(ctx context.Context) error {
 			AuthService:          oauth2Module.SessionAuthService,
 			TokenKey:             deps.Cfg.Server.PasetoSymmetricKey,
 			Server:               deps.WsServer,
+			EventLog:             deps.WsEventLog,
+			Presence:             deps.WsPresence,
 			OriginPatterns:       wsOriginPatterns,
 			MessageRatePerMinute: wsRateLimitPerMinute,
 		})
 
 		users.NewUsersModule(users.UsersModuleDeps{
-			Router:      r,
-			DB:          deps.DB,
-			AuthService: oauth2Module.SessionAuthService,
+			Router:         r,
+			DB:             deps.DB,
+			AuthService:    oauth2Module.SessionAuthService,
+			SessionRevoker: authModule.Service,
 		})
 
 		invitesModule := invites.NewInvitesModule(invites.InvitesModuleDeps{
@@ -187,6 +193,12 @@ func (deps *AppDeps) SetupApp(ctx context.Context) error {
 			InvitesService: invitesModule.Service,
 		})
 
+		decks.NewDecksModule(decks.DecksModuleDeps{
+			Router:      r,
+			DB:          deps.DB,
+			AuthService: oauth2Module.SessionAuthService,
+		})
+
 		gamificationModule := gamification.NewGamificationModule(gamification.GamificationModuleDeps{
 			Router:      r,
 			DB:          deps.DB,
@@ -195,12 +207,16 @@ func (deps *AppDeps) SetupApp(ctx context.Context) error {
 		})
 
 		roomsModule := rooms.NewRoomsModule(rooms.RoomsModuleDeps{
-			Router:         r,
-			DB:             deps.DB,
-			WsService:      wsModule.Service,
-			AuthService:    oauth2Module.SessionAuthService,
-			InvitesService: invitesModule.Service,
-			RewardService:  gamificationModule.Service,
+			Router:          r,
+			DB:              deps.DB,
+			WsService:       wsModule.Service,
+			PubSub:          deps.WsServer,
+			Redis:           deps.Redis,
+			AuthService:     oauth2Module.SessionAuthService,
+			InvitesService:  invitesModule.Service,
+			RewardService:   gamificationModule.Service,
+			EmailClient:     emailClient,
+			FrontendBaseURL: frontendBaseURL,
 		})
 
 		history.NewHistoryModule(history.HistoryModuleDeps{
@@ -209,9 +225,18 @@ func (deps *AppDeps) SetupApp(ctx context.Context) error {
 			AuthService: oauth2Module.SessionAuthService,
 		})
 
+		if deps.WsPresence != nil {
+			wsModule.Service.Start(ctx)
+		}
 		if roomsModule != nil && roomsModule.ExpiryService != nil {
 			roomsModule.ExpiryService.Start(ctx)
 		}
+		if roomsModule != nil && roomsModule.TimerService != nil {
+			roomsModule.TimerService.Start(ctx)
+		}
+		if roomsModule != nil && roomsModule.AsyncService != nil {
+			roomsModule.AsyncService.Start(ctx)
+		}
 	})
 
 	return nil
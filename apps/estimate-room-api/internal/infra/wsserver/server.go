@@ -12,12 +12,20 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// Server shares one Redis subscription between all channels, so channels can
+// be added and dropped as often as clients join and leave rooms. mu only
+// guards the handlers; subMu keeps the Redis calls in the order the handlers
+// changed, so delivering messages never waits on Redis.
 type Server struct {
 	pubClient *redis.Client
 	subClient *redis.Client
 	ctx       context.Context
 	cancel    context.CancelFunc
 	wg        sync.WaitGroup
+	mu        sync.Mutex
+	subMu     sync.Mutex
+	pubsub    *redis.PubSub
+	handlers  map[string][]func([]byte)
 }
 
 type ServerDeps struct {
@@ -39,6 +47,7 @@ func NewServer(deps ServerDeps) (*Server, error) {
 		subClient: deps.SubClient,
 		ctx:       ctx,
 		cancel:    cancel,
+		handlers:  make(map[string][]func([]byte)),
 	}, nil
 }
 
@@ -50,27 +59,82 @@ func (s *Server) Subscribe(channel string, onMessage func([]byte)) {
 		return
 	}
 
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		pubsub := s.subClient.Subscribe(s.ctx, channel)
-		defer pubsub.Close()
-
-		ch := pubsub.Channel()
-		for {
-			select {
-			case <-s.ctx.Done():
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.Lock()
+	subscribed := len(s.handlers[channel]) > 0
+	s.handlers[channel] = append(s.handlers[channel], onMessage)
+	pubsub := s.pubsub
+	s.mu.Unlock()
+	if subscribed {
+		return
+	}
+
+	if pubsub == nil {
+		pubsub = s.subClient.Subscribe(s.ctx, channel)
+		s.mu.Lock()
+		s.pubsub = pubsub
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.receive(pubsub)
+		return
+	}
+
+	if err := pubsub.Subscribe(s.ctx, channel); err != nil {
+		logger.L().Error(logger.Prefix("WS", "REDIS", "Failed to subscribe channel"), "channel", channel, "err", err)
+	}
+}
+
+// Unsubscribe drops every handler of the channel and stops receiving it.
+func (s *Server) Unsubscribe(channel string) {
+	if s == nil {
+		return
+	}
+
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.Lock()
+	_, ok := s.handlers[channel]
+	delete(s.handlers, channel)
+	pubsub := s.pubsub
+	s.mu.Unlock()
+
+	if !ok || pubsub == nil {
+		return
+	}
+	if err := pubsub.Unsubscribe(s.ctx, channel); err != nil {
+		logger.L().Error(logger.Prefix("WS", "REDIS", "Failed to unsubscribe channel"), "channel", channel, "err", err)
+	}
+}
+
+func (s *Server) receive(pubsub *redis.PubSub) {
+	defer s.wg.Done()
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
 				return
-			case msg, ok := <-ch:
-				if !ok {
-					return
-				}
-				if msg != nil {
-					onMessage([]byte(msg.Payload))
-				}
+			}
+			if msg == nil {
+				continue
+			}
+
+			s.mu.Lock()
+			handlers := append([]func([]byte){}, s.handlers[msg.Channel]...)
+			s.mu.Unlock()
+
+			for _, handler := range handlers {
+				handler([]byte(msg.Payload))
 			}
 		}
-	}()
+	}
 }
 
 func (s *Server) Publish(channel string, message any) error {
@@ -0,0 +1,64 @@
+package wsserver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+)
+
+func TestServer_DeliversAfterChannelChurn(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+
+	server, err := NewServer(ServerDeps{PubClient: client, SubClient: client})
+	if err != nil {
+		t.Fatalf("failed to create server: %v", err)
+	}
+	defer server.Shutdown()
+
+	kept := "test:" + uuid.NewString()
+	churned := "test:" + uuid.NewString()
+	received := make(chan string, 4)
+	server.Subscribe(kept, func(data []byte) {
+		received <- string(data)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			server.Subscribe(churned, func([]byte) {})
+			server.Unsubscribe(churned)
+		}()
+	}
+	wg.Wait()
+
+	server.mu.Lock()
+	_, stillHandled := server.handlers[churned]
+	server.mu.Unlock()
+	if stillHandled {
+		t.Fatal("expected the churned channel to end up without handlers")
+	}
+
+	// The first subscription is confirmed asynchronously, so publish until
+	// the kept channel receives.
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if err := server.Publish(kept, "ping"); err != nil {
+			t.Fatalf("failed to publish: %v", err)
+		}
+		select {
+		case data := <-received:
+			if data != `"ping"` {
+				t.Fatalf("expected the published message, got %s", data)
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	t.Fatal("expected the kept channel to keep receiving")
+}
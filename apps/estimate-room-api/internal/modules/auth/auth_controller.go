@@ -21,6 +21,7 @@ type AuthController interface {
 	ForgotPassword(w http.ResponseWriter, r *http.Request)
 	ValidateResetPasswordToken(w http.ResponseWriter, r *http.Request)
 	ResetPassword(w http.ResponseWriter, r *http.Request)
+	ChangePassword(w http.ResponseWriter, r *http.Request)
 	Logout(w http.ResponseWriter, r *http.Request)
 	GetSession(w http.ResponseWriter, r *http.Request)
 	GithubLogin(w http.ResponseWriter, r *http.Request)
@@ -29,13 +30,19 @@ type AuthController interface {
 
 type authController struct {
 	service           AuthService
+	sessionService    oauth2.Oauth2SessionAuthService
 	logger            *slog.Logger
 	trustProxyHeaders bool
 }
 
-func NewAuthController(service AuthService, trustProxyHeaders bool) AuthController {
+func NewAuthController(
+	service AuthService,
+	sessionService oauth2.Oauth2SessionAuthService,
+	trustProxyHeaders bool,
+) AuthController {
 	return &authController{
 		service:           service,
+		sessionService:    sessionService,
 		logger:            logger.L().With(slog.String("controller", "auth")),
 		trustProxyHeaders: trustProxyHeaders,
 	}
@@ -237,6 +244,47 @@ func (c *authController) ResetPassword(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteResponse(w, authdto.ResetPasswordResponse{Reset: true})
 }
 
+// ChangePassword godoc
+// @Summary Change current user password
+// @Description Verifies the current password, stores the new one, and revokes all active sessions and tokens for the user.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param body body authdto.ChangePasswordDTO true "Current and new password"
+// @Success 200 {object} authdto.ChangePasswordResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/auth/change-password [post]
+func (c *authController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID, err := c.sessionService.CheckAuth(r)
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+		return
+	}
+
+	dto := authdto.ChangePasswordDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := c.service.ChangePassword(r.Context(), userID, &dto); err != nil {
+		c.writeAuthError(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, oauth2.ExpiredOauth2SessionCookie(r, c.trustProxyHeaders))
+	http.SetCookie(w, oauth2.ExpiredOauth2AccessTokenCookie(r, c.trustProxyHeaders))
+	http.SetCookie(w, oauth2.ExpiredOauth2RefreshTokenCookie(r, c.trustProxyHeaders))
+
+	httputils.WriteResponse(w, authdto.ChangePasswordResponse{Changed: true})
+}
+
 // Logout godoc
 // @Summary Logout current session
 // @Description Revokes the current browser session and clears auth cookies.
@@ -372,8 +420,11 @@ func (c *authController) writeAuthError(w http.ResponseWriter, r *http.Request,
 	case errors.Is(err, ErrInvalidContinueURL),
 		errors.Is(err, ErrInvalidResetToken),
 		errors.Is(err, ErrExpiredResetToken),
-		errors.Is(err, ErrUsedResetToken):
+		errors.Is(err, ErrUsedResetToken),
+		errors.Is(err, ErrInvalidCurrentPassword):
 		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+	case errors.Is(err, apperrors.ErrUserNotFound):
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
 	case errors.Is(err, ErrGithubAuthNotConfigured):
 		c.writeError(w, r, apperrors.ErrInternal, err.Error(), err)
 	default:
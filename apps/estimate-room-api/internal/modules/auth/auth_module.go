@@ -48,7 +48,7 @@ func NewAuthModule(deps AuthModuleDeps) *AuthModule {
 		OidcSessionRepo:        oidcSessionRepo,
 		Github:                 deps.Github,
 	})
-	controller := NewAuthController(service, deps.TrustProxyHeaders)
+	controller := NewAuthController(service, deps.SessionService, deps.TrustProxyHeaders)
 
 	deps.Router.Route("/auth", func(r chi.Router) {
 		r.Post("/login", controller.Login)
@@ -56,6 +56,7 @@ func NewAuthModule(deps AuthModuleDeps) *AuthModule {
 		r.Post("/forgot-password", controller.ForgotPassword)
 		r.Get("/reset-password/validate", controller.ValidateResetPasswordToken)
 		r.Post("/reset-password", controller.ResetPassword)
+		r.Post("/change-password", controller.ChangePassword)
 		r.Post("/logout", controller.Logout)
 		r.Get("/session", controller.GetSession)
 		r.Get("/github/login", controller.GithubLogin)
@@ -38,6 +38,7 @@ var (
 	ErrUsedResetToken             = errors.New("used reset token")
 	ErrGithubAuthNotConfigured    = errors.New("github oauth is not configured")
 	ErrGithubAuthenticationFailed = errors.New("github authentication failed")
+	ErrInvalidCurrentPassword     = errors.New("invalid current password")
 )
 
 type AuthService interface {
@@ -46,6 +47,8 @@ type AuthService interface {
 	ForgotPassword(ctx context.Context, dto *authdto.ForgotPasswordDTO) error
 	ValidateResetPasswordToken(ctx context.Context, token string) (bool, string, error)
 	ResetPassword(ctx context.Context, dto *authdto.ResetPasswordDTO) error
+	ChangePassword(ctx context.Context, userID string, dto *authdto.ChangePasswordDTO) error
+	RevokeAllUserSessions(ctx context.Context, userID string) error
 	GetSession(r *http.Request) (*usersmodels.UserModel, bool, error)
 	Logout(ctx context.Context, sessionID string) error
 	StartGithubLogin(continueURL string) (string, error)
@@ -314,6 +317,34 @@ func (s *authService) ResetPassword(ctx context.Context, dto *authdto.ResetPassw
 	return s.revokeAllUserSessions(ctx, resetToken.UserID)
 }
 
+// ChangePassword re-verifies the current password before storing the new one
+// and then revokes every session and token of the user, including the caller's.
+func (s *authService) ChangePassword(ctx context.Context, userID string, dto *authdto.ChangePasswordDTO) error {
+	user, err := s.userService.GetCurrentUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if user.PasswordHash == nil || *user.PasswordHash == "" || !utils.CheckPasswordHash(dto.CurrentPassword, *user.PasswordHash) {
+		return ErrInvalidCurrentPassword
+	}
+
+	passwordHash, err := utils.HashPassword(dto.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := s.userService.UpdatePasswordHash(userID, passwordHash); err != nil {
+		return err
+	}
+
+	return s.revokeAllUserSessions(ctx, userID)
+}
+
+func (s *authService) RevokeAllUserSessions(ctx context.Context, userID string) error {
+	return s.revokeAllUserSessions(ctx, userID)
+}
+
 func (s *authService) GetSession(r *http.Request) (*usersmodels.UserModel, bool, error) {
 	sessionID := oauth2.ReadOauth2SessionID(r)
 	if sessionID == "" {
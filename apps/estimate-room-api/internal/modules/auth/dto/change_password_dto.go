@@ -0,0 +1,13 @@
+package authdto
+
+import "github.com/go-playground/validator/v10"
+
+type ChangePasswordDTO struct {
+	CurrentPassword string `json:"currentPassword" validate:"required,max=128"`
+	NewPassword     string `json:"newPassword" validate:"required,min=8,max=128"`
+}
+
+func (s *ChangePasswordDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
@@ -22,6 +22,10 @@ type ResetPasswordResponse struct {
 	Reset bool `json:"reset"`
 }
 
+type ChangePasswordResponse struct {
+	Changed bool `json:"changed"`
+}
+
 type ResetPasswordValidationResponse struct {
 	Valid  bool   `json:"valid"`
 	Reason string `json:"reason,omitempty"`
@@ -22,6 +22,7 @@ import (
 	"github.com/master-bogdan/estimate-room-api/internal/modules/users"
 	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
 	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/utils"
 	"github.com/uptrace/bun"
 )
 
@@ -579,6 +580,79 @@ func TestResetPassword_RevokesExistingSession(t *testing.T) {
 	}
 }
 
+func TestChangePassword_VerifiesCurrentPasswordAndRevokesSessions(t *testing.T) {
+	router, db, clientID, _ := setupAuthTest(t)
+	defer db.Close()
+
+	userID := testutils.SeedUser(t, db, "change@example.com", "password123")
+	sessionID := testutils.SeedSession(t, db, userID, clientID, "nonce-change-password")
+	tokens, err := testutils.NewOauth2Service(db).GenerateTokenPair(context.Background(), userID, clientID, sessionID, []string{"user"})
+	if err != nil {
+		t.Fatalf("failed to generate token pair: %v", err)
+	}
+
+	wrongReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/change-password", strings.NewReader(`{
+		"currentPassword":"wrong-password",
+		"newPassword":"newpassword123"
+	}`))
+	wrongReq.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	wrongRR := httptest.NewRecorder()
+	router.ServeHTTP(wrongRR, wrongReq)
+
+	if wrongRR.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request for wrong current password, got %d", wrongRR.Code)
+	}
+
+	changeReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/change-password", strings.NewReader(`{
+		"currentPassword":"password123",
+		"newPassword":"newpassword123"
+	}`))
+	changeReq.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	changeRR := httptest.NewRecorder()
+	router.ServeHTTP(changeRR, changeReq)
+
+	if changeRR.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", changeRR.Code, changeRR.Body.String())
+	}
+
+	user, err := usersrepositories.NewUserRepository(db).FindByID(userID)
+	if err != nil {
+		t.Fatalf("failed to load user: %v", err)
+	}
+	if user.PasswordHash == nil || !utils.CheckPasswordHash("newpassword123", *user.PasswordHash) {
+		t.Fatal("expected new password hash to be stored")
+	}
+
+	sessionReq := httptest.NewRequest(http.MethodGet, "/api/v1/auth/session", nil)
+	sessionReq.AddCookie(&http.Cookie{Name: oauth2.Oauth2SessionCookieName, Value: sessionID})
+
+	sessionRR := httptest.NewRecorder()
+	router.ServeHTTP(sessionRR, sessionReq)
+
+	var response authdto.SessionResponse
+	if err := json.NewDecoder(sessionRR.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode session response: %v", err)
+	}
+	if response.Authenticated {
+		t.Fatalf("expected password change to revoke existing session, got %#v", response)
+	}
+
+	repeatReq := httptest.NewRequest(http.MethodPost, "/api/v1/auth/change-password", strings.NewReader(`{
+		"currentPassword":"newpassword123",
+		"newPassword":"anotherpassword123"
+	}`))
+	repeatReq.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+
+	repeatRR := httptest.NewRecorder()
+	router.ServeHTTP(repeatRR, repeatReq)
+
+	if repeatRR.Code != http.StatusUnauthorized {
+		t.Fatalf("expected revoked access token to return 401, got %d", repeatRR.Code)
+	}
+}
+
 func seedResetToken(
 	t *testing.T,
 	db *bun.DB,
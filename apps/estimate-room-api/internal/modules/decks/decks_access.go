@@ -0,0 +1,100 @@
+package decks
+
+import (
+	"context"
+	"errors"
+
+	decksmodels "github.com/master-bogdan/estimate-room-api/internal/modules/decks/models"
+	decksrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/decks/repositories"
+	teamsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/teams/models"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+)
+
+// ensureDeckReadable allows system decks to everyone, personal decks to their
+// owner and team decks to any team member.
+func ensureDeckReadable(
+	ctx context.Context,
+	deckRepo decksrepositories.DeckRepository,
+	memberRepo teamsrepositories.TeamMemberRepository,
+	deckID, userID string,
+) (*decksmodels.DeckModel, error) {
+	deck, err := deckRepo.FindByID(ctx, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch deck.Scope() {
+	case decksmodels.DeckScopeSystem:
+		return deck, nil
+	case decksmodels.DeckScopeUser:
+		if *deck.OwnerUserID != userID {
+			return nil, apperrors.ErrForbidden
+		}
+		return deck, nil
+	default:
+		if _, err := memberRepo.FindByTeamAndUser(*deck.TeamID, userID); err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return nil, apperrors.ErrForbidden
+			}
+			return nil, err
+		}
+		return deck, nil
+	}
+}
+
+// ensureDeckManageable allows personal decks to be changed by their owner and
+// team decks by the team owner. System decks are read-only.
+func ensureDeckManageable(
+	ctx context.Context,
+	deckRepo decksrepositories.DeckRepository,
+	teamRepo teamsrepositories.TeamRepository,
+	memberRepo teamsrepositories.TeamMemberRepository,
+	deckID, userID string,
+) (*decksmodels.DeckModel, error) {
+	deck, err := deckRepo.FindByID(ctx, deckID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch deck.Scope() {
+	case decksmodels.DeckScopeSystem:
+		return nil, apperrors.ErrForbidden
+	case decksmodels.DeckScopeUser:
+		if *deck.OwnerUserID != userID {
+			return nil, apperrors.ErrForbidden
+		}
+		return deck, nil
+	default:
+		if _, err := ensureTeamOwner(teamRepo, memberRepo, *deck.TeamID, userID); err != nil {
+			return nil, err
+		}
+		return deck, nil
+	}
+}
+
+func ensureTeamOwner(
+	teamRepo teamsrepositories.TeamRepository,
+	memberRepo teamsrepositories.TeamMemberRepository,
+	teamID, actorUserID string,
+) (*teamsmodels.TeamModel, error) {
+	team, err := teamRepo.FindByID(teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	actorMember, err := memberRepo.FindByTeamAndUser(teamID, actorUserID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	if actorMember.Role != teamsmodels.TeamMemberRoleOwner || team.OwnerUserID != actorUserID {
+		return nil, apperrors.ErrForbidden
+	}
+
+	return team, nil
+}
@@ -0,0 +1,265 @@
+package decks
+
+import (
+	"encoding/json"
+	stdErrors "errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	decksdto "github.com/master-bogdan/estimate-room-api/internal/modules/decks/dto"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/httputils"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+)
+
+type DecksController interface {
+	CreateDeck(w http.ResponseWriter, r *http.Request)
+	ListDecks(w http.ResponseWriter, r *http.Request)
+	GetDeck(w http.ResponseWriter, r *http.Request)
+	UpdateDeck(w http.ResponseWriter, r *http.Request)
+	DeleteDeck(w http.ResponseWriter, r *http.Request)
+}
+
+type decksController struct {
+	service     DecksService
+	authService oauth2.Oauth2SessionAuthService
+	logger      *slog.Logger
+}
+
+func NewDecksController(service DecksService, authService oauth2.Oauth2SessionAuthService) DecksController {
+	return &decksController{
+		service:     service,
+		authService: authService,
+		logger:      logger.L().With(slog.String("controller", "decks")),
+	}
+}
+
+// CreateDeck godoc
+// @Summary Create deck
+// @Description Creates a personal deck, or a team deck when teamId is set and the caller owns the team.
+// @Tags decks
+// @Accept json
+// @Produce json
+// @Param request body decksdto.CreateDeckDTO true "Deck"
+// @Success 200 {object} decksdto.DeckResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/decks [post]
+func (c *decksController) CreateDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	dto := decksdto.CreateDeckDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	var teamID *string
+	if dto.TeamID != "" {
+		teamID = &dto.TeamID
+	}
+
+	deck, err := c.service.CreateDeck(r.Context(), CreateDeckInput{
+		Name:        dto.Name,
+		Kind:        dto.Kind,
+		Values:      dto.Values,
+		OwnerUserID: userID,
+		TeamID:      teamID,
+	})
+	if err != nil {
+		c.writeDeckError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, decksdto.NewDeckResponse(deck))
+}
+
+// ListDecks godoc
+// @Summary List decks
+// @Description Returns system decks, the caller's personal decks and decks of the caller's teams.
+// @Tags decks
+// @Produce json
+// @Success 200 {array} decksdto.DeckResponse
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/decks [get]
+func (c *decksController) ListDecks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	decks, err := c.service.ListDecks(r.Context(), userID)
+	if err != nil {
+		c.writeDeckError(w, r, err)
+		return
+	}
+
+	response := make([]decksdto.DeckResponse, 0, len(decks))
+	for _, deck := range decks {
+		response = append(response, decksdto.NewDeckResponse(deck))
+	}
+
+	httputils.WriteResponse(w, response)
+}
+
+// GetDeck godoc
+// @Summary Get deck
+// @Description Returns a deck visible to the caller.
+// @Tags decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Success 200 {object} decksdto.DeckResponse
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/decks/{id} [get]
+func (c *decksController) GetDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	deck, err := c.service.GetDeck(r.Context(), chi.URLParam(r, "id"), userID)
+	if err != nil {
+		c.writeDeckError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, decksdto.NewDeckResponse(deck))
+}
+
+// UpdateDeck godoc
+// @Summary Update deck
+// @Description Updates a personal deck or, for team owners, a team deck. System decks are read-only.
+// @Tags decks
+// @Accept json
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Param request body decksdto.UpdateDeckDTO true "Deck changes"
+// @Success 200 {object} decksdto.DeckResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/decks/{id} [patch]
+func (c *decksController) UpdateDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	dto := decksdto.UpdateDeckDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	deck, err := c.service.UpdateDeck(r.Context(), chi.URLParam(r, "id"), userID, UpdateDeckInput{
+		Name:   dto.Name,
+		Kind:   dto.Kind,
+		Values: dto.Values,
+	})
+	if err != nil {
+		c.writeDeckError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, decksdto.NewDeckResponse(deck))
+}
+
+// DeleteDeck godoc
+// @Summary Delete deck
+// @Description Deletes a personal deck or, for team owners, a team deck. Rooms keep their own deck copy.
+// @Tags decks
+// @Produce json
+// @Param id path string true "Deck ID"
+// @Success 200 {object} map[string]bool
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/decks/{id} [delete]
+func (c *decksController) DeleteDeck(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.service.DeleteDeck(r.Context(), chi.URLParam(r, "id"), userID); err != nil {
+		c.writeDeckError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, map[string]bool{"ok": true})
+}
+
+func (c *decksController) writeDeckError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case stdErrors.Is(err, apperrors.ErrBadRequest):
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrUnauthorized):
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrForbidden):
+		c.writeError(w, r, apperrors.ErrForbidden, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrNotFound):
+		c.writeError(w, r, apperrors.ErrNotFound, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrConflict):
+		c.writeError(w, r, apperrors.ErrConflict, err.Error(), err)
+	default:
+		c.writeError(w, r, apperrors.ErrInternal, "", err)
+	}
+}
+
+func (c *decksController) writeError(w http.ResponseWriter, r *http.Request, errType error, detail string, cause error) {
+	logArgs := []any{
+		"path", r.URL.Path,
+		"type", errType.Error(),
+	}
+	if detail != "" {
+		logArgs = append(logArgs, "detail", detail)
+	}
+	if cause != nil {
+		logArgs = append(logArgs, "err", cause)
+	}
+
+	logger.FromRequest(r, c.logger).Error("request failed", logArgs...)
+
+	httputils.WriteResponseError(w, apperrors.CreateHttpError(
+		errType,
+		apperrors.HttpError{
+			Detail:   detail,
+			Instance: r.URL.Path,
+		},
+	))
+}
+
+func (c *decksController) requireUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, err := c.authService.CheckAuth(r)
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+		return "", false
+	}
+
+	return userID, true
+}
@@ -0,0 +1,42 @@
+// Package decks provides estimation deck endpoints.
+package decks
+
+import (
+	"github.com/go-chi/chi/v5"
+	decksrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/decks/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
+	"github.com/uptrace/bun"
+)
+
+type DecksModule struct {
+	Controller DecksController
+	Service    DecksService
+}
+
+type DecksModuleDeps struct {
+	Router      chi.Router
+	DB          *bun.DB
+	AuthService oauth2.Oauth2SessionAuthService
+}
+
+func NewDecksModule(deps DecksModuleDeps) *DecksModule {
+	deckRepo := decksrepositories.NewDeckRepository(deps.DB)
+	teamRepo := teamsrepositories.NewTeamRepository(deps.DB)
+	memberRepo := teamsrepositories.NewTeamMemberRepository(deps.DB)
+	svc := NewDecksService(deckRepo, teamRepo, memberRepo)
+	ctrl := NewDecksController(svc, deps.AuthService)
+
+	deps.Router.Route("/decks", func(r chi.Router) {
+		r.Post("/", ctrl.CreateDeck)
+		r.Get("/", ctrl.ListDecks)
+		r.Get("/{id}", ctrl.GetDeck)
+		r.Patch("/{id}", ctrl.UpdateDeck)
+		r.Delete("/{id}", ctrl.DeleteDeck)
+	})
+
+	return &DecksModule{
+		Controller: ctrl,
+		Service:    svc,
+	}
+}
@@ -0,0 +1,209 @@
+package decks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	decksmodels "github.com/master-bogdan/estimate-room-api/internal/modules/decks/models"
+	decksrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/decks/repositories"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+)
+
+type DecksService interface {
+	CreateDeck(ctx context.Context, input CreateDeckInput) (*decksmodels.DeckModel, error)
+	ListDecks(ctx context.Context, userID string) ([]*decksmodels.DeckModel, error)
+	GetDeck(ctx context.Context, deckID, userID string) (*decksmodels.DeckModel, error)
+	UpdateDeck(ctx context.Context, deckID, userID string, input UpdateDeckInput) (*decksmodels.DeckModel, error)
+	DeleteDeck(ctx context.Context, deckID, userID string) error
+	ResolveRoomDeck(ctx context.Context, deckID, userID string) (roomsmodels.RoomDeck, error)
+}
+
+type CreateDeckInput struct {
+	Name        string
+	Kind        string
+	Values      []string
+	OwnerUserID string
+	TeamID      *string
+}
+
+type UpdateDeckInput struct {
+	Name   *string
+	Kind   *string
+	Values []string
+}
+
+type decksService struct {
+	deckRepo   decksrepositories.DeckRepository
+	teamRepo   teamsrepositories.TeamRepository
+	memberRepo teamsrepositories.TeamMemberRepository
+	logger     *slog.Logger
+}
+
+func NewDecksService(
+	deckRepo decksrepositories.DeckRepository,
+	teamRepo teamsrepositories.TeamRepository,
+	memberRepo teamsrepositories.TeamMemberRepository,
+) DecksService {
+	return &decksService{
+		deckRepo:   deckRepo,
+		teamRepo:   teamRepo,
+		memberRepo: memberRepo,
+		logger:     logger.L().With(slog.String("service", "decks")),
+	}
+}
+
+func (s *decksService) CreateDeck(ctx context.Context, input CreateDeckInput) (*decksmodels.DeckModel, error) {
+	deck, err := normalizeDeck(roomsmodels.RoomDeck{
+		Name:   input.Name,
+		Kind:   input.Kind,
+		Values: input.Values,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	model := &decksmodels.DeckModel{
+		DeckID: uuid.NewString(),
+		Name:   deck.Name,
+		Kind:   deck.Kind,
+		Values: deck.Values,
+	}
+
+	if teamID := normalizeOptionalString(input.TeamID); teamID != "" {
+		if _, err := ensureTeamOwner(s.teamRepo, s.memberRepo, teamID, input.OwnerUserID); err != nil {
+			return nil, err
+		}
+		model.TeamID = &teamID
+	} else {
+		ownerUserID := input.OwnerUserID
+		model.OwnerUserID = &ownerUserID
+	}
+
+	created, err := s.deckRepo.Create(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(decksServiceLog("Deck created"), "deck_id", created.DeckID, "user_id", input.OwnerUserID, "team_id", created.TeamID)
+
+	return created, nil
+}
+
+func (s *decksService) ListDecks(ctx context.Context, userID string) ([]*decksmodels.DeckModel, error) {
+	return s.deckRepo.ListAccessibleByUserID(ctx, userID)
+}
+
+func (s *decksService) GetDeck(ctx context.Context, deckID, userID string) (*decksmodels.DeckModel, error) {
+	return ensureDeckReadable(ctx, s.deckRepo, s.memberRepo, deckID, userID)
+}
+
+func (s *decksService) UpdateDeck(
+	ctx context.Context,
+	deckID, userID string,
+	input UpdateDeckInput,
+) (*decksmodels.DeckModel, error) {
+	current, err := ensureDeckManageable(ctx, s.deckRepo, s.teamRepo, s.memberRepo, deckID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	next := current.RoomDeck()
+	if input.Name != nil {
+		next.Name = *input.Name
+	}
+	if input.Kind != nil {
+		next.Kind = *input.Kind
+	}
+	if input.Values != nil {
+		next.Values = input.Values
+	}
+
+	deck, err := normalizeDeck(next)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.deckRepo.Update(ctx, deckID, decksrepositories.UpdateDeckFields{
+		Name:   &deck.Name,
+		Kind:   &deck.Kind,
+		Values: deck.Values,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(decksServiceLog("Deck updated"), "deck_id", deckID, "user_id", userID)
+
+	return updated, nil
+}
+
+func (s *decksService) DeleteDeck(ctx context.Context, deckID, userID string) error {
+	if _, err := ensureDeckManageable(ctx, s.deckRepo, s.teamRepo, s.memberRepo, deckID, userID); err != nil {
+		return err
+	}
+
+	if err := s.deckRepo.Delete(ctx, deckID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(decksServiceLog("Deck deleted"), "deck_id", deckID, "user_id", userID)
+
+	return nil
+}
+
+// ResolveRoomDeck loads a deck the user can read and returns a copy suitable
+// for storing on a room. Rooms keep their own snapshot, so later deck edits
+// never change a running session.
+func (s *decksService) ResolveRoomDeck(ctx context.Context, deckID, userID string) (roomsmodels.RoomDeck, error) {
+	deck, err := ensureDeckReadable(ctx, s.deckRepo, s.memberRepo, strings.TrimSpace(deckID), userID)
+	if err != nil {
+		return roomsmodels.RoomDeck{}, err
+	}
+
+	return deck.RoomDeck(), nil
+}
+
+func normalizeDeck(deck roomsmodels.RoomDeck) (roomsmodels.RoomDeck, error) {
+	deck.Name = strings.TrimSpace(deck.Name)
+	deck.Kind = strings.TrimSpace(deck.Kind)
+
+	seen := make(map[string]struct{}, len(deck.Values))
+	values := make([]string, 0, len(deck.Values))
+	for _, value := range deck.Values {
+		trimmedValue := strings.TrimSpace(value)
+		if trimmedValue == "" {
+			continue
+		}
+		if _, exists := seen[trimmedValue]; exists {
+			return roomsmodels.RoomDeck{}, fmt.Errorf("%w: duplicate deck value %q", apperrors.ErrBadRequest, trimmedValue)
+		}
+
+		seen[trimmedValue] = struct{}{}
+		values = append(values, trimmedValue)
+	}
+	deck.Values = values
+
+	if !deck.IsValid() {
+		return roomsmodels.RoomDeck{}, fmt.Errorf("%w: invalid deck", apperrors.ErrBadRequest)
+	}
+
+	return deck, nil
+}
+
+func normalizeOptionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(*value)
+}
+
+func decksServiceLog(message string) string {
+	return logger.Prefix("MODULE", "DECKS", message)
+}
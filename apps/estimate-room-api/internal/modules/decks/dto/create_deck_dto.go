@@ -0,0 +1,16 @@
+// Package decksdto is a collection of decks dtos
+package decksdto
+
+import "github.com/go-playground/validator/v10"
+
+type CreateDeckDTO struct {
+	Name   string   `json:"name" validate:"required,min=1,max=50"`
+	Kind   string   `json:"kind" validate:"required,min=1,max=30"`
+	Values []string `json:"values" validate:"required,min=1,max=50,dive,required,max=20"`
+	TeamID string   `json:"teamId" validate:"omitempty,max=100"`
+}
+
+func (s *CreateDeckDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
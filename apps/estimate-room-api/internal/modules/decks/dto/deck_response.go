@@ -0,0 +1,47 @@
+package decksdto
+
+import (
+	"time"
+
+	decksmodels "github.com/master-bogdan/estimate-room-api/internal/modules/decks/models"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+type DeckValueResponse struct {
+	Value string `json:"value"`
+	Type  string `json:"type"`
+}
+
+type DeckResponse struct {
+	DeckID      string              `json:"deckId"`
+	Name        string              `json:"name"`
+	Kind        string              `json:"kind"`
+	Scope       string              `json:"scope"`
+	OwnerUserID *string             `json:"ownerUserId"`
+	TeamID      *string             `json:"teamId"`
+	Values      []DeckValueResponse `json:"values"`
+	CreatedAt   time.Time           `json:"createdAt"`
+	UpdatedAt   time.Time           `json:"updatedAt"`
+}
+
+func NewDeckResponse(deck *decksmodels.DeckModel) DeckResponse {
+	values := make([]DeckValueResponse, 0, len(deck.Values))
+	for _, value := range deck.Values {
+		values = append(values, DeckValueResponse{
+			Value: value,
+			Type:  roomsmodels.DeckValueType(value),
+		})
+	}
+
+	return DeckResponse{
+		DeckID:      deck.DeckID,
+		Name:        deck.Name,
+		Kind:        deck.Kind,
+		Scope:       string(deck.Scope()),
+		OwnerUserID: deck.OwnerUserID,
+		TeamID:      deck.TeamID,
+		Values:      values,
+		CreatedAt:   deck.CreatedAt,
+		UpdatedAt:   deck.UpdatedAt,
+	}
+}
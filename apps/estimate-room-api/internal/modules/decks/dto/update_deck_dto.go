@@ -0,0 +1,14 @@
+package decksdto
+
+import "github.com/go-playground/validator/v10"
+
+type UpdateDeckDTO struct {
+	Name   *string  `json:"name" validate:"omitempty,min=1,max=50"`
+	Kind   *string  `json:"kind" validate:"omitempty,min=1,max=30"`
+	Values []string `json:"values" validate:"omitempty,min=1,max=50,dive,required,max=20"`
+}
+
+func (s *UpdateDeckDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
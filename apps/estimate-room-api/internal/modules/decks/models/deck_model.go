@@ -0,0 +1,51 @@
+package decksmodels
+
+import (
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/uptrace/bun"
+)
+
+type DeckScope string
+
+const (
+	DeckScopeSystem DeckScope = "SYSTEM"
+	DeckScopeUser   DeckScope = "USER"
+	DeckScopeTeam   DeckScope = "TEAM"
+)
+
+type DeckModel struct {
+	bun.BaseModel `bun:"table:decks,alias:d"`
+
+	DeckID      string    `bun:"deck_id,pk"`
+	Name        string    `bun:"name"`
+	Kind        string    `bun:"kind"`
+	Values      []string  `bun:"values,type:jsonb"`
+	OwnerUserID *string   `bun:"owner_user_id"`
+	TeamID      *string   `bun:"team_id"`
+	CreatedAt   time.Time `bun:"created_at"`
+	UpdatedAt   time.Time `bun:"updated_at"`
+}
+
+func (m *DeckModel) Scope() DeckScope {
+	switch {
+	case m.TeamID != nil:
+		return DeckScopeTeam
+	case m.OwnerUserID != nil:
+		return DeckScopeUser
+	default:
+		return DeckScopeSystem
+	}
+}
+
+func (m *DeckModel) RoomDeck() roomsmodels.RoomDeck {
+	values := make([]string, len(m.Values))
+	copy(values, m.Values)
+
+	return roomsmodels.RoomDeck{
+		Name:   m.Name,
+		Kind:   m.Kind,
+		Values: values,
+	}
+}
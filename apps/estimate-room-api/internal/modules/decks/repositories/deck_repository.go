@@ -0,0 +1,173 @@
+package decksrepositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	decksmodels "github.com/master-bogdan/estimate-room-api/internal/modules/decks/models"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/uptrace/bun"
+)
+
+type UpdateDeckFields struct {
+	Name   *string
+	Kind   *string
+	Values []string
+}
+
+type DeckRepository interface {
+	Create(ctx context.Context, model *decksmodels.DeckModel) (*decksmodels.DeckModel, error)
+	FindByID(ctx context.Context, deckID string) (*decksmodels.DeckModel, error)
+	ListAccessibleByUserID(ctx context.Context, userID string) ([]*decksmodels.DeckModel, error)
+	Update(ctx context.Context, deckID string, fields UpdateDeckFields) (*decksmodels.DeckModel, error)
+	Delete(ctx context.Context, deckID string) error
+}
+
+type deckRepository struct {
+	db bun.IDB
+}
+
+func NewDeckRepository(db bun.IDB) DeckRepository {
+	return &deckRepository{db: db}
+}
+
+func (r *deckRepository) Create(ctx context.Context, model *decksmodels.DeckModel) (*decksmodels.DeckModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		Column("deck_id", "name", "kind", "values", "owner_user_id", "team_id").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (r *deckRepository) FindByID(ctx context.Context, deckID string) (*decksmodels.DeckModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	deck := new(decksmodels.DeckModel)
+	err := r.db.NewSelect().
+		Model(deck).
+		Where("d.deck_id = ?", deckID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return deck, nil
+}
+
+// ListAccessibleByUserID returns system decks, decks owned by the user and
+// decks of every team the user belongs to.
+func (r *deckRepository) ListAccessibleByUserID(ctx context.Context, userID string) ([]*decksmodels.DeckModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	decks := make([]*decksmodels.DeckModel, 0)
+	err := r.db.NewSelect().
+		Model(&decks).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("d.owner_user_id IS NULL").
+						Where("d.team_id IS NULL")
+				}).
+				WhereOr("d.owner_user_id = ?", userID).
+				WhereOr("d.team_id IN (SELECT tm.team_id FROM team_members AS tm WHERE tm.user_id = ?)", userID)
+		}).
+		OrderExpr("(d.owner_user_id IS NULL AND d.team_id IS NULL) DESC").
+		OrderExpr("d.created_at ASC").
+		OrderExpr("d.deck_id ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return decks, nil
+}
+
+func (r *deckRepository) Update(
+	ctx context.Context,
+	deckID string,
+	fields UpdateDeckFields,
+) (*decksmodels.DeckModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := r.db.NewUpdate().
+		Model((*decksmodels.DeckModel)(nil)).
+		Set("updated_at = NOW()").
+		Where("deck_id = ?", deckID)
+
+	if fields.Name != nil {
+		query = query.Set("name = ?", *fields.Name)
+	}
+	if fields.Kind != nil {
+		query = query.Set("kind = ?", *fields.Kind)
+	}
+	if fields.Values != nil {
+		values, err := json.Marshal(fields.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		query = query.Set("values = ?::jsonb", string(values))
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, apperrors.ErrNotFound
+	}
+
+	return r.FindByID(ctx, deckID)
+}
+
+func (r *deckRepository) Delete(ctx context.Context, deckID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := r.db.NewDelete().
+		Model((*decksmodels.DeckModel)(nil)).
+		Where("deck_id = ?", deckID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return apperrors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,332 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
+	decksdto "github.com/master-bogdan/estimate-room-api/internal/modules/decks/dto"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+	"github.com/uptrace/bun"
+)
+
+func setupDecksTest(t *testing.T) (*chi.Mux, *bun.DB) {
+	t.Helper()
+
+	db := testutils.SetupTestDB(t)
+
+	_, err := db.ExecContext(context.Background(), `
+		TRUNCATE TABLE
+			decks,
+			team_members,
+			teams,
+			oauth2_access_tokens,
+			oauth2_refresh_tokens,
+			oauth2_auth_codes,
+			oauth2_oidc_sessions,
+			users,
+			oauth2_clients
+		RESTART IDENTITY CASCADE
+	`)
+	if err != nil {
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+
+	router := chi.NewRouter()
+	authService := oauth2.NewOauth2SessionAuthServiceFromDB(testutils.TestTokenKey, db)
+
+	router.Route("/api/v1", func(r chi.Router) {
+		decks.NewDecksModule(decks.DecksModuleDeps{
+			Router:      r,
+			DB:          db,
+			AuthService: authService,
+		})
+	})
+
+	return router, db
+}
+
+func createDecksAccessToken(t *testing.T, db *bun.DB, email string) (string, string) {
+	t.Helper()
+
+	redirectURI := "http://localhost:4081"
+	clientID := testutils.SeedClient(t, db, redirectURI, []string{"user"})
+	userID := testutils.SeedUser(t, db, email, "password123")
+	sessionID := testutils.SeedSession(t, db, userID, clientID, "nonce-decks")
+
+	svc := testutils.NewOauth2Service(db)
+	tokens, err := svc.GenerateTokenPair(context.Background(), userID, clientID, sessionID, []string{"user"})
+	if err != nil {
+		t.Fatalf("failed to generate token pair: %v", err)
+	}
+
+	return tokens.AccessToken, userID
+}
+
+func seedSystemDeck(t *testing.T, db *bun.DB, deckID string) {
+	t.Helper()
+
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO decks (deck_id, name, kind, values)
+		VALUES ($1, 'Fibonacci', 'FIBONACCI', '["1","2","3","?"]'::jsonb)
+	`, deckID)
+	if err != nil {
+		t.Fatalf("failed to insert system deck: %v", err)
+	}
+}
+
+func seedDeckTeam(t *testing.T, db *bun.DB, ownerUserID string) string {
+	t.Helper()
+
+	teamID := uuid.NewString()
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO teams (team_id, name, owner_user_id)
+		VALUES ($1, 'Deck Team', $2)
+	`, teamID, ownerUserID)
+	if err != nil {
+		t.Fatalf("failed to insert team: %v", err)
+	}
+
+	seedDeckTeamMember(t, db, teamID, ownerUserID, "OWNER")
+
+	return teamID
+}
+
+func seedDeckTeamMember(t *testing.T, db *bun.DB, teamID, userID, role string) {
+	t.Helper()
+
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO team_members (team_id, user_id, role)
+		VALUES ($1, $2, $3)
+	`, teamID, userID, role)
+	if err != nil {
+		t.Fatalf("failed to insert team member: %v", err)
+	}
+}
+
+func doDecksRequest(t *testing.T, router *chi.Mux, method, path, accessToken, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func decodeDeckResponse(t *testing.T, rr *httptest.ResponseRecorder) decksdto.DeckResponse {
+	t.Helper()
+
+	var response decksdto.DeckResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode deck response: %v", err)
+	}
+
+	return response
+}
+
+func TestCreateDeck_CreatesPersonalDeckWithTypedValues(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createDecksAccessToken(t, db, "owner@example.com")
+
+	rr := doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", accessToken, `{
+		"name":" Hours ",
+		"kind":"CUSTOM",
+		"values":["0.5","1"," 2 ","?","coffee"]
+	}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	response := decodeDeckResponse(t, rr)
+	if response.DeckID == "" {
+		t.Fatal("expected deck id")
+	}
+	if response.Name != "Hours" {
+		t.Fatalf("expected trimmed deck name, got %q", response.Name)
+	}
+	if response.Scope != "USER" {
+		t.Fatalf("expected USER scope, got %s", response.Scope)
+	}
+	if response.OwnerUserID == nil || *response.OwnerUserID != userID {
+		t.Fatalf("expected owner user id %s, got %v", userID, response.OwnerUserID)
+	}
+
+	expected := []decksdto.DeckValueResponse{
+		{Value: "0.5", Type: roomsmodels.DeckValueTypeNumeric},
+		{Value: "1", Type: roomsmodels.DeckValueTypeNumeric},
+		{Value: "2", Type: roomsmodels.DeckValueTypeNumeric},
+		{Value: "?", Type: roomsmodels.DeckValueTypeNonNumeric},
+		{Value: "coffee", Type: roomsmodels.DeckValueTypeNonNumeric},
+	}
+	if len(response.Values) != len(expected) {
+		t.Fatalf("expected %d values, got %d", len(expected), len(response.Values))
+	}
+	for i, value := range expected {
+		if response.Values[i] != value {
+			t.Fatalf("expected value %d to be %+v, got %+v", i, value, response.Values[i])
+		}
+	}
+}
+
+func TestCreateDeck_RejectsDuplicateValues(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	accessToken, _ := createDecksAccessToken(t, db, "owner@example.com")
+
+	rr := doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", accessToken, `{
+		"name":"Dupes",
+		"kind":"CUSTOM",
+		"values":["1","1"]
+	}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCreateDeck_TeamDeckRequiresTeamOwner(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	ownerToken, ownerUserID := createDecksAccessToken(t, db, "owner@example.com")
+	memberToken, memberUserID := createDecksAccessToken(t, db, "member@example.com")
+	teamID := seedDeckTeam(t, db, ownerUserID)
+	seedDeckTeamMember(t, db, teamID, memberUserID, "MEMBER")
+
+	body := `{"name":"Team Deck","kind":"CUSTOM","values":["S","M","L"],"teamId":"` + teamID + `"}`
+
+	rr := doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", memberToken, body)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden for team member, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", ownerToken, body)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for team owner, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	created := decodeDeckResponse(t, rr)
+	if created.Scope != "TEAM" || created.TeamID == nil || *created.TeamID != teamID {
+		t.Fatalf("expected team deck for %s, got %+v", teamID, created)
+	}
+
+	rr = doDecksRequest(t, router, http.MethodGet, "/api/v1/decks/"+created.DeckID, memberToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected team member to read team deck, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodPatch, "/api/v1/decks/"+created.DeckID, memberToken, `{"name":"Renamed"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden when member edits team deck, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestListDecks_ReturnsSystemPersonalAndTeamDecks(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	ownerToken, ownerUserID := createDecksAccessToken(t, db, "owner@example.com")
+	otherToken, _ := createDecksAccessToken(t, db, "other@example.com")
+	teamID := seedDeckTeam(t, db, ownerUserID)
+	seedSystemDeck(t, db, "SYSTEM_TEST")
+
+	rr := doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", ownerToken, `{"name":"Mine","kind":"CUSTOM","values":["1","2"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", ownerToken, `{"name":"Ours","kind":"CUSTOM","values":["S","M"],"teamId":"`+teamID+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+	rr = doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", otherToken, `{"name":"Theirs","kind":"CUSTOM","values":["1"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodGet, "/api/v1/decks/", ownerToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response []decksdto.DeckResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode list response: %v", err)
+	}
+
+	names := make([]string, 0, len(response))
+	for _, deck := range response {
+		names = append(names, deck.Name)
+	}
+	if len(names) != 3 || names[0] != "Fibonacci" || names[1] != "Mine" || names[2] != "Ours" {
+		t.Fatalf("expected [Fibonacci Mine Ours], got %v", names)
+	}
+}
+
+func TestDecks_SystemDeckIsReadOnly(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	accessToken, _ := createDecksAccessToken(t, db, "owner@example.com")
+	seedSystemDeck(t, db, "SYSTEM_TEST")
+
+	rr := doDecksRequest(t, router, http.MethodPatch, "/api/v1/decks/SYSTEM_TEST", accessToken, `{"name":"Mine now"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden on update, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodDelete, "/api/v1/decks/SYSTEM_TEST", accessToken, "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden on delete, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestDecks_UpdateAndDeletePersonalDeck(t *testing.T) {
+	router, db := setupDecksTest(t)
+	defer db.Close()
+
+	ownerToken, _ := createDecksAccessToken(t, db, "owner@example.com")
+	otherToken, _ := createDecksAccessToken(t, db, "other@example.com")
+
+	rr := doDecksRequest(t, router, http.MethodPost, "/api/v1/decks/", ownerToken, `{"name":"Mine","kind":"CUSTOM","values":["1","2"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+	created := decodeDeckResponse(t, rr)
+
+	rr = doDecksRequest(t, router, http.MethodGet, "/api/v1/decks/"+created.DeckID, otherToken, "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden for another user, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodPatch, "/api/v1/decks/"+created.DeckID, ownerToken, `{"values":["1","2","3","?"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on update, got %d: %s", rr.Code, rr.Body.String())
+	}
+	updated := decodeDeckResponse(t, rr)
+	if updated.Name != "Mine" || len(updated.Values) != 4 {
+		t.Fatalf("expected name to be kept and values replaced, got %+v", updated)
+	}
+
+	rr = doDecksRequest(t, router, http.MethodDelete, "/api/v1/decks/"+created.DeckID, ownerToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on delete, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doDecksRequest(t, router, http.MethodGet, "/api/v1/decks/"+created.DeckID, ownerToken, "")
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 Not Found after delete, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
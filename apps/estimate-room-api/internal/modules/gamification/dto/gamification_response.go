@@ -21,3 +21,17 @@ type AchievementResponse struct {
 	Level      int       `json:"level"`
 	UnlockedAt time.Time `json:"unlockedAt"`
 }
+
+type TeamResponse struct {
+	TeamID       string                `json:"teamId"`
+	Stats        TeamStatsResponse     `json:"stats"`
+	Achievements []AchievementResponse `json:"achievements"`
+}
+
+type TeamStatsResponse struct {
+	SessionsTotal  int `json:"sessionsTotal"`
+	TasksEstimated int `json:"tasksEstimated"`
+	XP             int `json:"xp"`
+	Level          int `json:"level"`
+	NextLevelXP    int `json:"nextLevelXp"`
+}
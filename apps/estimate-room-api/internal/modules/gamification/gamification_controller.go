@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
 	apperrors "github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/httputils"
@@ -13,6 +14,7 @@ import (
 
 type GamificationController interface {
 	GetMe(w http.ResponseWriter, r *http.Request)
+	GetTeam(w http.ResponseWriter, r *http.Request)
 }
 
 type gamificationController struct {
@@ -76,3 +78,68 @@ func (c *gamificationController) GetMe(w http.ResponseWriter, r *http.Request) {
 
 	httputils.WriteResponse(w, response)
 }
+
+// GetTeam godoc
+// @Summary Team gamification
+// @Description Returns cumulative stats and unlocked achievements for a team. Team membership is required.
+// @Tags gamification
+// @Produce json
+// @Param id path string true "Team ID"
+// @Success 200 {object} gamificationdto.TeamResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/gamification/teams/{id} [get]
+func (c *gamificationController) GetTeam(w http.ResponseWriter, r *http.Request) {
+	userID, err := c.authService.CheckAuth(r)
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+		return
+	}
+
+	response, err := c.service.GetTeam(r.Context(), chi.URLParam(r, "id"), userID)
+	if err != nil {
+		c.writeGamificationError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, response)
+}
+
+func (c *gamificationController) writeGamificationError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case stdErrors.Is(err, apperrors.ErrBadRequest):
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrForbidden):
+		c.writeError(w, r, apperrors.ErrForbidden, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrNotFound):
+		c.writeError(w, r, apperrors.ErrNotFound, err.Error(), err)
+	default:
+		c.writeError(w, r, apperrors.ErrInternal, "", err)
+	}
+}
+
+func (c *gamificationController) writeError(w http.ResponseWriter, r *http.Request, errType error, detail string, cause error) {
+	logArgs := []any{
+		"path", r.URL.Path,
+		"type", errType.Error(),
+	}
+	if detail != "" {
+		logArgs = append(logArgs, "detail", detail)
+	}
+	if cause != nil {
+		logArgs = append(logArgs, "err", cause)
+	}
+
+	logger.FromRequest(r, c.logger).Error("request failed", logArgs...)
+
+	httputils.WriteResponseError(w, apperrors.CreateHttpError(
+		errType,
+		apperrors.HttpError{
+			Detail:   detail,
+			Instance: r.URL.Path,
+		},
+	))
+}
@@ -3,6 +3,7 @@ package gamification
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
 	"github.com/uptrace/bun"
 )
@@ -20,11 +21,17 @@ type GamificationModuleDeps struct {
 }
 
 func NewGamificationModule(deps GamificationModuleDeps) *GamificationModule {
-	service := NewGamificationService(deps.DB, newWSRewardNotifier(deps.WsService))
+	service := NewGamificationService(
+		deps.DB,
+		teamsrepositories.NewTeamRepository(deps.DB),
+		teamsrepositories.NewTeamMemberRepository(deps.DB),
+		newWSRewardNotifier(deps.WsService),
+	)
 	controller := NewGamificationController(service, deps.AuthService)
 
 	deps.Router.Route("/gamification", func(r chi.Router) {
 		r.Get("/me", controller.GetMe)
+		r.Get("/teams/{id}", controller.GetTeam)
 	})
 
 	return &GamificationModule{
@@ -10,6 +10,7 @@ import (
 	gamificationmodels "github.com/master-bogdan/estimate-room-api/internal/modules/gamification/models"
 	gamificationrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/gamification/repositories"
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/uptrace/bun"
 )
@@ -19,11 +20,18 @@ const (
 	AchievementSessionParticipation = "SESSION_PARTICIPATION"
 	AchievementTasksEstimated       = "TASKS_ESTIMATED"
 
-	SessionRewardedEvent = "GAMIFICATION_SESSION_REWARDED"
+	AchievementTeamSessions       = "TEAM_SESSIONS"
+	AchievementTeamTasksEstimated = "TEAM_TASKS_ESTIMATED"
+
+	SessionRewardedEvent     = "GAMIFICATION_SESSION_REWARDED"
+	TeamSessionRewardedEvent = "GAMIFICATION_TEAM_SESSION_REWARDED"
 
 	adminSessionXP        = 25
 	participantSessionXP  = 10
 	estimatedTaskXP       = 3
+
+	teamSessionXP       = 20
+	teamEstimatedTaskXP = 5
 )
 
 var achievementMilestones = map[string][]int{
@@ -32,8 +40,14 @@ var achievementMilestones = map[string][]int{
 	AchievementTasksEstimated:       {1, 10, 25, 50},
 }
 
+var teamAchievementMilestones = map[string][]int{
+	AchievementTeamSessions:       {1, 10, 25, 50},
+	AchievementTeamTasksEstimated: {10, 50, 100, 250},
+}
+
 type RewardNotifier interface {
 	NotifySessionReward(ctx context.Context, reward AppliedRoomReward) error
+	NotifyTeamSessionReward(ctx context.Context, reward AppliedTeamReward) error
 }
 
 type AchievementProgress struct {
@@ -58,26 +72,55 @@ type AppliedRoomReward struct {
 	UnlockedAchievements       []AchievementProgress `json:"unlockedAchievements"`
 }
 
+// AppliedTeamReward describes the stats a team gained from one terminal room.
+// MemberUserIDs lists the team members the reward is pushed to.
+type AppliedTeamReward struct {
+	RoomID               string                `json:"roomId"`
+	RoomStatus           string                `json:"roomStatus"`
+	TeamID               string                `json:"teamId"`
+	SessionsTotalDelta   int                   `json:"sessionsTotalDelta"`
+	TasksEstimatedDelta  int                   `json:"tasksEstimatedDelta"`
+	XPGained             int                   `json:"xpGained"`
+	PreviousXP           int                   `json:"previousXp"`
+	CurrentXP            int                   `json:"currentXp"`
+	PreviousLevel        int                   `json:"previousLevel"`
+	CurrentLevel         int                   `json:"currentLevel"`
+	UnlockedAchievements []AchievementProgress `json:"unlockedAchievements"`
+	MemberUserIDs        []string              `json:"-"`
+}
+
 type RoomRewardService interface {
 	ApplyRoomTerminalRewards(ctx context.Context, db bun.IDB, room *roomsmodels.RoomsModel) ([]AppliedRoomReward, error)
 	NotifyAppliedRewards(ctx context.Context, rewards []AppliedRoomReward) error
+	ApplyTeamTerminalRewards(ctx context.Context, db bun.IDB, room *roomsmodels.RoomsModel) (*AppliedTeamReward, error)
+	NotifyAppliedTeamReward(ctx context.Context, reward *AppliedTeamReward) error
 }
 
 type GamificationService interface {
 	RoomRewardService
 	GetMe(ctx context.Context, userID string) (gamificationdto.MeResponse, error)
+	GetTeam(ctx context.Context, teamID, userID string) (gamificationdto.TeamResponse, error)
 }
 
 type gamificationService struct {
 	db          *bun.DB
 	repoFactory func(db bun.IDB) gamificationrepositories.GamificationRepository
+	teamRepo    teamsrepositories.TeamRepository
+	memberRepo  teamsrepositories.TeamMemberRepository
 	notifier    RewardNotifier
 }
 
-func NewGamificationService(db *bun.DB, notifier RewardNotifier) GamificationService {
+func NewGamificationService(
+	db *bun.DB,
+	teamRepo teamsrepositories.TeamRepository,
+	memberRepo teamsrepositories.TeamMemberRepository,
+	notifier RewardNotifier,
+) GamificationService {
 	return &gamificationService{
 		db:          db,
 		repoFactory: gamificationrepositories.NewGamificationRepository,
+		teamRepo:    teamRepo,
+		memberRepo:  memberRepo,
 		notifier:    notifier,
 	}
 }
@@ -131,6 +174,63 @@ func (s *gamificationService) GetMe(ctx context.Context, userID string) (gamific
 	}, nil
 }
 
+// GetTeam returns cumulative stats and achievements for a team. Any team
+// member may read them.
+func (s *gamificationService) GetTeam(ctx context.Context, teamID, userID string) (gamificationdto.TeamResponse, error) {
+	teamID = strings.TrimSpace(teamID)
+	if teamID == "" || strings.TrimSpace(userID) == "" {
+		return gamificationdto.TeamResponse{}, apperrors.ErrBadRequest
+	}
+	if s.db == nil {
+		return gamificationdto.TeamResponse{}, apperrors.ErrInternal
+	}
+
+	if _, err := s.teamRepo.FindByID(teamID); err != nil {
+		return gamificationdto.TeamResponse{}, err
+	}
+	if _, err := s.memberRepo.FindByTeamAndUser(teamID, userID); err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return gamificationdto.TeamResponse{}, apperrors.ErrForbidden
+		}
+		return gamificationdto.TeamResponse{}, err
+	}
+
+	repo := s.repoFactory(s.db)
+	stats, err := repo.GetTeamStats(ctx, teamID)
+	if err != nil {
+		return gamificationdto.TeamResponse{}, err
+	}
+
+	achievements, err := repo.ListTeamAchievements(ctx, teamID)
+	if err != nil {
+		return gamificationdto.TeamResponse{}, err
+	}
+
+	responseAchievements := make([]gamificationdto.AchievementResponse, 0, len(achievements))
+	for _, achievement := range achievements {
+		if achievement == nil {
+			continue
+		}
+		responseAchievements = append(responseAchievements, gamificationdto.AchievementResponse{
+			Key:        achievement.AchievementKey,
+			Level:      achievement.Level,
+			UnlockedAt: achievement.UnlockedAt,
+		})
+	}
+
+	return gamificationdto.TeamResponse{
+		TeamID: teamID,
+		Stats: gamificationdto.TeamStatsResponse{
+			SessionsTotal:  stats.SessionsTotal,
+			TasksEstimated: stats.TasksEstimated,
+			XP:             stats.XP,
+			Level:          levelForXP(stats.XP),
+			NextLevelXP:    nextLevelXP(stats.XP),
+		},
+		Achievements: responseAchievements,
+	}, nil
+}
+
 func (s *gamificationService) ApplyRoomTerminalRewards(
 	ctx context.Context,
 	db bun.IDB,
@@ -227,6 +327,160 @@ func (s *gamificationService) NotifyAppliedRewards(ctx context.Context, rewards
 	return nil
 }
 
+// ApplyTeamTerminalRewards credits the room's team once per room. It returns
+// nil when the room has no team or the reward was already applied.
+func (s *gamificationService) ApplyTeamTerminalRewards(
+	ctx context.Context,
+	db bun.IDB,
+	room *roomsmodels.RoomsModel,
+) (*AppliedTeamReward, error) {
+	if room == nil || strings.TrimSpace(room.RoomID) == "" {
+		return nil, apperrors.ErrBadRequest
+	}
+	if room.Status != "FINISHED" && room.Status != "EXPIRED" {
+		return nil, apperrors.ErrBadRequest
+	}
+	if room.TeamID == nil || strings.TrimSpace(*room.TeamID) == "" {
+		return nil, nil
+	}
+	if db == nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	teamID := *room.TeamID
+	repo := s.repoFactory(db)
+	tasksEstimated, err := repo.CountRoomEstimatedTasks(ctx, room.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	xpGained := teamSessionXP + tasksEstimated*teamEstimatedTaskXP
+	inserted, err := repo.InsertTeamSessionReward(ctx, &gamificationmodels.TeamSessionRewardModel{
+		RoomID:              room.RoomID,
+		TeamID:              teamID,
+		SessionsTotalDelta:  1,
+		TasksEstimatedDelta: tasksEstimated,
+		XPGained:            xpGained,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		return nil, nil
+	}
+
+	previousStats, err := repo.GetTeamStats(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentStats, err := repo.ApplyTeamStatsDelta(ctx, teamID, gamificationrepositories.TeamStatsDelta{
+		SessionsTotal:  1,
+		TasksEstimated: tasksEstimated,
+		XP:             xpGained,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unlockedAchievements, err := s.applyTeamAchievements(ctx, repo, teamID, previousStats, currentStats)
+	if err != nil {
+		return nil, err
+	}
+
+	memberUserIDs, err := repo.ListTeamMemberUserIDs(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppliedTeamReward{
+		RoomID:               room.RoomID,
+		RoomStatus:           room.Status,
+		TeamID:               teamID,
+		SessionsTotalDelta:   1,
+		TasksEstimatedDelta:  tasksEstimated,
+		XPGained:             xpGained,
+		PreviousXP:           previousStats.XP,
+		CurrentXP:            currentStats.XP,
+		PreviousLevel:        levelForXP(previousStats.XP),
+		CurrentLevel:         levelForXP(currentStats.XP),
+		UnlockedAchievements: unlockedAchievements,
+		MemberUserIDs:        memberUserIDs,
+	}, nil
+}
+
+func (s *gamificationService) NotifyAppliedTeamReward(ctx context.Context, reward *AppliedTeamReward) error {
+	if s.notifier == nil || reward == nil {
+		return nil
+	}
+
+	return s.notifier.NotifyTeamSessionReward(ctx, *reward)
+}
+
+func (s *gamificationService) applyTeamAchievements(
+	ctx context.Context,
+	repo gamificationrepositories.GamificationRepository,
+	teamID string,
+	previousStats, currentStats *gamificationmodels.TeamStatsModel,
+) ([]AchievementProgress, error) {
+	updates := make([]AchievementProgress, 0, len(teamAchievementMilestones))
+
+	for achievementKey, milestones := range teamAchievementMilestones {
+		var previousValue int
+		var currentValue int
+
+		switch achievementKey {
+		case AchievementTeamSessions:
+			previousValue = previousStats.SessionsTotal
+			currentValue = currentStats.SessionsTotal
+		case AchievementTeamTasksEstimated:
+			previousValue = previousStats.TasksEstimated
+			currentValue = currentStats.TasksEstimated
+		default:
+			continue
+		}
+
+		previousLevel := milestoneLevel(previousValue, milestones)
+		currentLevel := milestoneLevel(currentValue, milestones)
+		if currentLevel == 0 || currentLevel <= previousLevel {
+			continue
+		}
+
+		existingAchievement, err := repo.GetTeamAchievement(ctx, teamID, achievementKey)
+		if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+
+		storedPreviousLevel := 0
+		if existingAchievement != nil {
+			storedPreviousLevel = existingAchievement.Level
+		}
+		if storedPreviousLevel >= currentLevel {
+			continue
+		}
+
+		if err := repo.SaveTeamAchievement(ctx, &gamificationmodels.TeamAchievementModel{
+			TeamID:         teamID,
+			AchievementKey: achievementKey,
+			Level:          currentLevel,
+		}); err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, AchievementProgress{
+			Key:           achievementKey,
+			PreviousLevel: storedPreviousLevel,
+			CurrentLevel:  currentLevel,
+		})
+	}
+
+	sort.Slice(updates, func(i, j int) bool {
+		return updates[i].Key < updates[j].Key
+	})
+
+	return updates, nil
+}
+
 func (s *gamificationService) applyAchievements(
 	ctx context.Context,
 	repo gamificationrepositories.GamificationRepository,
@@ -0,0 +1,16 @@
+package gamificationmodels
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type TeamAchievementModel struct {
+	bun.BaseModel `bun:"table:team_achievements,alias:ta"`
+
+	TeamID         string    `bun:"team_id,pk"`
+	AchievementKey string    `bun:"achievement_key,pk"`
+	Level          int       `bun:"level"`
+	UnlockedAt     time.Time `bun:"unlocked_at"`
+}
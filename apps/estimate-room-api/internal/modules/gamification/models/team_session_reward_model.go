@@ -0,0 +1,18 @@
+package gamificationmodels
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type TeamSessionRewardModel struct {
+	bun.BaseModel `bun:"table:team_session_rewards,alias:tsr"`
+
+	RoomID              string    `bun:"room_id,pk"`
+	TeamID              string    `bun:"team_id"`
+	SessionsTotalDelta  int       `bun:"sessions_total_delta"`
+	TasksEstimatedDelta int       `bun:"tasks_estimated_delta"`
+	XPGained            int       `bun:"xp_gained"`
+	CreatedAt           time.Time `bun:"created_at"`
+}
@@ -0,0 +1,12 @@
+package gamificationmodels
+
+import "github.com/uptrace/bun"
+
+type TeamStatsModel struct {
+	bun.BaseModel `bun:"table:team_stats,alias:ts"`
+
+	TeamID         string `bun:"team_id,pk"`
+	SessionsTotal  int    `bun:"sessions_total"`
+	TasksEstimated int    `bun:"tasks_estimated"`
+	XP             int    `bun:"xp"`
+}
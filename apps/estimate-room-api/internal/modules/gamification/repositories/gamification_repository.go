@@ -26,6 +26,12 @@ type RoomRewardCandidate struct {
 	TasksEstimatedDelta       int    `bun:"tasks_estimated_delta"`
 }
 
+type TeamStatsDelta struct {
+	SessionsTotal  int
+	TasksEstimated int
+	XP             int
+}
+
 type GamificationRepository interface {
 	GetUserStats(ctx context.Context, userID string) (*gamificationmodels.UserStatsModel, error)
 	ListUserAchievements(ctx context.Context, userID string) ([]*gamificationmodels.UserAchievementModel, error)
@@ -34,6 +40,14 @@ type GamificationRepository interface {
 	ApplyUserStatsDelta(ctx context.Context, userID string, delta UserStatsDelta) (*gamificationmodels.UserStatsModel, error)
 	GetUserAchievement(ctx context.Context, userID, achievementKey string) (*gamificationmodels.UserAchievementModel, error)
 	SaveUserAchievement(ctx context.Context, model *gamificationmodels.UserAchievementModel) error
+	GetTeamStats(ctx context.Context, teamID string) (*gamificationmodels.TeamStatsModel, error)
+	ListTeamAchievements(ctx context.Context, teamID string) ([]*gamificationmodels.TeamAchievementModel, error)
+	CountRoomEstimatedTasks(ctx context.Context, roomID string) (int, error)
+	InsertTeamSessionReward(ctx context.Context, model *gamificationmodels.TeamSessionRewardModel) (bool, error)
+	ApplyTeamStatsDelta(ctx context.Context, teamID string, delta TeamStatsDelta) (*gamificationmodels.TeamStatsModel, error)
+	GetTeamAchievement(ctx context.Context, teamID, achievementKey string) (*gamificationmodels.TeamAchievementModel, error)
+	SaveTeamAchievement(ctx context.Context, model *gamificationmodels.TeamAchievementModel) error
+	ListTeamMemberUserIDs(ctx context.Context, teamID string) ([]string, error)
 }
 
 type gamificationRepository struct {
@@ -244,3 +258,189 @@ func (r *gamificationRepository) SaveUserAchievement(
 		Exec(ctx)
 	return err
 }
+
+func (r *gamificationRepository) GetTeamStats(ctx context.Context, teamID string) (*gamificationmodels.TeamStatsModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stats := new(gamificationmodels.TeamStatsModel)
+	err := r.db.NewSelect().
+		Model(stats).
+		Where("ts.team_id = ?", teamID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &gamificationmodels.TeamStatsModel{TeamID: teamID}, nil
+		}
+
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *gamificationRepository) ListTeamAchievements(ctx context.Context, teamID string) ([]*gamificationmodels.TeamAchievementModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	achievements := make([]*gamificationmodels.TeamAchievementModel, 0)
+	err := r.db.NewSelect().
+		Model(&achievements).
+		Where("ta.team_id = ?", teamID).
+		OrderExpr("ta.achievement_key ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return achievements, nil
+}
+
+func (r *gamificationRepository) CountRoomEstimatedTasks(ctx context.Context, roomID string) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var count int
+	err := r.db.NewSelect().
+		TableExpr("tasks AS t").
+		ColumnExpr("COUNT(*)").
+		Where("t.room_id = ?", roomID).
+		Where("t.status = 'ESTIMATED'").
+		Scan(ctx, &count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *gamificationRepository) InsertTeamSessionReward(
+	ctx context.Context,
+	model *gamificationmodels.TeamSessionRewardModel,
+) (bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := r.db.NewInsert().
+		Model(model).
+		Column(
+			"room_id",
+			"team_id",
+			"sessions_total_delta",
+			"tasks_estimated_delta",
+			"xp_gained",
+		).
+		On("CONFLICT (room_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+func (r *gamificationRepository) ApplyTeamStatsDelta(
+	ctx context.Context,
+	teamID string,
+	delta TeamStatsDelta,
+) (*gamificationmodels.TeamStatsModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stats := &gamificationmodels.TeamStatsModel{
+		TeamID:         teamID,
+		SessionsTotal:  delta.SessionsTotal,
+		TasksEstimated: delta.TasksEstimated,
+		XP:             delta.XP,
+	}
+
+	_, err := r.db.NewInsert().
+		Model(stats).
+		Column("team_id", "sessions_total", "tasks_estimated", "xp").
+		On("CONFLICT (team_id) DO UPDATE").
+		Set("sessions_total = team_stats.sessions_total + EXCLUDED.sessions_total").
+		Set("tasks_estimated = team_stats.tasks_estimated + EXCLUDED.tasks_estimated").
+		Set("xp = team_stats.xp + EXCLUDED.xp").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (r *gamificationRepository) GetTeamAchievement(
+	ctx context.Context,
+	teamID, achievementKey string,
+) (*gamificationmodels.TeamAchievementModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	achievement := new(gamificationmodels.TeamAchievementModel)
+	err := r.db.NewSelect().
+		Model(achievement).
+		Where("ta.team_id = ?", teamID).
+		Where("ta.achievement_key = ?", achievementKey).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return achievement, nil
+}
+
+func (r *gamificationRepository) SaveTeamAchievement(
+	ctx context.Context,
+	model *gamificationmodels.TeamAchievementModel,
+) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	model.UnlockedAt = time.Now().UTC()
+	_, err := r.db.NewInsert().
+		Model(model).
+		Column("team_id", "achievement_key", "level", "unlocked_at").
+		On("CONFLICT (team_id, achievement_key) DO UPDATE").
+		Set("level = EXCLUDED.level").
+		Set("unlocked_at = EXCLUDED.unlocked_at").
+		Exec(ctx)
+	return err
+}
+
+func (r *gamificationRepository) ListTeamMemberUserIDs(ctx context.Context, teamID string) ([]string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	userIDs := make([]string, 0)
+	err := r.db.NewSelect().
+		TableExpr("team_members AS tm").
+		Column("tm.user_id").
+		Where("tm.team_id = ?", teamID).
+		OrderExpr("tm.user_id ASC").
+		Scan(ctx, &userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return userIDs, nil
+}
@@ -146,6 +146,44 @@ func seedGamificationGuestParticipant(t *testing.T, db *bun.DB, roomID, guestNam
 	return participantID
 }
 
+func seedGamificationTeam(t *testing.T, db *bun.DB, ownerUserID string, memberUserIDs ...string) string {
+	t.Helper()
+
+	teamID := uuid.NewString()
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO teams (team_id, name, owner_user_id)
+		VALUES ($1, 'Gamification Team', $2)
+	`, teamID, ownerUserID); err != nil {
+		t.Fatalf("failed to insert team: %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO team_members (team_id, user_id, role)
+		VALUES ($1, $2, 'OWNER')
+	`, teamID, ownerUserID); err != nil {
+		t.Fatalf("failed to insert team owner: %v", err)
+	}
+	for _, memberUserID := range memberUserIDs {
+		if _, err := db.ExecContext(context.Background(), `
+			INSERT INTO team_members (team_id, user_id, role)
+			VALUES ($1, $2, 'MEMBER')
+		`, teamID, memberUserID); err != nil {
+			t.Fatalf("failed to insert team member: %v", err)
+		}
+	}
+
+	return teamID
+}
+
+func assignGamificationRoomTeam(t *testing.T, db *bun.DB, roomID, teamID string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET team_id = $2 WHERE room_id = $1
+	`, roomID, teamID); err != nil {
+		t.Fatalf("failed to assign room team: %v", err)
+	}
+}
+
 func seedGamificationTask(t *testing.T, db *bun.DB, roomID, status string, isActive bool, finalEstimateValue *string) string {
 	t.Helper()
 
@@ -399,3 +437,96 @@ func TestExpiryRoom_AppliesSameRewardRules(t *testing.T) {
 		t.Fatalf("expected expiry rewards to match participation rules, got %+v", memberProfile.Stats)
 	}
 }
+
+func TestApplyTeamTerminalRewards_IsIdempotentAndVisibleToMembers(t *testing.T) {
+	router, db, gamificationService, _ := setupGamificationTest(t)
+	defer db.Close()
+
+	_, adminUserID := createGamificationAccessToken(t, db, "team-admin@example.com")
+	memberToken, memberUserID := createGamificationAccessToken(t, db, "team-member@example.com")
+	outsiderToken, _ := createGamificationAccessToken(t, db, "outsider@example.com")
+	teamID := seedGamificationTeam(t, db, adminUserID, memberUserID)
+
+	teamIDCopy := teamID
+	room := &roomsmodels.RoomsModel{
+		RoomID:      uuid.NewString(),
+		AdminUserID: adminUserID,
+		TeamID:      &teamIDCopy,
+		Status:      "FINISHED",
+	}
+	seedGamificationRoom(t, db, room.RoomID, adminUserID, room.Status, time.Now().UTC())
+	assignGamificationRoomTeam(t, db, room.RoomID, teamID)
+
+	finalEstimate := "5"
+	seedGamificationTask(t, db, room.RoomID, "ESTIMATED", false, &finalEstimate)
+	seedGamificationTask(t, db, room.RoomID, "SKIPPED", false, nil)
+
+	firstReward, err := gamificationService.ApplyTeamTerminalRewards(context.Background(), db, room)
+	if err != nil {
+		t.Fatalf("failed to apply team rewards: %v", err)
+	}
+	if firstReward == nil {
+		t.Fatal("expected team reward on first application")
+	}
+	if firstReward.TasksEstimatedDelta != 1 || firstReward.XPGained != 25 || len(firstReward.MemberUserIDs) != 2 {
+		t.Fatalf("unexpected team reward: %+v", firstReward)
+	}
+
+	secondReward, err := gamificationService.ApplyTeamTerminalRewards(context.Background(), db, room)
+	if err != nil {
+		t.Fatalf("failed to apply second team rewards: %v", err)
+	}
+	if secondReward != nil {
+		t.Fatalf("expected no team reward on second application, got %+v", secondReward)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/gamification/teams/"+teamID, nil)
+	req.Header.Set("Authorization", "Bearer "+memberToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK from team gamification, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response gamificationdto.TeamResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode team response: %v", err)
+	}
+	if response.Stats.SessionsTotal != 1 || response.Stats.TasksEstimated != 1 || response.Stats.XP != 25 {
+		t.Fatalf("unexpected team stats: %+v", response.Stats)
+	}
+	if len(response.Achievements) != 1 || response.Achievements[0].Key != gamification.AchievementTeamSessions {
+		t.Fatalf("expected TEAM_SESSIONS achievement, got %+v", response.Achievements)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/gamification/teams/"+teamID, nil)
+	req.Header.Set("Authorization", "Bearer "+outsiderToken)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-member, got %d", rr.Code)
+	}
+}
+
+func TestApplyTeamTerminalRewards_SkipsRoomsWithoutTeam(t *testing.T) {
+	_, db, gamificationService, _ := setupGamificationTest(t)
+	defer db.Close()
+
+	_, adminUserID := createGamificationAccessToken(t, db, "solo-admin@example.com")
+	room := &roomsmodels.RoomsModel{
+		RoomID:      uuid.NewString(),
+		AdminUserID: adminUserID,
+		Status:      "EXPIRED",
+	}
+	seedGamificationRoom(t, db, room.RoomID, adminUserID, room.Status, time.Now().UTC())
+
+	reward, err := gamificationService.ApplyTeamTerminalRewards(context.Background(), db, room)
+	if err != nil {
+		t.Fatalf("failed to apply team rewards: %v", err)
+	}
+	if reward != nil {
+		t.Fatalf("expected no team reward for a room without team, got %+v", reward)
+	}
+}
@@ -42,6 +42,13 @@ func (p *gamificationTestPubSub) Subscribe(channel string, onMessage func([]byte
 	p.subscriptions[channel] = append(p.subscriptions[channel], onMessage)
 }
 
+func (p *gamificationTestPubSub) Unsubscribe(channel string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.subscriptions, channel)
+}
+
 func (p *gamificationTestPubSub) Publish(channel string, message any) error {
 	data, err := json.Marshal(message)
 	if err != nil {
@@ -223,3 +230,150 @@ func TestFinishRoom_EmitsRewardEventToConnectedUser(t *testing.T) {
 		t.Fatalf("unexpected reward xp payload: %+v", payload)
 	}
 }
+
+func TestFinishTeamRoom_EmitsTeamRewardEventToMembers(t *testing.T) {
+	server, db := setupGamificationRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createGamificationAccessToken(t, db, "ws-team-admin@example.com")
+	memberToken, memberUserID := createGamificationAccessToken(t, db, "ws-team-member@example.com")
+	teamID := seedGamificationTeam(t, db, adminUserID, memberUserID)
+
+	roomID := uuid.NewString()
+	seedGamificationRoom(t, db, roomID, adminUserID, "ACTIVE", time.Now().UTC())
+	assignGamificationRoomTeam(t, db, roomID, teamID)
+	seedGamificationParticipant(t, db, roomID, adminUserID, "ADMIN")
+	finalEstimate := "5"
+	seedGamificationTask(t, db, roomID, "ESTIMATED", false, &finalEstimate)
+
+	memberConn := connectGamificationWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	readGamificationEvent(t, memberConn, ws.EventTypeHello)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/rooms/"+roomID, strings.NewReader(`{"status":"FINISHED"}`))
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	server.Config.Handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected room finish to succeed, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	event := readGamificationEvent(t, memberConn, gamification.TeamSessionRewardedEvent)
+	payload := struct {
+		RoomID              string `json:"roomId"`
+		TeamID              string `json:"teamId"`
+		SessionsTotalDelta  int    `json:"sessionsTotalDelta"`
+		TasksEstimatedDelta int    `json:"tasksEstimatedDelta"`
+		XPGained            int    `json:"xpGained"`
+	}{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		t.Fatalf("failed to decode team reward payload: %v", err)
+	}
+
+	if payload.RoomID != roomID || payload.TeamID != teamID {
+		t.Fatalf("unexpected team reward target: %+v", payload)
+	}
+	if payload.SessionsTotalDelta != 1 || payload.TasksEstimatedDelta != 1 || payload.XPGained != 25 {
+		t.Fatalf("unexpected team reward payload: %+v", payload)
+	}
+}
+
+func TestFinishRoomOverWebSocket_BroadcastsSummaryBeforeRewards(t *testing.T) {
+	server, db := setupGamificationRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createGamificationAccessToken(t, db, "ws-finish-admin@example.com")
+	memberToken, memberUserID := createGamificationAccessToken(t, db, "ws-finish-member@example.com")
+
+	roomID := uuid.NewString()
+	seedGamificationRoom(t, db, roomID, adminUserID, "ACTIVE", time.Now().UTC())
+
+	adminParticipantID := seedGamificationParticipant(t, db, roomID, adminUserID, "ADMIN")
+	memberParticipantID := seedGamificationParticipant(t, db, roomID, memberUserID, "MEMBER")
+	for _, value := range []string{"5", "?"} {
+		finalEstimate := value
+		taskID := seedGamificationTask(t, db, roomID, "ESTIMATED", false, &finalEstimate)
+		seedGamificationTaskRound(t, db, taskID, 1, "REVEALED")
+		seedGamificationVote(t, db, taskID, adminParticipantID, 1, value)
+		seedGamificationVote(t, db, taskID, memberParticipantID, 1, value)
+	}
+	seedGamificationTask(t, db, roomID, "SKIPPED", false, nil)
+	seedGamificationTask(t, db, roomID, "PENDING", false, nil)
+
+	adminConn := connectGamificationWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectGamificationWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	for _, conn := range []*websocket.Conn{adminConn, memberConn} {
+		readGamificationEvent(t, conn, ws.EventTypeHello)
+		writeGamificationEvent(t, conn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+		readGamificationEvent(t, conn, rooms.RoomsSnapshot)
+	}
+
+	writeGamificationEvent(t, memberConn, ws.Event{Type: rooms.RoomsFinish, RoomID: roomID})
+	writeGamificationEvent(t, adminConn, ws.Event{Type: rooms.RoomsFinish, RoomID: roomID})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var summary *struct {
+		RoomID             string  `json:"roomId"`
+		Status             string  `json:"status"`
+		DurationSeconds    int64   `json:"durationSeconds"`
+		TaskCount          int     `json:"taskCount"`
+		EstimatedTaskCount int     `json:"estimatedTaskCount"`
+		SkippedTaskCount   int     `json:"skippedTaskCount"`
+		EstimatedTotal     float64 `json:"estimatedTotal"`
+	}
+	for {
+		_, data, err := memberConn.Read(ctx)
+		if err != nil {
+			t.Fatalf("failed to read websocket event: %v", err)
+		}
+
+		event := ws.Event{}
+		if err := json.Unmarshal(data, &event); err != nil {
+			t.Fatalf("failed to decode websocket event: %v", err)
+		}
+
+		if event.Type == rooms.RoomsFinished {
+			if err := json.Unmarshal(event.Payload, &summary); err != nil {
+				t.Fatalf("failed to decode room summary: %v", err)
+			}
+		}
+		if event.Type == gamification.SessionRewardedEvent {
+			break
+		}
+	}
+
+	if summary == nil {
+		t.Fatalf("expected the room summary before the reward event")
+	}
+	if summary.RoomID != roomID || summary.Status != "FINISHED" || summary.DurationSeconds < 0 {
+		t.Fatalf("unexpected room summary %+v", summary)
+	}
+	if summary.TaskCount != 4 || summary.EstimatedTaskCount != 2 || summary.SkippedTaskCount != 1 || summary.EstimatedTotal != 5 {
+		t.Fatalf("expected 4 tasks, 2 estimated totalling 5 and 1 skipped, got %+v", summary)
+	}
+}
+
+func writeGamificationEvent(t *testing.T, conn *websocket.Conn, event ws.Event) {
+	t.Helper()
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to encode websocket event: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := conn.Write(ctx, websocket.MessageText, data); err != nil {
+		t.Fatalf("failed to write websocket event: %v", err)
+	}
+}
@@ -25,6 +25,20 @@ type sessionRewardEventPayload struct {
 	UnlockedAchievements      []AchievementProgress `json:"unlockedAchievements"`
 }
 
+type teamSessionRewardEventPayload struct {
+	RoomID               string                `json:"roomId"`
+	RoomStatus           string                `json:"roomStatus"`
+	TeamID               string                `json:"teamId"`
+	SessionsTotalDelta   int                   `json:"sessionsTotalDelta"`
+	TasksEstimatedDelta  int                   `json:"tasksEstimatedDelta"`
+	XPGained             int                   `json:"xpGained"`
+	PreviousXP           int                   `json:"previousXp"`
+	CurrentXP            int                   `json:"currentXp"`
+	PreviousLevel        int                   `json:"previousLevel"`
+	CurrentLevel         int                   `json:"currentLevel"`
+	UnlockedAchievements []AchievementProgress `json:"unlockedAchievements"`
+}
+
 func newWSRewardNotifier(wsService *ws.Service) RewardNotifier {
 	if wsService == nil {
 		return nil
@@ -61,3 +75,40 @@ func (n *wsRewardNotifier) NotifySessionReward(ctx context.Context, reward Appli
 		Payload: payload,
 	})
 }
+
+// NotifyTeamSessionReward pushes the team reward to every team member that is
+// currently connected.
+func (n *wsRewardNotifier) NotifyTeamSessionReward(ctx context.Context, reward AppliedTeamReward) error {
+	if n == nil || n.wsService == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(teamSessionRewardEventPayload{
+		RoomID:               reward.RoomID,
+		RoomStatus:           reward.RoomStatus,
+		TeamID:               reward.TeamID,
+		SessionsTotalDelta:   reward.SessionsTotalDelta,
+		TasksEstimatedDelta:  reward.TasksEstimatedDelta,
+		XPGained:             reward.XPGained,
+		PreviousXP:           reward.PreviousXP,
+		CurrentXP:            reward.CurrentXP,
+		PreviousLevel:        reward.PreviousLevel,
+		CurrentLevel:         reward.CurrentLevel,
+		UnlockedAchievements: reward.UnlockedAchievements,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range reward.MemberUserIDs {
+		if err := n.wsService.SendToUser(userID, ws.Event{
+			Type:    TeamSessionRewardedEvent,
+			UserID:  userID,
+			Payload: payload,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
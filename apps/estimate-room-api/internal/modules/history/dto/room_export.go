@@ -0,0 +1,194 @@
+package historydto
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+type RoomExportFormat string
+
+const (
+	RoomExportFormatCSV      RoomExportFormat = "csv"
+	RoomExportFormatMarkdown RoomExportFormat = "markdown"
+	RoomExportFormatJSON     RoomExportFormat = "json"
+)
+
+// RoomExportSchemaVersion is bumped whenever a field of RoomExport is renamed
+// or removed, so scripts reading the JSON export can detect the change.
+const RoomExportSchemaVersion = 1
+
+// RoomExport is the JSON export of a room. Unlike RoomSummaryResponse it is
+// kept stable for external tooling.
+type RoomExport struct {
+	SchemaVersion int                     `json:"schemaVersion"`
+	ExportedAt    time.Time               `json:"exportedAt"`
+	Room          RoomExportRoom          `json:"room"`
+	Participants  []RoomExportParticipant `json:"participants"`
+	Tasks         []RoomExportTask        `json:"tasks"`
+}
+
+type RoomExportRoom struct {
+	RoomID              string     `json:"roomId"`
+	TeamID              *string    `json:"teamId"`
+	Name                string     `json:"name"`
+	Status              string     `json:"status"`
+	AdminName           string     `json:"adminName"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	FinishedAt          *time.Time `json:"finishedAt"`
+	TasksCount          int        `json:"tasksCount"`
+	EstimatedTasksCount int        `json:"estimatedTasksCount"`
+	// Dimensions is empty unless the room estimated several criteria.
+	Dimensions []RoomExportDimension `json:"dimensions"`
+}
+
+type RoomExportDimension struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+type RoomExportParticipant struct {
+	ParticipantID string `json:"participantId"`
+	Name          string `json:"name"`
+	Role          string `json:"role"`
+	IsGuest       bool   `json:"isGuest"`
+}
+
+type RoomExportTask struct {
+	TaskID        string  `json:"taskId"`
+	ExternalKey   *string `json:"externalKey"`
+	Title         string  `json:"title"`
+	Status        string  `json:"status"`
+	FinalEstimate *string `json:"finalEstimate"`
+	// FinalDimensionEstimates maps dimension keys to their final estimate.
+	FinalDimensionEstimates map[string]string `json:"finalDimensionEstimates"`
+	Rounds                  []RoomExportRound `json:"rounds"`
+}
+
+type RoomExportRound struct {
+	RoundNumber    int                              `json:"roundNumber"`
+	Votes          []RoomExportVote                 `json:"votes"`
+	Stats          *roomsmodels.VoteStats           `json:"stats"`
+	DimensionStats map[string]roomsmodels.VoteStats `json:"dimensionStats"`
+}
+
+// RoomExportVote has a blank Dimension in rooms without dimensions.
+type RoomExportVote struct {
+	ParticipantID string `json:"participantId"`
+	Name          string `json:"name"`
+	Dimension     string `json:"dimension"`
+	Value         string `json:"value"`
+}
+
+func ParseRoomExportFormat(values url.Values) (RoomExportFormat, error) {
+	rawFormat := strings.ToLower(strings.TrimSpace(values.Get("format")))
+	if rawFormat == "" {
+		return RoomExportFormatJSON, nil
+	}
+
+	switch RoomExportFormat(rawFormat) {
+	case RoomExportFormatCSV, RoomExportFormatMarkdown, RoomExportFormatJSON:
+		return RoomExportFormat(rawFormat), nil
+	default:
+		return "", fmt.Errorf("format must be one of csv, markdown, json")
+	}
+}
+
+// NewRoomExport flattens a room summary into the export schema. Only revealed
+// rounds are listed, matching the votes the summary exposes.
+func NewRoomExport(summary RoomSummaryResponse, exportedAt time.Time) RoomExport {
+	export := RoomExport{
+		SchemaVersion: RoomExportSchemaVersion,
+		ExportedAt:    exportedAt.UTC(),
+		Room: RoomExportRoom{
+			RoomID:              summary.Overview.RoomID,
+			TeamID:              summary.Overview.TeamID,
+			Name:                summary.Overview.Name,
+			Status:              summary.Overview.Status,
+			AdminName:           summary.Overview.AdminUser.DisplayName,
+			CreatedAt:           summary.Overview.CreatedAt,
+			FinishedAt:          summary.Overview.FinishedAt,
+			TasksCount:          summary.Overview.TasksCount,
+			EstimatedTasksCount: summary.Overview.EstimatedTasksCount,
+			Dimensions:          make([]RoomExportDimension, 0, len(summary.Overview.Dimensions)),
+		},
+		Participants: make([]RoomExportParticipant, 0, len(summary.Participants)),
+		Tasks:        make([]RoomExportTask, 0, len(summary.Tasks)),
+	}
+
+	for _, dimension := range summary.Overview.Dimensions {
+		export.Room.Dimensions = append(export.Room.Dimensions, RoomExportDimension{
+			Key:  dimension.Key,
+			Name: dimension.Name,
+		})
+	}
+
+	for _, participant := range summary.Participants {
+		export.Participants = append(export.Participants, RoomExportParticipant{
+			ParticipantID: participant.ParticipantID,
+			Name:          roomExportName(participant.DisplayName, participant.GuestName, participant.ParticipantID),
+			Role:          participant.Role,
+			IsGuest:       participant.UserID == nil,
+		})
+	}
+
+	for _, task := range summary.Tasks {
+		exportTask := RoomExportTask{
+			TaskID:                  task.TaskID,
+			ExternalKey:             task.ExternalKey,
+			Title:                   task.Title,
+			Status:                  task.Status,
+			FinalEstimate:           task.FinalEstimateValue,
+			FinalDimensionEstimates: task.FinalDimensionEstimates,
+			Rounds:                  make([]RoomExportRound, 0, len(task.Rounds)),
+		}
+
+		for _, round := range task.Rounds {
+			if round.Status != "REVEALED" {
+				continue
+			}
+
+			exportRound := RoomExportRound{
+				RoundNumber:    round.RoundNumber,
+				Votes:          make([]RoomExportVote, 0, len(round.Votes)),
+				Stats:          round.Stats,
+				DimensionStats: round.DimensionStats,
+			}
+			for _, vote := range round.Votes {
+				exportRound.Votes = append(exportRound.Votes, RoomExportVote{
+					ParticipantID: vote.ParticipantID,
+					Name:          roomExportName(vote.DisplayName, vote.GuestName, vote.ParticipantID),
+					Dimension:     vote.Dimension,
+					Value:         vote.Value,
+				})
+			}
+
+			exportTask.Rounds = append(exportTask.Rounds, exportRound)
+		}
+
+		export.Tasks = append(export.Tasks, exportTask)
+	}
+
+	return export
+}
+
+// roomExportAnonymousName names votes of an anonymous room, which carry no
+// participant.
+const roomExportAnonymousName = "Anonymous"
+
+func roomExportName(displayName, guestName *string, participantID string) string {
+	if displayName != nil && strings.TrimSpace(*displayName) != "" {
+		return *displayName
+	}
+	if guestName != nil && strings.TrimSpace(*guestName) != "" {
+		return *guestName
+	}
+	if participantID == "" {
+		return roomExportAnonymousName
+	}
+
+	return participantID
+}
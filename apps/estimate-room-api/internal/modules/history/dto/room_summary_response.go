@@ -1,6 +1,10 @@
 package historydto
 
-import "time"
+import (
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
 
 type RoomSummaryResponse struct {
 	Overview     RoomSummaryOverview      `json:"overview"`
@@ -9,19 +13,24 @@ type RoomSummaryResponse struct {
 }
 
 type RoomSummaryOverview struct {
-	RoomID                string              `json:"roomId" bun:"room_id"`
-	TeamID                *string             `json:"teamId,omitempty" bun:"team_id"`
-	Name                  string              `json:"name" bun:"name"`
-	Status                string              `json:"status" bun:"status"`
-	CreatedAt             time.Time           `json:"createdAt" bun:"created_at"`
-	FinishedAt            *time.Time          `json:"finishedAt,omitempty" bun:"finished_at"`
-	LastActivityAt        time.Time           `json:"lastActivityAt" bun:"last_activity_at"`
-	ApproxDurationSeconds int64               `json:"approxDurationSeconds" bun:"approx_duration_seconds"`
-	ParticipantsCount     int                 `json:"participantsCount" bun:"participants_count"`
-	EstimatedTasksCount   int                 `json:"estimatedTasksCount" bun:"estimated_tasks_count"`
-	TasksCount            int                 `json:"tasksCount" bun:"tasks_count"`
-	RoundCount            int                 `json:"roundCount" bun:"round_count"`
-	AdminUser             RoomSummaryUserRef  `json:"adminUser"`
+	RoomID                string     `json:"roomId" bun:"room_id"`
+	TeamID                *string    `json:"teamId,omitempty" bun:"team_id"`
+	Name                  string     `json:"name" bun:"name"`
+	Status                string     `json:"status" bun:"status"`
+	CreatedAt             time.Time  `json:"createdAt" bun:"created_at"`
+	FinishedAt            *time.Time `json:"finishedAt,omitempty" bun:"finished_at"`
+	LastActivityAt        time.Time  `json:"lastActivityAt" bun:"last_activity_at"`
+	ApproxDurationSeconds int64      `json:"approxDurationSeconds" bun:"approx_duration_seconds"`
+	ParticipantsCount     int        `json:"participantsCount" bun:"participants_count"`
+	EstimatedTasksCount   int        `json:"estimatedTasksCount" bun:"estimated_tasks_count"`
+	TasksCount            int        `json:"tasksCount" bun:"tasks_count"`
+	RoundCount            int        `json:"roundCount" bun:"round_count"`
+	// AnonymousVoting means votes are listed without their participants
+	// unless the viewer is the room admin and the room allows it.
+	AnonymousVoting bool `json:"anonymousVoting" bun:"anonymous_voting"`
+	// Dimensions lists the criteria of a multi-criteria room.
+	Dimensions roomsmodels.RoomDimensions `json:"dimensions,omitempty" bun:"-"`
+	AdminUser  RoomSummaryUserRef         `json:"adminUser"`
 }
 
 type RoomSummaryUserRef struct {
@@ -46,39 +55,60 @@ type RoomSummaryParticipant struct {
 }
 
 type RoomSummaryTask struct {
-	TaskID                 string                 `json:"taskId" bun:"task_id"`
-	Title                  string                 `json:"title" bun:"title"`
-	Description            *string                `json:"description,omitempty" bun:"description"`
-	ExternalKey            *string                `json:"externalKey,omitempty" bun:"external_key"`
-	Status                 string                 `json:"status" bun:"status"`
-	IsActive               bool                   `json:"isActive" bun:"is_active"`
-	FinalEstimateValue     *string                `json:"finalEstimateValue,omitempty" bun:"final_estimate_value"`
-	CreatedAt              time.Time              `json:"createdAt" bun:"created_at"`
-	UpdatedAt              time.Time              `json:"updatedAt" bun:"updated_at"`
-	ApproxDurationSeconds  int64                  `json:"approxDurationSeconds" bun:"approx_duration_seconds"`
-	RoundCount             int                    `json:"roundCount" bun:"round_count"`
-	Rounds                 []RoomSummaryTaskRound `json:"rounds"`
+	TaskID                  string                 `json:"taskId" bun:"task_id"`
+	Title                   string                 `json:"title" bun:"title"`
+	Description             *string                `json:"description,omitempty" bun:"description"`
+	ExternalKey             *string                `json:"externalKey,omitempty" bun:"external_key"`
+	Status                  string                 `json:"status" bun:"status"`
+	IsActive                bool                   `json:"isActive" bun:"is_active"`
+	FinalEstimateValue      *string                `json:"finalEstimateValue,omitempty" bun:"final_estimate_value"`
+	FinalDimensionEstimates map[string]string      `json:"finalDimensionEstimates,omitempty" bun:"final_dimension_estimates,type:jsonb"`
+	CreatedAt               time.Time              `json:"createdAt" bun:"created_at"`
+	UpdatedAt               time.Time              `json:"updatedAt" bun:"updated_at"`
+	ApproxDurationSeconds   int64                  `json:"approxDurationSeconds" bun:"approx_duration_seconds"`
+	RoundCount              int                    `json:"roundCount" bun:"round_count"`
+	Rounds                  []RoomSummaryTaskRound `json:"rounds"`
+	// Reestimations lists the outcomes the task had before it was voted on
+	// again, oldest first.
+	Reestimations []RoomSummaryTaskReestimation `json:"reestimations"`
+}
+
+type RoomSummaryTaskReestimation struct {
+	TaskID                     string    `json:"-" bun:"task_id"`
+	RoundNumber                int       `json:"roundNumber" bun:"round_number"`
+	PreviousStatus             string    `json:"previousStatus" bun:"previous_status"`
+	PreviousFinalEstimateValue *string   `json:"previousFinalEstimateValue,omitempty" bun:"previous_final_estimate_value"`
+	CreatedAt                  time.Time `json:"createdAt" bun:"created_at"`
 }
 
 type RoomSummaryTaskRound struct {
-	TaskID                 string            `json:"-" bun:"task_id"`
-	RoundNumber            int               `json:"roundNumber" bun:"round_number"`
-	Status                 string            `json:"status" bun:"status"`
-	CreatedAt              time.Time         `json:"createdAt" bun:"created_at"`
-	UpdatedAt              time.Time         `json:"updatedAt" bun:"updated_at"`
-	EligibleParticipantIDs []string          `json:"eligibleParticipantIds" bun:"eligible_participant_ids"`
-	Votes                  []RoomSummaryVote `json:"votes"`
+	TaskID                 string    `json:"-" bun:"task_id"`
+	RoundNumber            int       `json:"roundNumber" bun:"round_number"`
+	Status                 string    `json:"status" bun:"status"`
+	CreatedAt              time.Time `json:"createdAt" bun:"created_at"`
+	UpdatedAt              time.Time `json:"updatedAt" bun:"updated_at"`
+	EligibleParticipantIDs []string  `json:"eligibleParticipantIds" bun:"eligible_participant_ids"`
+	// ChangedVotesCount is how many participants changed or retracted their
+	// vote before the round was revealed.
+	ChangedVotesCount int                    `json:"changedVotesCount" bun:"changed_votes_count"`
+	Votes             []RoomSummaryVote      `json:"votes"`
+	Stats             *roomsmodels.VoteStats `json:"stats,omitempty" bun:"-"`
+	// DimensionStats holds the stats of each dimension, keyed by dimension
+	// key, in multi-criteria rooms. Stats stays empty there.
+	DimensionStats map[string]roomsmodels.VoteStats `json:"dimensionStats,omitempty" bun:"-"`
 }
 
+// RoomSummaryVote has no participant fields when votes are anonymous.
 type RoomSummaryVote struct {
-	TaskID        string     `json:"-" bun:"task_id"`
-	RoundNumber   int        `json:"-" bun:"round_number"`
-	ParticipantID string     `json:"participantId" bun:"participant_id"`
-	UserID        *string    `json:"userId,omitempty" bun:"user_id"`
-	GuestName     *string    `json:"guestName,omitempty" bun:"guest_name"`
-	Email         *string    `json:"email,omitempty" bun:"email"`
-	DisplayName   *string    `json:"displayName,omitempty" bun:"display_name"`
-	AvatarURL     *string    `json:"avatarUrl,omitempty" bun:"avatar_url"`
-	Value         string     `json:"value" bun:"value"`
-	CreatedAt     time.Time  `json:"createdAt" bun:"created_at"`
+	TaskID        string    `json:"-" bun:"task_id"`
+	RoundNumber   int       `json:"-" bun:"round_number"`
+	ParticipantID string    `json:"participantId,omitempty" bun:"participant_id"`
+	UserID        *string   `json:"userId,omitempty" bun:"user_id"`
+	GuestName     *string   `json:"guestName,omitempty" bun:"guest_name"`
+	Email         *string   `json:"email,omitempty" bun:"email"`
+	DisplayName   *string   `json:"displayName,omitempty" bun:"display_name"`
+	AvatarURL     *string   `json:"avatarUrl,omitempty" bun:"avatar_url"`
+	Dimension     string    `json:"dimension,omitempty" bun:"dimension"`
+	Value         string    `json:"value" bun:"value"`
+	CreatedAt     time.Time `json:"createdAt" bun:"created_at"`
 }
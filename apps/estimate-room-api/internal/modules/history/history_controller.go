@@ -3,6 +3,7 @@ package history
 import (
 	stdErrors "errors"
 	"log/slog"
+	"mime"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -17,6 +18,7 @@ type HistoryController interface {
 	ListMySessions(w http.ResponseWriter, r *http.Request)
 	ListTeamSessions(w http.ResponseWriter, r *http.Request)
 	GetRoomSummary(w http.ResponseWriter, r *http.Request)
+	ExportRoomSummary(w http.ResponseWriter, r *http.Request)
 }
 
 type historyController struct {
@@ -135,6 +137,47 @@ func (c *historyController) GetRoomSummary(w http.ResponseWriter, r *http.Reques
 	httputils.WriteResponse(w, response)
 }
 
+// ExportRoomSummary godoc
+// @Summary Room export
+// @Description Downloads the room summary as CSV (one row per task), Markdown, or versioned JSON. Access follows the room summary.
+// @Tags history
+// @Produce json
+// @Produce text/csv
+// @Produce text/markdown
+// @Param id path string true "Room ID"
+// @Param format query string false "Export format" Enums(csv,markdown,json) default(json)
+// @Success 200 {object} historydto.RoomExport
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 403 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/history/rooms/{id}/export [get]
+func (c *historyController) ExportRoomSummary(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	format, err := historydto.ParseRoomExportFormat(r.URL.Query())
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	file, err := c.service.ExportRoomSummary(r.Context(), roomID, userID, format)
+	if err != nil {
+		c.writeHistoryError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", file.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": file.FileName}))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Content)
+}
+
 func (c *historyController) writeHistoryError(w http.ResponseWriter, r *http.Request, err error) {
 	switch {
 	case stdErrors.Is(err, errHistoryNotImplemented):
@@ -0,0 +1,217 @@
+package history
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	historydto "github.com/master-bogdan/estimate-room-api/internal/modules/history/dto"
+)
+
+// RoomExportFile is a rendered room export ready to be sent as a download.
+type RoomExportFile struct {
+	ContentType string
+	FileName    string
+	Content     []byte
+}
+
+func renderRoomExport(export historydto.RoomExport, format historydto.RoomExportFormat) (RoomExportFile, error) {
+	file := RoomExportFile{}
+
+	switch format {
+	case historydto.RoomExportFormatCSV:
+		content, err := renderRoomExportCSV(export)
+		if err != nil {
+			return RoomExportFile{}, err
+		}
+		file.ContentType = "text/csv; charset=utf-8"
+		file.FileName = "room-" + export.Room.RoomID + ".csv"
+		file.Content = content
+	case historydto.RoomExportFormatMarkdown:
+		file.ContentType = "text/markdown; charset=utf-8"
+		file.FileName = "room-" + export.Room.RoomID + ".md"
+		file.Content = renderRoomExportMarkdown(export)
+	default:
+		content, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return RoomExportFile{}, err
+		}
+		file.ContentType = "application/json; charset=utf-8"
+		file.FileName = "room-" + export.Room.RoomID + ".json"
+		file.Content = append(content, '\n')
+	}
+
+	return file, nil
+}
+
+// renderRoomExportCSV writes one row per task. Each revealed round gets its
+// own column holding "name: value" pairs, so the column count follows the
+// task with the most rounds. Rooms with dimensions get an extra
+// final_dimension_estimates column.
+func renderRoomExportCSV(export historydto.RoomExport) ([]byte, error) {
+	maxRounds := 0
+	for _, task := range export.Tasks {
+		maxRounds = max(maxRounds, len(task.Rounds))
+	}
+	withDimensions := len(export.Room.Dimensions) > 0
+
+	header := []string{"external_key", "title", "status", "final_estimate"}
+	if withDimensions {
+		header = append(header, "final_dimension_estimates")
+	}
+	header = append(header, "rounds")
+	for idx := 1; idx <= maxRounds; idx++ {
+		header = append(header, fmt.Sprintf("round_%d_votes", idx))
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, task := range export.Tasks {
+		record := []string{
+			stringValue(task.ExternalKey),
+			task.Title,
+			task.Status,
+			stringValue(task.FinalEstimate),
+		}
+		if withDimensions {
+			record = append(record, formatDimensionEstimates(export.Room.Dimensions, task.FinalDimensionEstimates, "; "))
+		}
+		record = append(record, strconv.Itoa(len(task.Rounds)))
+		for idx := 0; idx < maxRounds; idx++ {
+			if idx < len(task.Rounds) {
+				record = append(record, formatRoundVotes(task.Rounds[idx], ": ", "; "))
+			} else {
+				record = append(record, "")
+			}
+		}
+
+		for idx := range record {
+			record[idx] = escapeCSVFormula(record[idx])
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func renderRoomExportMarkdown(export historydto.RoomExport) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", escapeMarkdown(export.Room.Name))
+	fmt.Fprintf(&buf, "- Status: %s\n", export.Room.Status)
+	fmt.Fprintf(&buf, "- Admin: %s\n", escapeMarkdown(export.Room.AdminName))
+	fmt.Fprintf(&buf, "- Created: %s\n", formatExportTime(export.Room.CreatedAt))
+	if export.Room.FinishedAt != nil {
+		fmt.Fprintf(&buf, "- Finished: %s\n", formatExportTime(*export.Room.FinishedAt))
+	}
+	fmt.Fprintf(&buf, "- Participants: %d\n", len(export.Participants))
+	fmt.Fprintf(&buf, "- Estimated tasks: %d of %d\n", export.Room.EstimatedTasksCount, export.Room.TasksCount)
+	if len(export.Room.Dimensions) > 0 {
+		names := make([]string, 0, len(export.Room.Dimensions))
+		for _, dimension := range export.Room.Dimensions {
+			names = append(names, escapeMarkdown(dimension.Name))
+		}
+		fmt.Fprintf(&buf, "- Dimensions: %s\n", strings.Join(names, ", "))
+	}
+
+	buf.WriteString("\n## Tasks\n\n")
+	if len(export.Tasks) == 0 {
+		buf.WriteString("No tasks.\n")
+		return buf.Bytes()
+	}
+
+	buf.WriteString("| Key | Task | Status | Final estimate | Votes |\n")
+	buf.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, task := range export.Tasks {
+		votes := make([]string, 0, len(task.Rounds))
+		for _, round := range task.Rounds {
+			votes = append(votes, fmt.Sprintf("R%d: %s", round.RoundNumber, formatRoundVotes(round, " ", ", ")))
+		}
+
+		finalEstimate := stringValue(task.FinalEstimate)
+		if dimensionEstimates := formatDimensionEstimates(export.Room.Dimensions, task.FinalDimensionEstimates, ", "); dimensionEstimates != "" {
+			finalEstimate += " (" + dimensionEstimates + ")"
+		}
+
+		fmt.Fprintf(
+			&buf,
+			"| %s | %s | %s | %s | %s |\n",
+			escapeMarkdown(stringValue(task.ExternalKey)),
+			escapeMarkdown(task.Title),
+			task.Status,
+			escapeMarkdown(finalEstimate),
+			escapeMarkdown(strings.Join(votes, "<br>")),
+		)
+	}
+
+	return buf.Bytes()
+}
+
+func formatRoundVotes(round historydto.RoomExportRound, valueSeparator, voteSeparator string) string {
+	votes := make([]string, 0, len(round.Votes))
+	for _, vote := range round.Votes {
+		name := vote.Name
+		if vote.Dimension != "" {
+			name += " [" + vote.Dimension + "]"
+		}
+		votes = append(votes, name+valueSeparator+vote.Value)
+	}
+
+	return strings.Join(votes, voteSeparator)
+}
+
+// formatDimensionEstimates lists "key: value" pairs in room dimension order.
+func formatDimensionEstimates(dimensions []historydto.RoomExportDimension, estimates map[string]string, separator string) string {
+	pairs := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		if value, ok := estimates[dimension.Key]; ok {
+			pairs = append(pairs, dimension.Key+": "+value)
+		}
+	}
+
+	return strings.Join(pairs, separator)
+}
+
+func formatExportTime(value time.Time) string {
+	return value.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+// escapeMarkdown keeps user-provided text from breaking the table layout.
+func escapeMarkdown(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	value = strings.ReplaceAll(value, "\n", " ")
+	return strings.ReplaceAll(value, "|", `\|`)
+}
+
+// escapeCSVFormula keeps a spreadsheet from running user-provided text as a
+// formula when the export is opened.
+func escapeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
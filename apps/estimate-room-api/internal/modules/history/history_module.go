@@ -31,6 +31,7 @@ func NewHistoryModule(deps HistoryModuleDeps) *HistoryModule {
 		r.Get("/me/sessions", ctrl.ListMySessions)
 		r.Get("/teams/{id}/sessions", ctrl.ListTeamSessions)
 		r.Get("/rooms/{id}/summary", ctrl.GetRoomSummary)
+		r.Get("/rooms/{id}/export", ctrl.ExportRoomSummary)
 	})
 
 	return &HistoryModule{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	historydto "github.com/master-bogdan/estimate-room-api/internal/modules/history/dto"
 	historyrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/history/repositories"
@@ -18,6 +19,7 @@ type HistoryService interface {
 	ListMySessions(ctx context.Context, userID string, query historydto.MySessionsQuery) (historydto.PaginatedResponse[historydto.SessionListItem], error)
 	ListTeamSessions(ctx context.Context, teamID, userID string, query historydto.TeamSessionsQuery) (historydto.PaginatedResponse[historydto.SessionListItem], error)
 	GetRoomSummary(ctx context.Context, roomID, userID string) (historydto.RoomSummaryResponse, error)
+	ExportRoomSummary(ctx context.Context, roomID, userID string, format historydto.RoomExportFormat) (RoomExportFile, error)
 }
 
 type historyService struct {
@@ -84,7 +86,7 @@ func (s *historyService) GetRoomSummary(
 		return historydto.RoomSummaryResponse{}, apperrors.ErrBadRequest
 	}
 
-	summary, err := s.repo.GetRoomSummary(ctx, roomID)
+	summary, err := s.repo.GetRoomSummary(ctx, roomID, userID)
 	if err != nil {
 		return historydto.RoomSummaryResponse{}, err
 	}
@@ -104,6 +106,21 @@ func (s *historyService) GetRoomSummary(
 	return summary, nil
 }
 
+// ExportRoomSummary renders the room summary in the requested format. Access
+// follows GetRoomSummary.
+func (s *historyService) ExportRoomSummary(
+	ctx context.Context,
+	roomID, userID string,
+	format historydto.RoomExportFormat,
+) (RoomExportFile, error) {
+	summary, err := s.GetRoomSummary(ctx, roomID, userID)
+	if err != nil {
+		return RoomExportFile{}, err
+	}
+
+	return renderRoomExport(historydto.NewRoomExport(summary, time.Now()), format)
+}
+
 func (s *historyService) ensureTeamOwner(teamID, userID string) error {
 	team, err := s.teamRepo.FindByID(teamID)
 	if err != nil {
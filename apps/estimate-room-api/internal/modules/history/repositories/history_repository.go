@@ -9,6 +9,7 @@ import (
 	"time"
 
 	historydto "github.com/master-bogdan/estimate-room-api/internal/modules/history/dto"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/uptrace/bun"
 )
@@ -16,7 +17,7 @@ import (
 type HistoryRepository interface {
 	ListMySessions(ctx context.Context, userID string, query historydto.MySessionsQuery) ([]historydto.SessionListItem, int, error)
 	ListTeamSessions(ctx context.Context, teamID, userID string, query historydto.TeamSessionsQuery) ([]historydto.SessionListItem, int, error)
-	GetRoomSummary(ctx context.Context, roomID string) (historydto.RoomSummaryResponse, error)
+	GetRoomSummary(ctx context.Context, roomID, viewerUserID string) (historydto.RoomSummaryResponse, error)
 }
 
 type historyRepository struct {
@@ -66,9 +67,11 @@ func (r *historyRepository) ListTeamSessions(
 	})
 }
 
+// GetRoomSummary hides who voted what in an anonymous room unless the viewer
+// is the room admin and the room allows it.
 func (r *historyRepository) GetRoomSummary(
 	ctx context.Context,
-	roomID string,
+	roomID, viewerUserID string,
 ) (historydto.RoomSummaryResponse, error) {
 	if ctx == nil {
 		ctx = context.Background()
@@ -79,7 +82,7 @@ func (r *historyRepository) GetRoomSummary(
 		return historydto.RoomSummaryResponse{}, apperrors.ErrBadRequest
 	}
 
-	overview, err := r.getRoomSummaryOverview(ctx, roomID)
+	overview, deck, options, err := r.getRoomSummaryOverview(ctx, roomID)
 	if err != nil {
 		return historydto.RoomSummaryResponse{}, err
 	}
@@ -99,7 +102,13 @@ func (r *historyRepository) GetRoomSummary(
 		return historydto.RoomSummaryResponse{}, err
 	}
 
-	votes, err := r.getRoomSummaryVotes(ctx, roomID)
+	reestimations, err := r.getRoomSummaryReestimations(ctx, roomID)
+	if err != nil {
+		return historydto.RoomSummaryResponse{}, err
+	}
+
+	showVoters := options.ShowsVoters(overview.AdminUser.UserID == strings.TrimSpace(viewerUserID))
+	votes, err := r.getRoomSummaryVotes(ctx, roomID, showVoters)
 	if err != nil {
 		return historydto.RoomSummaryResponse{}, err
 	}
@@ -110,6 +119,11 @@ func (r *historyRepository) GetRoomSummary(
 		roundsByTask[round.TaskID] = append(roundsByTask[round.TaskID], round)
 	}
 
+	reestimationsByTask := make(map[string][]historydto.RoomSummaryTaskReestimation, len(reestimations))
+	for _, reestimation := range reestimations {
+		reestimationsByTask[reestimation.TaskID] = append(reestimationsByTask[reestimation.TaskID], reestimation)
+	}
+
 	votesByTaskRound := make(map[string][]historydto.RoomSummaryVote, len(votes))
 	for _, vote := range votes {
 		key := roomTaskRoundKey(vote.TaskID, vote.RoundNumber)
@@ -121,8 +135,18 @@ func (r *historyRepository) GetRoomSummary(
 		for roundIdx := range taskRounds {
 			key := roomTaskRoundKey(taskRounds[roundIdx].TaskID, taskRounds[roundIdx].RoundNumber)
 			taskRounds[roundIdx].Votes = votesByTaskRound[key]
+			if taskRounds[roundIdx].Status == string(roomsmodels.RoomTaskRoundStatusRevealed) {
+				if len(overview.Dimensions) == 0 {
+					taskRounds[roundIdx].Stats = buildRoomSummaryVoteStats(deck, taskRounds[roundIdx].Votes)
+				} else {
+					taskRounds[roundIdx].DimensionStats = buildRoomSummaryDimensionStats(overview.Dimensions, taskRounds[roundIdx].Votes)
+				}
+			}
 		}
 		tasks[taskIdx].Rounds = taskRounds
+		if taskReestimations, ok := reestimationsByTask[tasks[taskIdx].TaskID]; ok {
+			tasks[taskIdx].Reestimations = taskReestimations
+		}
 	}
 
 	return historydto.RoomSummaryResponse{
@@ -214,24 +238,27 @@ func (r *historyRepository) listSessions(
 func (r *historyRepository) getRoomSummaryOverview(
 	ctx context.Context,
 	roomID string,
-) (historydto.RoomSummaryOverview, error) {
+) (historydto.RoomSummaryOverview, roomsmodels.RoomDeck, roomsmodels.RoomOptions, error) {
 	type roomSummaryOverviewRow struct {
-		RoomID                string     `bun:"room_id"`
-		TeamID                *string    `bun:"team_id"`
-		Name                  string     `bun:"name"`
-		Status                string     `bun:"status"`
-		CreatedAt             time.Time  `bun:"created_at"`
-		FinishedAt            *time.Time `bun:"finished_at"`
-		LastActivityAt        time.Time  `bun:"last_activity_at"`
-		ApproxDurationSeconds int64      `bun:"approx_duration_seconds"`
-		ParticipantsCount     int        `bun:"participants_count"`
-		EstimatedTasksCount   int        `bun:"estimated_tasks_count"`
-		TasksCount            int        `bun:"tasks_count"`
-		RoundCount            int        `bun:"round_count"`
-		AdminUserID           string     `bun:"admin_user_id"`
-		AdminEmail            *string    `bun:"admin_email"`
-		AdminDisplayName      string     `bun:"admin_display_name"`
-		AdminAvatarURL        *string    `bun:"admin_avatar_url"`
+		RoomID                string                     `bun:"room_id"`
+		TeamID                *string                    `bun:"team_id"`
+		Name                  string                     `bun:"name"`
+		Status                string                     `bun:"status"`
+		CreatedAt             time.Time                  `bun:"created_at"`
+		FinishedAt            *time.Time                 `bun:"finished_at"`
+		LastActivityAt        time.Time                  `bun:"last_activity_at"`
+		ApproxDurationSeconds int64                      `bun:"approx_duration_seconds"`
+		ParticipantsCount     int                        `bun:"participants_count"`
+		EstimatedTasksCount   int                        `bun:"estimated_tasks_count"`
+		TasksCount            int                        `bun:"tasks_count"`
+		RoundCount            int                        `bun:"round_count"`
+		Deck                  roomsmodels.RoomDeck       `bun:"deck,type:jsonb"`
+		Dimensions            roomsmodels.RoomDimensions `bun:"dimensions,type:jsonb"`
+		Options               roomsmodels.RoomOptions    `bun:"options,type:jsonb"`
+		AdminUserID           string                     `bun:"admin_user_id"`
+		AdminEmail            *string                    `bun:"admin_email"`
+		AdminDisplayName      string                     `bun:"admin_display_name"`
+		AdminAvatarURL        *string                    `bun:"admin_avatar_url"`
 	}
 
 	row := roomSummaryOverviewRow{}
@@ -270,8 +297,11 @@ func (r *historyRepository) getRoomSummaryOverview(
 				JOIN tasks AS t ON t.task_id = tr.task_id
 				WHERE t.room_id = r.room_id
 			), 0)::int AS round_count,
+			r.deck,
+			r.dimensions,
+			r.options,
 			r.admin_user_id,
-			u.email AS admin_email,
+			CASE WHEN u.deleted_at IS NULL THEN u.email END AS admin_email,
 			u.display_name AS admin_display_name,
 			u.avatar_url AS admin_avatar_url
 		FROM rooms AS r
@@ -282,9 +312,9 @@ func (r *historyRepository) getRoomSummaryOverview(
 
 	if err := r.db.NewRaw(query, roomID).Scan(ctx, &row); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return historydto.RoomSummaryOverview{}, apperrors.ErrNotFound
+			return historydto.RoomSummaryOverview{}, roomsmodels.RoomDeck{}, roomsmodels.RoomOptions{}, apperrors.ErrNotFound
 		}
-		return historydto.RoomSummaryOverview{}, err
+		return historydto.RoomSummaryOverview{}, roomsmodels.RoomDeck{}, roomsmodels.RoomOptions{}, err
 	}
 
 	return historydto.RoomSummaryOverview{
@@ -300,13 +330,15 @@ func (r *historyRepository) getRoomSummaryOverview(
 		EstimatedTasksCount:   row.EstimatedTasksCount,
 		TasksCount:            row.TasksCount,
 		RoundCount:            row.RoundCount,
+		AnonymousVoting:       row.Options.AnonymousVoting,
+		Dimensions:            row.Dimensions,
 		AdminUser: historydto.RoomSummaryUserRef{
 			UserID:      row.AdminUserID,
 			Email:       row.AdminEmail,
 			DisplayName: row.AdminDisplayName,
 			AvatarURL:   row.AdminAvatarURL,
 		},
-	}, nil
+	}, row.Deck, row.Options, nil
 }
 
 func (r *historyRepository) getRoomSummaryParticipants(
@@ -319,7 +351,7 @@ func (r *historyRepository) getRoomSummaryParticipants(
 			rp.room_participants_id AS participant_id,
 			rp.user_id,
 			rp.guest_name,
-			u.email,
+			CASE WHEN u.deleted_at IS NULL THEN u.email END AS email,
 			NULLIF(u.display_name, '') AS display_name,
 			u.avatar_url,
 			rp.role,
@@ -337,6 +369,7 @@ func (r *historyRepository) getRoomSummaryParticipants(
 			rp.user_id,
 			rp.guest_name,
 			u.email,
+			u.deleted_at,
 			u.display_name,
 			u.avatar_url,
 			rp.role,
@@ -366,6 +399,7 @@ func (r *historyRepository) getRoomSummaryTasks(
 			t.status,
 			t.is_active,
 			t.final_estimate_value,
+			t.final_dimension_estimates,
 			t.created_at,
 			t.updated_at,
 			COALESCE((
@@ -383,7 +417,7 @@ func (r *historyRepository) getRoomSummaryTasks(
 			), 0)::int AS round_count
 		FROM tasks AS t
 		WHERE t.room_id = ?
-		ORDER BY t.created_at ASC, t.task_id ASC
+		ORDER BY t.position ASC, t.created_at ASC, t.task_id ASC
 	`
 
 	if err := r.db.NewRaw(query, roomID).Scan(ctx, &tasks); err != nil {
@@ -392,6 +426,7 @@ func (r *historyRepository) getRoomSummaryTasks(
 
 	for idx := range tasks {
 		tasks[idx].Rounds = make([]historydto.RoomSummaryTaskRound, 0)
+		tasks[idx].Reestimations = make([]historydto.RoomSummaryTaskReestimation, 0)
 	}
 
 	return tasks, nil
@@ -409,7 +444,14 @@ func (r *historyRepository) getRoomSummaryRounds(
 			tr.status,
 			tr.created_at,
 			tr.updated_at,
-			tr.eligible_participant_ids
+			tr.eligible_participant_ids,
+			COALESCE((
+				SELECT COUNT(DISTINCT vc.participant_id)
+				FROM vote_changes AS vc
+				WHERE vc.task_id = tr.task_id
+				  AND vc.round_number = tr.round_number
+				  AND vc.action IN ('CHANGED', 'RETRACTED')
+			), 0)::int AS changed_votes_count
 		FROM task_rounds AS tr
 		JOIN tasks AS t ON t.task_id = tr.task_id
 		WHERE t.room_id = ?
@@ -423,11 +465,63 @@ func (r *historyRepository) getRoomSummaryRounds(
 	return rounds, nil
 }
 
+func (r *historyRepository) getRoomSummaryReestimations(
+	ctx context.Context,
+	roomID string,
+) ([]historydto.RoomSummaryTaskReestimation, error) {
+	reestimations := make([]historydto.RoomSummaryTaskReestimation, 0)
+	query := `
+		SELECT
+			tre.task_id,
+			tre.round_number,
+			tre.previous_status,
+			tre.previous_final_estimate_value,
+			tre.created_at
+		FROM task_reestimations AS tre
+		JOIN tasks AS t ON t.task_id = tre.task_id
+		WHERE t.room_id = ?
+		ORDER BY tre.created_at ASC, tre.round_number ASC
+	`
+
+	if err := r.db.NewRaw(query, roomID).Scan(ctx, &reestimations); err != nil {
+		return nil, err
+	}
+
+	return reestimations, nil
+}
+
+// getRoomSummaryVotes leaves the participant columns empty and orders the
+// votes by value when showVoters is false, so votes cannot be matched to
+// participants.
 func (r *historyRepository) getRoomSummaryVotes(
 	ctx context.Context,
 	roomID string,
+	showVoters bool,
 ) ([]historydto.RoomSummaryVote, error) {
 	votes := make([]historydto.RoomSummaryVote, 0)
+	if !showVoters {
+		query := `
+			SELECT
+				v.task_id,
+				v.round_number,
+				v.dimension,
+				v.value,
+				tr.updated_at AS created_at
+			FROM votes AS v
+			JOIN tasks AS t ON t.task_id = v.task_id
+			JOIN task_rounds AS tr ON tr.task_id = v.task_id AND tr.round_number = v.round_number
+			WHERE t.room_id = ?
+			  AND tr.status = 'REVEALED'
+			ORDER BY t.created_at ASC, v.round_number ASC, v.dimension ASC, v.value ASC
+		`
+
+		if err := r.db.NewRaw(query, roomID).Scan(ctx, &votes); err != nil {
+			return nil, err
+		}
+
+		return votes, nil
+	}
+
 	query := `
 		SELECT
 			v.task_id,
@@ -435,9 +529,10 @@ func (r *historyRepository) getRoomSummaryVotes(
 			v.participant_id,
 			rp.user_id,
 			rp.guest_name,
-			u.email,
+			CASE WHEN u.deleted_at IS NULL THEN u.email END AS email,
 			NULLIF(u.display_name, '') AS display_name,
 			u.avatar_url,
+			v.dimension,
 			v.value,
 			v.created_at
 		FROM votes AS v
@@ -516,6 +611,36 @@ func buildListTeamSessionsWhere(teamID string, query historydto.TeamSessionsQuer
 	return whereSQL, args
 }
 
+func buildRoomSummaryVoteStats(deck roomsmodels.RoomDeck, votes []historydto.RoomSummaryVote) *roomsmodels.VoteStats {
+	values := make([]string, 0, len(votes))
+	for _, vote := range votes {
+		values = append(values, vote.Value)
+	}
+
+	stats := roomsmodels.ComputeVoteStats(deck, values)
+	return &stats
+}
+
+// buildRoomSummaryDimensionStats computes the stats of each dimension against
+// its own deck.
+func buildRoomSummaryDimensionStats(
+	dimensions roomsmodels.RoomDimensions,
+	votes []historydto.RoomSummaryVote,
+) map[string]roomsmodels.VoteStats {
+	stats := make(map[string]roomsmodels.VoteStats, len(dimensions))
+	for _, dimension := range dimensions {
+		values := make([]string, 0, len(votes))
+		for _, vote := range votes {
+			if vote.Dimension == dimension.Key {
+				values = append(values, vote.Value)
+			}
+		}
+		stats[dimension.Key] = roomsmodels.ComputeVoteStats(dimension.Deck, values)
+	}
+
+	return stats
+}
+
 func roomTaskRoundKey(taskID string, roundNumber int) string {
 	return taskID + "#" + strconv.Itoa(roundNumber)
 }
@@ -291,6 +291,25 @@ func seedHistoryVote(
 	}
 }
 
+func seedHistoryVoteChange(
+	t *testing.T,
+	db *bun.DB,
+	taskID, participantID string,
+	roundNumber int,
+	action string,
+	previousValue, value *string,
+) {
+	t.Helper()
+
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO vote_changes (task_id, participant_id, round_number, action, previous_value, value)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, taskID, participantID, roundNumber, action, previousValue, value)
+	if err != nil {
+		t.Fatalf("failed to insert vote change: %v", err)
+	}
+}
+
 func TestListMySessions_ReturnsSessionsWithCountsAndOrdering(t *testing.T) {
 	router, db := setupHistoryTest(t)
 	defer db.Close()
@@ -668,6 +687,13 @@ func TestGetRoomSummary_AllowsAdminAndAggregatesDetails(t *testing.T) {
 	seedHistoryVote(t, db, estimatedTaskID, guestParticipantID, 1, "8", roomCreatedAt.Add(16*time.Minute))
 	seedHistoryVote(t, db, votingTaskID, memberParticipantID, 1, "3", roomCreatedAt.Add(50*time.Minute))
 
+	firstValue, changedValue, guestValue := "3", "5", "8"
+	seedHistoryVoteChange(t, db, estimatedTaskID, memberParticipantID, 1, "CAST", nil, &firstValue)
+	seedHistoryVoteChange(t, db, estimatedTaskID, memberParticipantID, 1, "CHANGED", &firstValue, &changedValue)
+	seedHistoryVoteChange(t, db, estimatedTaskID, memberParticipantID, 1, "RETRACTED", &changedValue, nil)
+	seedHistoryVoteChange(t, db, estimatedTaskID, memberParticipantID, 1, "CAST", nil, &changedValue)
+	seedHistoryVoteChange(t, db, estimatedTaskID, guestParticipantID, 1, "CAST", nil, &guestValue)
+
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/history/rooms/"+roomID+"/summary", nil)
 	req.Header.Set("Authorization", "Bearer "+adminToken)
 
@@ -755,6 +781,22 @@ func TestGetRoomSummary_AllowsAdminAndAggregatesDetails(t *testing.T) {
 	if len(estimatedTask.Rounds[0].Votes) != 2 {
 		t.Fatalf("expected 2 revealed votes, got %d", len(estimatedTask.Rounds[0].Votes))
 	}
+	if estimatedTask.Rounds[0].ChangedVotesCount != 1 {
+		t.Fatalf("expected one participant to have changed their vote, got %d", estimatedTask.Rounds[0].ChangedVotesCount)
+	}
+	revealedStats := estimatedTask.Rounds[0].Stats
+	if revealedStats == nil {
+		t.Fatal("expected revealed round to include vote stats")
+	}
+	if revealedStats.Mean == nil || *revealedStats.Mean != 6.5 {
+		t.Fatalf("expected revealed round mean 6.5, got %v", revealedStats.Mean)
+	}
+	if revealedStats.NearestDeckValue == nil || *revealedStats.NearestDeckValue != "8" {
+		t.Fatalf("expected nearest deck value 8, got %v", revealedStats.NearestDeckValue)
+	}
+	if revealedStats.Spread != 1 || !revealedStats.Consensus || revealedStats.ConsensusPercent != 50 {
+		t.Fatalf("expected adjacent votes to reach consensus at 50%%, got spread=%d consensus=%t percent=%v", revealedStats.Spread, revealedStats.Consensus, revealedStats.ConsensusPercent)
+	}
 
 	if activeTask.TaskID == "" {
 		t.Fatal("expected active task in summary")
@@ -768,6 +810,9 @@ func TestGetRoomSummary_AllowsAdminAndAggregatesDetails(t *testing.T) {
 	if len(activeTask.Rounds[0].Votes) != 0 {
 		t.Fatalf("expected unrevealed round to hide votes, got %d", len(activeTask.Rounds[0].Votes))
 	}
+	if activeTask.Rounds[0].Stats != nil {
+		t.Fatal("expected unrevealed round to omit vote stats")
+	}
 }
 
 func TestGetRoomSummary_AllowsTeamOwnerAndRejectsUnauthorizedUsers(t *testing.T) {
@@ -821,3 +866,194 @@ func TestGetRoomSummary_AllowsTeamOwnerAndRejectsUnauthorizedUsers(t *testing.T)
 		t.Fatalf("expected 403 for outsider, got %d: %s", outsiderRR.Code, outsiderRR.Body.String())
 	}
 }
+
+func TestGetRoomSummary_HidesVotersInAnonymousRooms(t *testing.T) {
+	router, db := setupHistoryTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createHistoryAccessToken(t, db, "anonymous-admin@example.com")
+	_, memberUserID := createHistoryAccessToken(t, db, "anonymous-member@example.com")
+
+	roomID := uuid.NewString()
+	createdAt := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Hour)
+	seedHistoryRoom(t, db, roomID, "Anonymous room", adminUserID, nil, "FINISHED", createdAt, finishedAt, &finishedAt)
+
+	memberParticipantID := seedHistoryParticipantWithID(t, db, roomID, memberUserID, "MEMBER", createdAt)
+	guestParticipantID := seedHistoryGuestParticipantWithID(t, db, roomID, "Guest Estimator", "GUEST", createdAt)
+
+	finalEstimate := "5"
+	taskID := seedHistoryTaskWithID(t, db, roomID, "Backend API", "ESTIMATED", false, &finalEstimate, createdAt, createdAt.Add(30*time.Minute))
+	seedHistoryTaskRound(t, db, taskID, 1, "REVEALED", []string{memberParticipantID, guestParticipantID}, createdAt, createdAt.Add(10*time.Minute))
+	seedHistoryVote(t, db, taskID, memberParticipantID, 1, "8", createdAt.Add(time.Minute))
+	seedHistoryVote(t, db, taskID, guestParticipantID, 1, "3", createdAt.Add(2*time.Minute))
+
+	getSummary := func(options string) historydto.RoomSummaryResponse {
+		t.Helper()
+
+		if _, err := db.ExecContext(context.Background(), `
+			UPDATE rooms SET options = $2::jsonb WHERE room_id = $1
+		`, roomID, options); err != nil {
+			t.Fatalf("failed to set room options: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/history/rooms/"+roomID+"/summary", nil)
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+		}
+
+		var response historydto.RoomSummaryResponse
+		if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	response := getSummary(`{"anonymousVoting":true}`)
+	if !response.Overview.AnonymousVoting {
+		t.Fatal("expected overview to report anonymous voting")
+	}
+	votes := response.Tasks[0].Rounds[0].Votes
+	if len(votes) != 2 || votes[0].Value != "3" || votes[1].Value != "8" {
+		t.Fatalf("expected anonymous votes ordered by value, got %+v", votes)
+	}
+	for _, vote := range votes {
+		if vote.ParticipantID != "" || vote.UserID != nil || vote.GuestName != nil || vote.DisplayName != nil {
+			t.Fatalf("expected vote without participant details, got %+v", vote)
+		}
+	}
+	if stats := response.Tasks[0].Rounds[0].Stats; stats == nil {
+		t.Fatal("expected anonymous round to keep its stats")
+	}
+
+	response = getSummary(`{"anonymousVoting":true,"adminSeesVotes":true}`)
+	votes = response.Tasks[0].Rounds[0].Votes
+	if len(votes) != 2 || votes[0].ParticipantID != memberParticipantID || votes[1].ParticipantID != guestParticipantID {
+		t.Fatalf("expected the admin to see voters, got %+v", votes)
+	}
+}
+
+func TestGetRoomSummary_KeepsReplacedEstimatesOfReestimatedTasks(t *testing.T) {
+	router, db := setupHistoryTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createHistoryAccessToken(t, db, "reestimate-admin@example.com")
+
+	roomID := uuid.NewString()
+	roomCreatedAt := time.Date(2026, 3, 17, 9, 0, 0, 0, time.UTC)
+	finishedAt := roomCreatedAt.Add(time.Hour)
+	seedHistoryRoom(t, db, roomID, "Re-estimate", adminUserID, nil, "FINISHED", roomCreatedAt, finishedAt, &finishedAt)
+	adminParticipantID := seedHistoryParticipantWithID(t, db, roomID, adminUserID, "ADMIN", roomCreatedAt)
+
+	finalEstimate := "8"
+	taskID := seedHistoryTaskWithID(t, db, roomID, "Reopened", "ESTIMATED", false, &finalEstimate, roomCreatedAt, finishedAt)
+	for roundNumber := 1; roundNumber <= 2; roundNumber++ {
+		roundStart := roomCreatedAt.Add(time.Duration(roundNumber*10) * time.Minute)
+		seedHistoryTaskRound(t, db, taskID, roundNumber, "REVEALED", []string{adminParticipantID}, roundStart, roundStart.Add(5*time.Minute))
+	}
+	seedHistoryVote(t, db, taskID, adminParticipantID, 1, "3", roomCreatedAt.Add(12*time.Minute))
+	seedHistoryVote(t, db, taskID, adminParticipantID, 2, "8", roomCreatedAt.Add(22*time.Minute))
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO task_reestimations (task_id, round_number, previous_status, previous_final_estimate_value, reopened_by_user_id)
+		VALUES ($1, 2, 'ESTIMATED', '3', $2)
+	`, taskID, adminUserID); err != nil {
+		t.Fatalf("failed to insert task reestimation: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/history/rooms/"+roomID+"/summary", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response historydto.RoomSummaryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode summary response: %v", err)
+	}
+	if len(response.Tasks) != 1 {
+		t.Fatalf("expected one task, got %d", len(response.Tasks))
+	}
+
+	task := response.Tasks[0]
+	if task.RoundCount != 2 || len(task.Rounds) != 2 || len(task.Rounds[0].Votes) != 1 {
+		t.Fatalf("expected both rounds with their votes, got %+v", task.Rounds)
+	}
+	if len(task.Reestimations) != 1 {
+		t.Fatalf("expected one reestimation, got %+v", task.Reestimations)
+	}
+	reestimation := task.Reestimations[0]
+	if reestimation.RoundNumber != 2 || reestimation.PreviousStatus != "ESTIMATED" ||
+		reestimation.PreviousFinalEstimateValue == nil || *reestimation.PreviousFinalEstimateValue != "3" {
+		t.Fatalf("expected the replaced estimate 3 before round 2, got %+v", reestimation)
+	}
+}
+
+func TestGetRoomSummary_IncludesDimensionEstimatesAndStats(t *testing.T) {
+	router, db := setupHistoryTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createHistoryAccessToken(t, db, "dimensions-admin@example.com")
+
+	roomID := uuid.NewString()
+	roomCreatedAt := time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC)
+	finishedAt := roomCreatedAt.Add(time.Hour)
+	seedHistoryRoom(t, db, roomID, "Multi-criteria", adminUserID, nil, "FINISHED", roomCreatedAt, finishedAt, &finishedAt)
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms
+		SET dimensions = '[{"key":"complexity","name":"Complexity","deck":{"name":"Fibonacci","kind":"FIBONACCI","values":["1","2","3","5","8"]}},{"key":"risk","name":"Risk","deck":{"name":"Risk","kind":"CUSTOM","values":["low","high"]}}]'::jsonb
+		WHERE room_id = $1
+	`, roomID); err != nil {
+		t.Fatalf("failed to set room dimensions: %v", err)
+	}
+	adminParticipantID := seedHistoryParticipantWithID(t, db, roomID, adminUserID, "ADMIN", roomCreatedAt)
+
+	finalEstimate := "5"
+	taskID := seedHistoryTaskWithID(t, db, roomID, "Dimensions", "ESTIMATED", false, &finalEstimate, roomCreatedAt, finishedAt)
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE tasks SET final_dimension_estimates = '{"complexity":"5","risk":"high"}'::jsonb WHERE task_id = $1
+	`, taskID); err != nil {
+		t.Fatalf("failed to set final dimension estimates: %v", err)
+	}
+	seedHistoryTaskRound(t, db, taskID, 1, "REVEALED", []string{adminParticipantID}, roomCreatedAt, roomCreatedAt.Add(5*time.Minute))
+	for dimension, value := range map[string]string{"complexity": "5", "risk": "high"} {
+		if _, err := db.ExecContext(context.Background(), `
+			INSERT INTO votes (votes_id, task_id, participant_id, value, round_number, dimension)
+			VALUES ($1, $2, $3, $4, 1, $5)
+		`, uuid.NewString(), taskID, adminParticipantID, value, dimension); err != nil {
+			t.Fatalf("failed to insert %s vote: %v", dimension, err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/history/rooms/"+roomID+"/summary", nil)
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response historydto.RoomSummaryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode summary response: %v", err)
+	}
+	if len(response.Overview.Dimensions) != 2 || response.Overview.Dimensions[1].Key != "risk" {
+		t.Fatalf("expected the room dimensions in the overview, got %+v", response.Overview.Dimensions)
+	}
+	if len(response.Tasks) != 1 || response.Tasks[0].FinalDimensionEstimates["risk"] != "high" {
+		t.Fatalf("expected final dimension estimates on the task, got %+v", response.Tasks)
+	}
+
+	round := response.Tasks[0].Rounds[0]
+	if len(round.Votes) != 2 || round.Stats != nil {
+		t.Fatalf("expected two dimension votes without overall stats, got %+v", round)
+	}
+	if stats, ok := round.DimensionStats["complexity"]; !ok || stats.Median == nil || *stats.Median != 5 {
+		t.Fatalf("expected complexity stats, got %+v", round.DimensionStats)
+	}
+}
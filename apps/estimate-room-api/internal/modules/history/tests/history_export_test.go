@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	historydto "github.com/master-bogdan/estimate-room-api/internal/modules/history/dto"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+func TestNewRoomExport_ListsRevealedRoundsWithParticipantNames(t *testing.T) {
+	userID := uuid.NewString()
+	displayName := "Alice"
+	guestName := "Guest Bob"
+	finalEstimate := "5"
+
+	summary := historydto.RoomSummaryResponse{
+		Overview: historydto.RoomSummaryOverview{RoomID: "room-1", Name: "Sprint", Status: "FINISHED"},
+		Participants: []historydto.RoomSummaryParticipant{
+			{ParticipantID: "p-1", UserID: &userID, DisplayName: &displayName, Role: "ADMIN"},
+			{ParticipantID: "p-2", GuestName: &guestName, Role: "GUEST"},
+		},
+		Tasks: []historydto.RoomSummaryTask{{
+			TaskID:             "task-1",
+			Title:              "Backend API",
+			Status:             "ESTIMATED",
+			FinalEstimateValue: &finalEstimate,
+			Rounds: []historydto.RoomSummaryTaskRound{
+				{RoundNumber: 1, Status: "REVEALED", Votes: []historydto.RoomSummaryVote{
+					{ParticipantID: "p-1", DisplayName: &displayName, Value: "5"},
+					{ParticipantID: "p-2", GuestName: &guestName, Value: "8"},
+				}},
+				{RoundNumber: 2, Status: "ACTIVE", Votes: []historydto.RoomSummaryVote{}},
+			},
+		}},
+	}
+
+	export := historydto.NewRoomExport(summary, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	if export.SchemaVersion != historydto.RoomExportSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", historydto.RoomExportSchemaVersion, export.SchemaVersion)
+	}
+	if len(export.Participants) != 2 || export.Participants[0].IsGuest || !export.Participants[1].IsGuest {
+		t.Fatalf("unexpected participants: %+v", export.Participants)
+	}
+	if len(export.Tasks) != 1 || len(export.Tasks[0].Rounds) != 1 {
+		t.Fatalf("expected one task with one revealed round, got %+v", export.Tasks)
+	}
+
+	votes := export.Tasks[0].Rounds[0].Votes
+	if votes[0].Name != "Alice" || votes[1].Name != "Guest Bob" || votes[1].Value != "8" {
+		t.Fatalf("unexpected votes: %+v", votes)
+	}
+}
+
+func TestNewRoomExport_CarriesDimensionEstimatesAndVotes(t *testing.T) {
+	displayName := "Alice"
+	finalEstimate := "5"
+	deck := roomsmodels.DefaultRoomDeck()
+
+	summary := historydto.RoomSummaryResponse{
+		Overview: historydto.RoomSummaryOverview{
+			RoomID: "room-1",
+			Name:   "Sprint",
+			Dimensions: roomsmodels.RoomDimensions{
+				{Key: "complexity", Name: "Complexity", Deck: deck},
+				{Key: "risk", Name: "Risk", Deck: deck},
+			},
+		},
+		Tasks: []historydto.RoomSummaryTask{{
+			TaskID:                  "task-1",
+			Title:                   "Backend API",
+			Status:                  "ESTIMATED",
+			FinalEstimateValue:      &finalEstimate,
+			FinalDimensionEstimates: map[string]string{"complexity": "5", "risk": "3"},
+			Rounds: []historydto.RoomSummaryTaskRound{
+				{RoundNumber: 1, Status: "REVEALED", Votes: []historydto.RoomSummaryVote{
+					{ParticipantID: "p-1", DisplayName: &displayName, Dimension: "complexity", Value: "5"},
+					{ParticipantID: "p-1", DisplayName: &displayName, Dimension: "risk", Value: "3"},
+				}},
+			},
+		}},
+	}
+
+	export := historydto.NewRoomExport(summary, time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC))
+
+	if len(export.Room.Dimensions) != 2 || export.Room.Dimensions[0].Key != "complexity" {
+		t.Fatalf("unexpected dimensions: %+v", export.Room.Dimensions)
+	}
+	if export.Tasks[0].FinalDimensionEstimates["risk"] != "3" {
+		t.Fatalf("expected final dimension estimates, got %+v", export.Tasks[0])
+	}
+	votes := export.Tasks[0].Rounds[0].Votes
+	if votes[0].Dimension != "complexity" || votes[1].Dimension != "risk" {
+		t.Fatalf("expected votes tagged with their dimension, got %+v", votes)
+	}
+}
+
+func TestExportRoomSummary_RendersFormatsWithSummaryAccessRules(t *testing.T) {
+	router, db := setupHistoryTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createHistoryAccessToken(t, db, "export-admin@example.com")
+	_, memberUserID := createHistoryAccessToken(t, db, "export-member@example.com")
+	outsiderToken, _ := createHistoryAccessToken(t, db, "export-outsider@example.com")
+
+	roomID := uuid.NewString()
+	createdAt := time.Date(2026, 3, 15, 9, 0, 0, 0, time.UTC)
+	finishedAt := createdAt.Add(time.Hour)
+	seedHistoryRoom(t, db, roomID, "Sprint | Planning", adminUserID, nil, "FINISHED", createdAt, finishedAt, &finishedAt)
+
+	memberParticipantID := seedHistoryParticipantWithID(t, db, roomID, memberUserID, "MEMBER", createdAt)
+	guestParticipantID := seedHistoryGuestParticipantWithID(t, db, roomID, "Guest Estimator", "GUEST", createdAt)
+
+	finalEstimate := "5"
+	taskID := seedHistoryTaskWithID(t, db, roomID, "Backend API", "ESTIMATED", false, &finalEstimate, createdAt, createdAt.Add(30*time.Minute))
+	seedHistoryTaskRound(t, db, taskID, 1, "REVEALED", []string{memberParticipantID, guestParticipantID}, createdAt, createdAt.Add(10*time.Minute))
+	seedHistoryTaskRound(t, db, taskID, 2, "REVEALED", []string{memberParticipantID, guestParticipantID}, createdAt.Add(10*time.Minute), createdAt.Add(20*time.Minute))
+	seedHistoryVote(t, db, taskID, memberParticipantID, 1, "3", createdAt.Add(time.Minute))
+	seedHistoryVote(t, db, taskID, guestParticipantID, 1, "8", createdAt.Add(2*time.Minute))
+	seedHistoryVote(t, db, taskID, memberParticipantID, 2, "5", createdAt.Add(11*time.Minute))
+	seedHistoryVote(t, db, taskID, guestParticipantID, 2, "5", createdAt.Add(12*time.Minute))
+	seedHistoryTaskWithID(t, db, roomID, "=Frontend polish", "PENDING", false, nil, createdAt.Add(time.Minute), createdAt.Add(time.Minute))
+
+	export := func(token, format string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/history/rooms/"+roomID+"/export?format="+format, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := export(adminToken, "csv")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for csv export, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if !strings.HasPrefix(rr.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("unexpected csv content type %q", rr.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rr.Header().Get("Content-Disposition"), "room-"+roomID+".csv") {
+		t.Fatalf("unexpected content disposition %q", rr.Header().Get("Content-Disposition"))
+	}
+
+	records, err := csv.NewReader(rr.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read csv export: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected header and 2 task rows, got %d", len(records))
+	}
+	if strings.Join(records[0], ",") != "external_key,title,status,final_estimate,rounds,round_1_votes,round_2_votes" {
+		t.Fatalf("unexpected csv header %v", records[0])
+	}
+	if records[1][1] != "Backend API" || records[1][3] != "5" || records[1][4] != "2" {
+		t.Fatalf("unexpected estimated task row %v", records[1])
+	}
+	if !strings.Contains(records[1][5], "Guest Estimator: 8") || !strings.Contains(records[1][6], "Guest Estimator: 5") {
+		t.Fatalf("expected per-round votes, got %v", records[1])
+	}
+	if records[2][1] != "'=Frontend polish" || records[2][4] != "0" || records[2][5] != "" {
+		t.Fatalf("unexpected pending task row %v", records[2])
+	}
+
+	rr = export(adminToken, "markdown")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for markdown export, got %d: %s", rr.Code, rr.Body.String())
+	}
+	markdown := rr.Body.String()
+	if !strings.HasPrefix(markdown, `# Sprint \| Planning`) {
+		t.Fatalf("expected escaped room title, got %q", markdown)
+	}
+	if !strings.Contains(markdown, "| Backend API | ESTIMATED | 5 |") || !strings.Contains(markdown, "R2: ") {
+		t.Fatalf("expected task table in markdown, got %q", markdown)
+	}
+
+	rr = export(adminToken, "json")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for json export, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var exportResponse historydto.RoomExport
+	if err := json.NewDecoder(rr.Body).Decode(&exportResponse); err != nil {
+		t.Fatalf("failed to decode json export: %v", err)
+	}
+	if exportResponse.SchemaVersion != historydto.RoomExportSchemaVersion || exportResponse.Room.RoomID != roomID {
+		t.Fatalf("unexpected json export header %+v", exportResponse.Room)
+	}
+	if len(exportResponse.Tasks) != 2 || len(exportResponse.Tasks[0].Rounds) != 2 {
+		t.Fatalf("unexpected json export tasks %+v", exportResponse.Tasks)
+	}
+	if stats := exportResponse.Tasks[0].Rounds[1].Stats; stats == nil || !stats.Consensus {
+		t.Fatalf("expected consensus stats on the second round, got %+v", stats)
+	}
+
+	rr = export(adminToken, "xml")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = export(outsiderToken, "csv")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for outsider, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
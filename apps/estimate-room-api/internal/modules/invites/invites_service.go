@@ -3,6 +3,7 @@ package invites
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -57,6 +58,8 @@ const (
 	guestTokenTTL         = 30 * 24 * time.Hour
 )
 
+var errRoomLocked = fmt.Errorf("%w: room is locked", apperrors.ErrForbidden)
+
 type InvitesService interface {
 	CreateInvitation(ctx context.Context, input CreateInvitationInput) (*invitesmodels.InvitationModel, string, error)
 	CreateInvitationWithDB(ctx context.Context, db bun.IDB, input CreateInvitationInput) (*invitesmodels.InvitationModel, string, error)
@@ -301,7 +304,7 @@ func (s *invitesService) ValidateGuestRoomAccess(roomID, guestToken string) (*ro
 		return nil, err
 	}
 
-	if participant.Role != roomsmodels.RoomParticipantRoleGuest {
+	if !participant.IsGuest() {
 		return nil, apperrors.ErrForbidden
 	}
 
@@ -516,10 +519,14 @@ func (s *invitesService) acceptRoomLinkInvitation(
 		}
 
 		if normalizedActorUserID != "" {
-			participant, err = s.joinRegisteredRoomParticipant(participantRepo, room.RoomID, normalizedActorUserID)
+			participant, err = s.joinRegisteredRoomParticipant(participantRepo, room, normalizedActorUserID)
 			return err
 		}
 
+		if room.IsLocked() {
+			return errRoomLocked
+		}
+
 		participant, err = s.joinGuestRoomParticipant(participantRepo, room.RoomID, trimmedGuestName)
 		if err != nil {
 			return err
@@ -599,19 +606,26 @@ func (s *invitesService) acceptTeamMemberInvitation(
 	return s.invitationRepo.FindByID(ctx, invitation.InvitationID)
 }
 
+// joinRegisteredRoomParticipant lets a user who is already in a locked room
+// reuse the link, but does not admit anyone new.
 func (s *invitesService) joinRegisteredRoomParticipant(
 	participantRepo roomsrepositories.RoomParticipantRepository,
-	roomID, actorUserID string,
+	room *roomsmodels.RoomsModel,
+	actorUserID string,
 ) (*roomsmodels.RoomParticipantModel, error) {
-	participant, err := participantRepo.FindActiveByUserID(roomID, actorUserID)
+	participant, err := participantRepo.FindActiveByUserID(room.RoomID, actorUserID)
 	if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
 		return nil, err
 	}
 
 	if participant == nil {
+		if room.IsLocked() {
+			return nil, errRoomLocked
+		}
+
 		participant, err = participantRepo.Create(&roomsmodels.RoomParticipantModel{
 			RoomParticipantID: uuid.NewString(),
-			RoomID:            roomID,
+			RoomID:            room.RoomID,
 			UserID:            &actorUserID,
 			Role:              roomsmodels.RoomParticipantRoleMember,
 		})
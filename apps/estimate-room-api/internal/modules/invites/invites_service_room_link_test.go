@@ -147,3 +147,53 @@ func TestAcceptRoomLinkInvitation_RejectsRevokedStaleInvitationForGuest(t *testi
 		t.Fatalf("expected no active room participants after rejected guest join, got %d", count)
 	}
 }
+
+func TestAcceptRoomLinkInvitation_RejectsNewParticipantsWhileRoomLocked(t *testing.T) {
+	db, svc := setupInternalInvitesTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "room-link-locked-admin@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "room-link-locked-member@example.com", "password123")
+	roomID := seedInternalInviteRoom(t, db, adminUserID, "room-link-locked", "room-link-locked")
+
+	_, token, err := svc.CreateInvitation(context.Background(), CreateInvitationInput{
+		Kind:            invitesmodels.InvitationKindRoomLink,
+		RoomID:          &roomID,
+		CreatedByUserID: adminUserID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create room link invitation: %v", err)
+	}
+
+	invitation, err := svc.PreviewInvitation(context.Background(), token)
+	if err != nil {
+		t.Fatalf("failed to preview invitation: %v", err)
+	}
+
+	if _, err := db.ExecContext(context.Background(), `UPDATE rooms SET locked_at = NOW() WHERE room_id = $1`, roomID); err != nil {
+		t.Fatalf("failed to lock room: %v", err)
+	}
+
+	guestName := "Late Guest"
+	if _, err := svc.acceptRoomLinkInvitation(context.Background(), invitation, "", &guestName); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Fatalf("expected forbidden guest join while locked, got %v", err)
+	}
+	if _, err := svc.acceptRoomLinkInvitation(context.Background(), invitation, memberUserID, nil); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Fatalf("expected forbidden member join while locked, got %v", err)
+	}
+	if count := countActiveRoomParticipants(t, db, roomID); count != 0 {
+		t.Fatalf("expected no active room participants while locked, got %d", count)
+	}
+
+	if _, err := db.ExecContext(context.Background(), `UPDATE rooms SET locked_at = NULL WHERE room_id = $1`, roomID); err != nil {
+		t.Fatalf("failed to unlock room: %v", err)
+	}
+
+	result, err := svc.acceptRoomLinkInvitation(context.Background(), invitation, "", &guestName)
+	if err != nil {
+		t.Fatalf("expected guest join after unlock, got %v", err)
+	}
+	if result.GuestToken == "" {
+		t.Fatal("expected guest token after unlock")
+	}
+}
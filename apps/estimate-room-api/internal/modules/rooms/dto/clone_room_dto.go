@@ -0,0 +1,15 @@
+package roomsdto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type CloneRoomDTO struct {
+	Name               *string `json:"name" validate:"omitempty,min=1,max=30"`
+	InviteParticipants bool    `json:"inviteParticipants"`
+}
+
+func (s *CloneRoomDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
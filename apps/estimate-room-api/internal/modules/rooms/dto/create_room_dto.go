@@ -4,11 +4,33 @@ package roomsdto
 import "github.com/go-playground/validator/v10"
 
 type CreateRoomDTO struct {
-	Name            string             `json:"name" validate:"required,min=1,max=30"`
-	InviteTeamID    string             `json:"inviteTeamId" validate:"omitempty"`
-	InviteEmails    []string           `json:"inviteEmails" validate:"omitempty,max=200,dive,email,max=255"`
-	CreateShareLink bool               `json:"createShareLink"`
-	Deck            *CreateRoomDeckDTO `json:"deck" validate:"omitempty"`
+	Name            string                `json:"name" validate:"required,min=1,max=30"`
+	InviteTeamID    string                `json:"inviteTeamId" validate:"omitempty"`
+	InviteEmails    []string              `json:"inviteEmails" validate:"omitempty,max=200,dive,email,max=255"`
+	CreateShareLink bool                  `json:"createShareLink"`
+	Deck            *CreateRoomDeckDTO    `json:"deck" validate:"omitempty,excluded_with=DeckID"`
+	DeckID          string                `json:"deckId" validate:"omitempty,max=100"`
+	Options         *CreateRoomOptionsDTO `json:"options" validate:"omitempty"`
+	// Dimensions turns the room into a multi-criteria room where every round
+	// collects one vote per dimension.
+	Dimensions []CreateRoomDimensionDTO `json:"dimensions" validate:"omitempty,max=5,dive"`
+	// Mode is LIVE by default; ASYNC rooms are voted on over REST against a
+	// deadline and never expire for inactivity.
+	Mode string `json:"mode" validate:"omitempty,oneof=LIVE ASYNC"`
+}
+
+type CreateRoomDimensionDTO struct {
+	Key  string            `json:"key" validate:"required,min=1,max=32"`
+	Name string            `json:"name" validate:"required,min=1,max=50"`
+	Deck CreateRoomDeckDTO `json:"deck" validate:"required"`
+}
+
+type CreateRoomOptionsDTO struct {
+	CreateShareLink    bool `json:"createShareLink"`
+	AutoReveal         bool `json:"autoReveal"`
+	VotingTimerSeconds int  `json:"votingTimerSeconds" validate:"omitempty,min=10,max=3600"`
+	AnonymousVoting    bool `json:"anonymousVoting"`
+	AdminSeesVotes     bool `json:"adminSeesVotes"`
 }
 
 type CreateRoomDeckDTO struct {
@@ -0,0 +1,101 @@
+package roomsdto
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const MaxImportedRoomTasks = 200
+
+// ImportRoomTasksDTO is a pasted backlog. JSON bodies are an array of
+// CreateRoomTaskDTO; CSV bodies need a header row with a title column and
+// optional description and external_key columns.
+type ImportRoomTasksDTO struct {
+	Tasks []CreateRoomTaskDTO
+}
+
+// ParseImportRoomTasksCSV reads a CSV backlog. Blank lines are skipped.
+func ParseImportRoomTasksCSV(r io.Reader) (*ImportRoomTasksDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv header row is required")
+		}
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for idx, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		key = strings.ReplaceAll(key, "_", "")
+		switch key {
+		case "title", "description", "externalkey":
+			columns[key] = idx
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("csv header must include a title column")
+	}
+
+	column := func(record []string, key string) string {
+		idx, ok := columns[key]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	dto := &ImportRoomTasksDTO{Tasks: make([]CreateRoomTaskDTO, 0)}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		task := CreateRoomTaskDTO{
+			Title:       column(record, "title"),
+			Description: column(record, "description"),
+			ExternalKey: column(record, "externalkey"),
+		}
+		if task == (CreateRoomTaskDTO{}) {
+			continue
+		}
+
+		dto.Tasks = append(dto.Tasks, task)
+	}
+
+	return dto, nil
+}
+
+// Validate checks every task and reports the first invalid one by its
+// 1-based position in the import.
+func (s *ImportRoomTasksDTO) Validate() error {
+	if len(s.Tasks) == 0 {
+		return errors.New("at least one task is required")
+	}
+	if len(s.Tasks) > MaxImportedRoomTasks {
+		return fmt.Errorf("at most %d tasks can be imported at once", MaxImportedRoomTasks)
+	}
+
+	validate := validator.New()
+	for idx := range s.Tasks {
+		s.Tasks[idx].Title = strings.TrimSpace(s.Tasks[idx].Title)
+		if err := validate.Struct(&s.Tasks[idx]); err != nil {
+			return fmt.Errorf("task %d: %w", idx+1, err)
+		}
+	}
+
+	return nil
+}
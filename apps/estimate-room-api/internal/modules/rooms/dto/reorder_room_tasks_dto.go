@@ -0,0 +1,18 @@
+package roomsdto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// ReorderRoomTasksDTO moves TaskIDs, in the given order, so the first of them
+// lands at the 0-based Position of the backlog. Listing every task with
+// position 0 replaces the whole order.
+type ReorderRoomTasksDTO struct {
+	TaskIDs  []string `json:"taskIds" validate:"required,min=1,unique,dive,required"`
+	Position int      `json:"position" validate:"min=0"`
+}
+
+func (s *ReorderRoomTasksDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
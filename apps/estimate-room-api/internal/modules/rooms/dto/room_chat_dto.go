@@ -0,0 +1,97 @@
+package roomsdto
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+const (
+	DefaultChatMessagesLimit = 50
+	MaxChatMessagesLimit     = 100
+)
+
+// RoomChatMessagesQuery selects a page of the room channel, or of a task
+// thread when TaskID is set. Before is the ID of the oldest message already
+// loaded.
+type RoomChatMessagesQuery struct {
+	TaskID *string
+	Before string
+	Limit  int
+}
+
+type RoomChatMessageResponse struct {
+	MessageID     string    `json:"messageId"`
+	TaskID        *string   `json:"taskId,omitempty"`
+	ParticipantID string    `json:"participantId"`
+	UserID        *string   `json:"userId,omitempty"`
+	GuestName     *string   `json:"guestName,omitempty"`
+	DisplayName   *string   `json:"displayName,omitempty"`
+	Body          string    `json:"body"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// RoomChatMessagesResponse lists messages oldest first. HasMore reports older
+// messages; pass the first item's messageId as before to load them.
+type RoomChatMessagesResponse struct {
+	Items   []RoomChatMessageResponse `json:"items"`
+	HasMore bool                      `json:"hasMore"`
+}
+
+func ParseRoomChatMessagesQuery(values url.Values) (RoomChatMessagesQuery, error) {
+	query := RoomChatMessagesQuery{
+		Before: strings.TrimSpace(values.Get("before")),
+		Limit:  DefaultChatMessagesLimit,
+	}
+
+	if taskID := strings.TrimSpace(values.Get("taskId")); taskID != "" {
+		query.TaskID = &taskID
+	}
+
+	if rawLimit := strings.TrimSpace(values.Get("limit")); rawLimit != "" {
+		limit, err := strconv.Atoi(rawLimit)
+		if err != nil || limit < 1 {
+			return RoomChatMessagesQuery{}, fmt.Errorf("limit must be a positive integer")
+		}
+		if limit > MaxChatMessagesLimit {
+			limit = MaxChatMessagesLimit
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+func NewRoomChatMessageResponse(message *roomsmodels.RoomChatMessageModel) RoomChatMessageResponse {
+	response := RoomChatMessageResponse{
+		MessageID:     message.ChatMessageID,
+		TaskID:        message.TaskID,
+		ParticipantID: message.ParticipantID,
+		Body:          message.Body,
+		CreatedAt:     message.CreatedAt,
+	}
+
+	if participant := message.Participant; participant != nil {
+		response.UserID = participant.UserID
+		response.GuestName = participant.GuestName
+		if participant.User != nil && participant.User.DisplayName != "" {
+			displayName := participant.User.DisplayName
+			response.DisplayName = &displayName
+		}
+	}
+
+	return response
+}
+
+func NewRoomChatMessageResponses(messages []*roomsmodels.RoomChatMessageModel) []RoomChatMessageResponse {
+	responses := make([]RoomChatMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		responses = append(responses, NewRoomChatMessageResponse(message))
+	}
+
+	return responses
+}
@@ -0,0 +1,42 @@
+package roomsdto
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// OpenRoomTaskDTO opens a task of an async room for voting until Deadline.
+type OpenRoomTaskDTO struct {
+	Deadline time.Time `json:"deadline" validate:"required"`
+}
+
+func (s *OpenRoomTaskDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+// CastRoomTaskVoteDTO is a vote cast over REST in an async room. Dimension is
+// required in multi-criteria rooms only.
+type CastRoomTaskVoteDTO struct {
+	Dimension string `json:"dimension" validate:"omitempty,max=32"`
+	Value     string `json:"value" validate:"required,max=20"`
+}
+
+func (s *CastRoomTaskVoteDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+// RoomTaskVotingResponse is the voting round of an async task as the caller
+// sees it. Votes stay hidden until the round is revealed, so only who has
+// voted is listed.
+type RoomTaskVotingResponse struct {
+	TaskID                 string     `json:"taskId"`
+	RoundNumber            int        `json:"roundNumber"`
+	RoundStatus            string     `json:"roundStatus"`
+	Deadline               *time.Time `json:"deadline"`
+	EligibleParticipantIDs []string   `json:"eligibleParticipantIds"`
+	VotedParticipantIDs    []string   `json:"votedParticipantIds"`
+	VotedDimensions        []string   `json:"votedDimensions"`
+}
@@ -0,0 +1,14 @@
+package roomsdto
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type TransferRoomAdminDTO struct {
+	ParticipantID string `json:"participantId" validate:"required"`
+}
+
+func (s *TransferRoomAdminDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
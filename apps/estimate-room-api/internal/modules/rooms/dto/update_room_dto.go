@@ -5,8 +5,18 @@ import (
 )
 
 type UpdateRoomDTO struct {
-	Name   *string `json:"name" validate:"omitempty,min=1,max=30"`
-	Status *string `json:"status" validate:"omitempty,oneof=ACTIVE FINISHED EXPIRED"`
+	Name    *string               `json:"name" validate:"omitempty,min=1,max=30"`
+	Status  *string               `json:"status" validate:"omitempty,oneof=ACTIVE FINISHED EXPIRED"`
+	Options *UpdateRoomOptionsDTO `json:"options" validate:"omitempty"`
+}
+
+// UpdateRoomOptionsDTO sets votingTimerSeconds to 0 to turn the timer off.
+// anonymousVoting can be turned on but not off.
+type UpdateRoomOptionsDTO struct {
+	AutoReveal         *bool `json:"autoReveal"`
+	VotingTimerSeconds *int  `json:"votingTimerSeconds" validate:"omitempty,min=0,max=3600"`
+	AnonymousVoting    *bool `json:"anonymousVoting"`
+	AdminSeesVotes     *bool `json:"adminSeesVotes"`
 }
 
 func (s *UpdateRoomDTO) Validate() error {
@@ -11,6 +11,9 @@ type UpdateRoomTaskDTO struct {
 	Status             *string `json:"status" validate:"omitempty,oneof=PENDING VOTING ESTIMATED SKIPPED"`
 	IsActive           *bool   `json:"isActive"`
 	FinalEstimateValue *string `json:"finalEstimateValue" validate:"omitempty,max=255"`
+	// FinalDimensionEstimates maps each dimension key of a multi-criteria room
+	// to its final estimate.
+	FinalDimensionEstimates map[string]string `json:"finalDimensionEstimates" validate:"omitempty,max=5,dive,keys,required,max=32,endkeys,required,max=20"`
 }
 
 func (s *UpdateRoomTaskDTO) Validate() error {
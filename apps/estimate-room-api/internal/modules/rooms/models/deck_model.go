@@ -1,6 +1,15 @@
 package roomsmodels
 
-import "strings"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	DeckValueTypeNumeric    = "NUMERIC"
+	DeckValueTypeNonNumeric = "NON_NUMERIC"
+)
 
 type RoomDeck struct {
 	Name   string   `json:"name"`
@@ -51,3 +60,22 @@ func (d RoomDeck) IsValid() bool {
 
 	return true
 }
+
+// NumericDeckValue parses a card value as a number. Cards such as "?" or
+// "XL" are non-numeric and report false.
+func NumericDeckValue(value string) (float64, bool) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+		return 0, false
+	}
+
+	return parsed, true
+}
+
+func DeckValueType(value string) string {
+	if _, ok := NumericDeckValue(value); ok {
+		return DeckValueTypeNumeric
+	}
+
+	return DeckValueTypeNonNumeric
+}
@@ -0,0 +1,25 @@
+package roomsmodels
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// MaxChatMessageLength caps a chat message body, in characters.
+const MaxChatMessageLength = 1000
+
+// RoomChatMessageModel is a message in the room chat. Messages with a TaskID
+// belong to that task's discussion thread.
+type RoomChatMessageModel struct {
+	bun.BaseModel `bun:"table:chat_messages,alias:cm"`
+
+	ChatMessageID string    `bun:"chat_message_id,pk"`
+	RoomID        string    `bun:"room_id"`
+	TaskID        *string   `bun:"task_id"`
+	ParticipantID string    `bun:"participant_id"`
+	Body          string    `bun:"body"`
+	CreatedAt     time.Time `bun:"created_at"`
+
+	Participant *RoomParticipantModel `bun:"rel:belongs-to,join:participant_id=room_participants_id"`
+}
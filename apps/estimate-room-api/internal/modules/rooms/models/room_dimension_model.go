@@ -0,0 +1,70 @@
+package roomsmodels
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MaxRoomDimensions caps how many dimensions a room can estimate at once.
+const MaxRoomDimensions = 5
+
+var roomDimensionKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// RoomDimension is one criterion a multi-criteria room estimates, such as
+// complexity or risk, with its own deck.
+type RoomDimension struct {
+	Key  string   `json:"key"`
+	Name string   `json:"name"`
+	Deck RoomDeck `json:"deck"`
+}
+
+// RoomDimensions lists the criteria of a room. A room without dimensions
+// collects one vote per participant from the room deck.
+type RoomDimensions []RoomDimension
+
+func (d RoomDimensions) IsValid() bool {
+	if len(d) > MaxRoomDimensions {
+		return false
+	}
+
+	keys := make(map[string]struct{}, len(d))
+	for _, dimension := range d {
+		if !roomDimensionKeyPattern.MatchString(dimension.Key) {
+			return false
+		}
+		if strings.TrimSpace(dimension.Name) == "" || !dimension.Deck.IsValid() {
+			return false
+		}
+		if _, exists := keys[dimension.Key]; exists {
+			return false
+		}
+		keys[dimension.Key] = struct{}{}
+	}
+
+	return true
+}
+
+func (d RoomDimensions) Find(key string) (RoomDimension, bool) {
+	for _, dimension := range d {
+		if dimension.Key == key {
+			return dimension, true
+		}
+	}
+
+	return RoomDimension{}, false
+}
+
+// Keys returns the dimension keys in room order. A room without dimensions
+// has the single blank key its votes are stored under.
+func (d RoomDimensions) Keys() []string {
+	if len(d) == 0 {
+		return []string{""}
+	}
+
+	keys := make([]string, 0, len(d))
+	for _, dimension := range d {
+		keys = append(keys, dimension.Key)
+	}
+
+	return keys
+}
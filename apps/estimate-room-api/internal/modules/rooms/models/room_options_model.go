@@ -0,0 +1,45 @@
+package roomsmodels
+
+import "time"
+
+const (
+	MinVotingTimerSeconds = 10
+	MaxVotingTimerSeconds = 3600
+)
+
+// RoomOptions are per-room preferences chosen at creation time. Users can
+// store a default set in their settings so they are not re-entered for every
+// session.
+type RoomOptions struct {
+	CreateShareLink bool `json:"createShareLink"`
+	// AutoReveal reveals the round as soon as every eligible participant has
+	// voted.
+	AutoReveal bool `json:"autoReveal"`
+	// VotingTimerSeconds starts a countdown with every round that reveals it
+	// on expiry. Zero disables the timer.
+	VotingTimerSeconds int `json:"votingTimerSeconds"`
+	// AnonymousVoting reveals only the value distribution of a round; who
+	// voted what stays hidden. It cannot be turned off again.
+	AnonymousVoting bool `json:"anonymousVoting"`
+	// AdminSeesVotes lets the room admin still see who voted what in an
+	// anonymous room.
+	AdminSeesVotes bool `json:"adminSeesVotes"`
+}
+
+func (o RoomOptions) IsValid() bool {
+	if o.VotingTimerSeconds == 0 {
+		return true
+	}
+
+	return o.VotingTimerSeconds >= MinVotingTimerSeconds && o.VotingTimerSeconds <= MaxVotingTimerSeconds
+}
+
+// ShowsVoters reports whether revealed votes may be paired with participants
+// for the viewer.
+func (o RoomOptions) ShowsVoters(viewerIsAdmin bool) bool {
+	return !o.AnonymousVoting || (viewerIsAdmin && o.AdminSeesVotes)
+}
+
+func (o RoomOptions) VotingTimer() time.Duration {
+	return time.Duration(o.VotingTimerSeconds) * time.Second
+}
@@ -10,20 +10,28 @@ import (
 type RoomParticipantRole string
 
 const (
-	RoomParticipantRoleAdmin  RoomParticipantRole = "ADMIN"
-	RoomParticipantRoleMember RoomParticipantRole = "MEMBER"
-	RoomParticipantRoleGuest  RoomParticipantRole = "GUEST"
+	RoomParticipantRoleAdmin       RoomParticipantRole = "ADMIN"
+	RoomParticipantRoleMember      RoomParticipantRole = "MEMBER"
+	RoomParticipantRoleGuest       RoomParticipantRole = "GUEST"
+	RoomParticipantRoleObserver    RoomParticipantRole = "OBSERVER"
+	RoomParticipantRoleFacilitator RoomParticipantRole = "FACILITATOR"
 )
 
 func (r RoomParticipantRole) IsValid() bool {
 	switch r {
-	case RoomParticipantRoleAdmin, RoomParticipantRoleMember, RoomParticipantRoleGuest:
+	case RoomParticipantRoleAdmin, RoomParticipantRoleMember, RoomParticipantRoleGuest, RoomParticipantRoleObserver, RoomParticipantRoleFacilitator:
 		return true
 	default:
 		return false
 	}
 }
 
+// CanFacilitate reports whether the role may run the session: manage tasks
+// and drive the voting rounds.
+func (r RoomParticipantRole) CanFacilitate() bool {
+	return r == RoomParticipantRoleAdmin || r == RoomParticipantRoleFacilitator
+}
+
 type RoomParticipantModel struct {
 	bun.BaseModel `bun:"table:room_participants,alias:rp"`
 
@@ -39,3 +47,9 @@ type RoomParticipantModel struct {
 	Votes []*RoomVoteModel       `bun:"rel:has-many,join:room_participants_id=participant_id"`
 	Room  *RoomsModel            `bun:"rel:belongs-to,join:room_id=room_id" json:"-"`
 }
+
+// IsGuest reports whether the participant joined through a guest token. Guests
+// keep this identity when they are switched to observers.
+func (p *RoomParticipantModel) IsGuest() bool {
+	return p.UserID == nil
+}
@@ -0,0 +1,35 @@
+package roomsmodels
+
+import (
+	"slices"
+	"strings"
+)
+
+// RoomFlag is a signal a participant raises in a room until they, or a
+// facilitator, lower it again. Flags only live in Redis while the room is
+// active.
+type RoomFlag string
+
+const (
+	RoomFlagCoffeeBreak RoomFlag = "COFFEE_BREAK"
+	RoomFlagNeedInfo    RoomFlag = "NEED_INFO"
+)
+
+// RoomFlags lists the flags participants can raise.
+var RoomFlags = []RoomFlag{RoomFlagCoffeeBreak, RoomFlagNeedInfo}
+
+func (f RoomFlag) IsValid() bool {
+	switch f {
+	case RoomFlagCoffeeBreak, RoomFlagNeedInfo:
+		return true
+	default:
+		return false
+	}
+}
+
+// RoomReactions lists the emoji participants can react with.
+var RoomReactions = []string{"👍", "👎", "🎉", "😂", "🤔", "😮", "❤️", "👀", "🔥", "☕"}
+
+func IsRoomReaction(emoji string) bool {
+	return slices.Contains(RoomReactions, strings.TrimSpace(emoji))
+}
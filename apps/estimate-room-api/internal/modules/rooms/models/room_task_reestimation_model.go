@@ -0,0 +1,21 @@
+package roomsmodels
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// RoomTaskReestimationModel records a task that was moved back to voting
+// after it had been estimated or skipped, keeping the outcome it replaced.
+type RoomTaskReestimationModel struct {
+	bun.BaseModel `bun:"table:task_reestimations,alias:tre"`
+
+	TaskReestimationID         string    `bun:"task_reestimation_id,pk"`
+	TaskID                     string    `bun:"task_id"`
+	RoundNumber                int       `bun:"round_number"`
+	PreviousStatus             string    `bun:"previous_status"`
+	PreviousFinalEstimateValue *string   `bun:"previous_final_estimate_value"`
+	ReopenedByUserID           string    `bun:"reopened_by_user_id"`
+	CreatedAt                  time.Time `bun:"created_at"`
+}
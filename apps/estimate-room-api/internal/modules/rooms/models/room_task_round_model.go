@@ -20,6 +20,9 @@ type RoomTaskRoundModel struct {
 	RoundNumber            int                 `bun:"round_number,pk"`
 	EligibleParticipantIDs []string            `bun:"eligible_participant_ids,type:jsonb"`
 	Status                 RoomTaskRoundStatus `bun:"status"`
+	TimerDurationSeconds   *int                `bun:"timer_duration_seconds"`
+	TimerEndsAt            *time.Time          `bun:"timer_ends_at"`
+	ReminderSentAt         *time.Time          `bun:"reminder_sent_at"`
 	CreatedAt              time.Time           `bun:"created_at"`
 	UpdatedAt              time.Time           `bun:"updated_at"`
 
@@ -0,0 +1,31 @@
+package roomsmodels
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type RoomVoteChangeAction string
+
+const (
+	RoomVoteChangeActionCast      RoomVoteChangeAction = "CAST"
+	RoomVoteChangeActionChanged   RoomVoteChangeAction = "CHANGED"
+	RoomVoteChangeActionRetracted RoomVoteChangeAction = "RETRACTED"
+)
+
+// RoomVoteChangeModel is an append-only record of a participant casting,
+// changing or retracting their vote before the round is revealed.
+type RoomVoteChangeModel struct {
+	bun.BaseModel `bun:"table:vote_changes,alias:vc"`
+
+	VoteChangeID  string               `bun:"vote_change_id,pk"`
+	TaskID        string               `bun:"task_id"`
+	ParticipantID string               `bun:"participant_id"`
+	RoundNumber   int                  `bun:"round_number"`
+	Dimension     string               `bun:"dimension"`
+	Action        RoomVoteChangeAction `bun:"action"`
+	PreviousValue *string              `bun:"previous_value"`
+	Value         *string              `bun:"value"`
+	CreatedAt     time.Time            `bun:"created_at"`
+}
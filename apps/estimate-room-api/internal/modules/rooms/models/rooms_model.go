@@ -6,20 +6,48 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// RoomMode tells how a room is estimated. LIVE rooms vote together in the
+// realtime session; ASYNC rooms open tasks over REST with a deadline and let
+// participants vote on their own time.
+type RoomMode string
+
+const (
+	RoomModeLive  RoomMode = "LIVE"
+	RoomModeAsync RoomMode = "ASYNC"
+)
+
+func (m RoomMode) IsValid() bool {
+	return m == RoomModeLive || m == RoomModeAsync
+}
+
 type RoomsModel struct {
 	bun.BaseModel `bun:"table:rooms,alias:r"`
 
-	RoomID         string     `bun:"room_id,pk"`
-	Code           string     `bun:"code"`
-	Name           string     `bun:"name"`
-	AdminUserID    string     `bun:"admin_user_id"`
-	TeamID         *string    `bun:"team_id"`
-	Deck           RoomDeck   `bun:"deck,type:jsonb"`
-	Status         string     `bun:"status"`
-	CreatedAt      time.Time  `bun:"created_at"`
-	LastActivityAt time.Time  `bun:"last_activity_at"`
-	FinishedAt     *time.Time `bun:"finished_at"`
+	RoomID         string         `bun:"room_id,pk"`
+	Code           string         `bun:"code"`
+	Name           string         `bun:"name"`
+	AdminUserID    string         `bun:"admin_user_id"`
+	TeamID         *string        `bun:"team_id"`
+	Deck           RoomDeck       `bun:"deck,type:jsonb"`
+	Options        RoomOptions    `bun:"options,type:jsonb"`
+	Dimensions     RoomDimensions `bun:"dimensions,type:jsonb"`
+	Mode           RoomMode       `bun:"mode"`
+	Status         string         `bun:"status"`
+	CreatedAt      time.Time      `bun:"created_at"`
+	LastActivityAt time.Time      `bun:"last_activity_at"`
+	FinishedAt     *time.Time     `bun:"finished_at"`
+	LockedAt       *time.Time     `bun:"locked_at"`
 
 	Participants []*RoomParticipantModel `bun:"rel:has-many,join:room_id=room_id"`
 	Tasks        []*RoomTaskModel        `bun:"rel:has-many,join:room_id=room_id"`
 }
+
+// IsLocked reports whether the room stopped admitting new participants.
+func (r *RoomsModel) IsLocked() bool {
+	return r.LockedAt != nil
+}
+
+// IsAsync reports whether the room is estimated asynchronously.
+func (r *RoomsModel) IsAsync() bool {
+	return r.Mode == RoomModeAsync
+}
@@ -0,0 +1,122 @@
+package roomsmodels
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// VoteStats describes how far apart the numeric votes of a round are. Cards
+// that are not numeric deck values, such as "?", are left out.
+type VoteStats struct {
+	NumericVotes     int      `json:"numericVotes"`
+	Mean             *float64 `json:"mean,omitempty"`
+	Median           *float64 `json:"median,omitempty"`
+	Min              *float64 `json:"min,omitempty"`
+	Max              *float64 `json:"max,omitempty"`
+	StdDev           *float64 `json:"stdDev,omitempty"`
+	NearestDeckValue *string  `json:"nearestDeckValue,omitempty"`
+	// Spread is the number of deck steps between the lowest and highest card.
+	Spread           int     `json:"spread"`
+	Consensus        bool    `json:"consensus"`
+	ConsensusPercent float64 `json:"consensusPercent"`
+}
+
+// ComputeVoteStats builds the statistics for the given vote values using the
+// numeric cards of the deck in deck order. Consensus means every numeric vote
+// landed on the same or an adjacent card; ConsensusPercent is the share of
+// numeric votes on the most common card.
+func ComputeVoteStats(deck RoomDeck, values []string) VoteStats {
+	deckValues := make([]string, 0, len(deck.Values))
+	deckNumbers := make([]float64, 0, len(deck.Values))
+	positions := make(map[string]int, len(deck.Values))
+	for _, value := range deck.Values {
+		trimmed := strings.TrimSpace(value)
+		number, ok := NumericDeckValue(trimmed)
+		if !ok {
+			continue
+		}
+		if _, exists := positions[trimmed]; exists {
+			continue
+		}
+		positions[trimmed] = len(deckValues)
+		deckValues = append(deckValues, trimmed)
+		deckNumbers = append(deckNumbers, number)
+	}
+
+	numbers := make([]float64, 0, len(values))
+	counts := make(map[int]int, len(values))
+	lowest, highest := -1, -1
+	for _, value := range values {
+		position, ok := positions[strings.TrimSpace(value)]
+		if !ok {
+			continue
+		}
+
+		numbers = append(numbers, deckNumbers[position])
+		counts[position]++
+		if lowest == -1 || position < lowest {
+			lowest = position
+		}
+		if highest == -1 || position > highest {
+			highest = position
+		}
+	}
+
+	stats := VoteStats{NumericVotes: len(numbers)}
+	if len(numbers) == 0 {
+		return stats
+	}
+
+	sort.Float64s(numbers)
+
+	sum := 0.0
+	for _, number := range numbers {
+		sum += number
+	}
+	mean := sum / float64(len(numbers))
+
+	variance := 0.0
+	for _, number := range numbers {
+		variance += (number - mean) * (number - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(numbers)))
+
+	middle := len(numbers) / 2
+	median := numbers[middle]
+	if len(numbers)%2 == 0 {
+		median = (numbers[middle-1] + numbers[middle]) / 2
+	}
+
+	minValue := numbers[0]
+	maxValue := numbers[len(numbers)-1]
+
+	nearest := 0
+	for idx, number := range deckNumbers {
+		distance := math.Abs(number - mean)
+		nearestDistance := math.Abs(deckNumbers[nearest] - mean)
+		if distance < nearestDistance || (distance == nearestDistance && number > deckNumbers[nearest]) {
+			nearest = idx
+		}
+	}
+	nearestValue := deckValues[nearest]
+
+	topCount := 0
+	for _, count := range counts {
+		if count > topCount {
+			topCount = count
+		}
+	}
+
+	stats.Mean = &mean
+	stats.Median = &median
+	stats.Min = &minValue
+	stats.Max = &maxValue
+	stats.StdDev = &stdDev
+	stats.NearestDeckValue = &nearestValue
+	stats.Spread = highest - lowest
+	stats.Consensus = stats.Spread <= 1
+	stats.ConsensusPercent = math.Round(float64(topCount)*1000/float64(len(numbers))) / 10
+
+	return stats
+}
@@ -0,0 +1,106 @@
+package roomsrepositories
+
+import (
+	"context"
+	"slices"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/uptrace/bun"
+)
+
+type RoomChatRepository interface {
+	Create(model *roomsmodels.RoomChatMessageModel) (*roomsmodels.RoomChatMessageModel, error)
+	ListThread(roomID string, taskID *string, beforeMessageID string, limit int) ([]*roomsmodels.RoomChatMessageModel, error)
+	ListRecent(roomID string, limit int) ([]*roomsmodels.RoomChatMessageModel, error)
+}
+
+type roomChatRepository struct {
+	db bun.IDB
+}
+
+func NewRoomChatRepository(db bun.IDB) RoomChatRepository {
+	return &roomChatRepository{db: db}
+}
+
+// Create stores the message and returns it with its author loaded.
+func (r *roomChatRepository) Create(model *roomsmodels.RoomChatMessageModel) (*roomsmodels.RoomChatMessageModel, error) {
+	ctx := context.Background()
+	_, err := r.db.NewInsert().
+		Model(model).
+		Column("chat_message_id", "room_id", "task_id", "participant_id", "body").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	created := new(roomsmodels.RoomChatMessageModel)
+	err = r.db.NewSelect().
+		Model(created).
+		Relation("Participant").
+		Relation("Participant.User").
+		Where("cm.chat_message_id = ?", model.ChatMessageID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ListThread returns up to limit messages of the room channel (nil taskID) or
+// of a task thread, newest first. With beforeMessageID only messages older
+// than that message are returned.
+func (r *roomChatRepository) ListThread(roomID string, taskID *string, beforeMessageID string, limit int) ([]*roomsmodels.RoomChatMessageModel, error) {
+	messages := make([]*roomsmodels.RoomChatMessageModel, 0)
+	query := r.db.NewSelect().
+		Model(&messages).
+		Relation("Participant").
+		Relation("Participant.User").
+		Where("cm.room_id = ?", roomID)
+
+	if taskID == nil {
+		query = query.Where("cm.task_id IS NULL")
+	} else {
+		query = query.Where("cm.task_id = ?", *taskID)
+	}
+	if beforeMessageID != "" {
+		query = query.Where(
+			"(cm.created_at, cm.chat_message_id) < (SELECT created_at, chat_message_id FROM chat_messages WHERE chat_message_id = ? AND room_id = ?)",
+			beforeMessageID,
+			roomID,
+		)
+	}
+
+	err := query.
+		OrderExpr("cm.created_at DESC, cm.chat_message_id DESC").
+		Limit(limit).
+		Scan(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// ListRecent returns the latest messages of the room across all threads,
+// oldest first.
+func (r *roomChatRepository) ListRecent(roomID string, limit int) ([]*roomsmodels.RoomChatMessageModel, error) {
+	messages := make([]*roomsmodels.RoomChatMessageModel, 0)
+	err := r.db.NewSelect().
+		Model(&messages).
+		Relation("Participant").
+		Relation("Participant.User").
+		Where("cm.room_id = ?", roomID).
+		OrderExpr("cm.created_at DESC, cm.chat_message_id DESC").
+		Limit(limit).
+		Scan(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(messages)
+
+	return messages, nil
+}
@@ -16,8 +16,12 @@ type RoomParticipantRepository interface {
 	FindActiveByGuestName(roomID, guestName string) (*roomsmodels.RoomParticipantModel, error)
 	FindActiveByID(roomID, participantID string) (*roomsmodels.RoomParticipantModel, error)
 	ListActiveByRoom(roomID string) ([]*roomsmodels.RoomParticipantModel, error)
+	ListRegisteredByRoom(roomID string) ([]*roomsmodels.RoomParticipantModel, error)
 	CountActiveByRoom(roomID string) (int, error)
 	Create(model *roomsmodels.RoomParticipantModel) (*roomsmodels.RoomParticipantModel, error)
+	UpdateRole(roomID, participantID string, role roomsmodels.RoomParticipantRole) (*roomsmodels.RoomParticipantModel, error)
+	MarkLeft(roomID, participantID string) (*roomsmodels.RoomParticipantModel, error)
+	MarkLeftByUserID(ctx context.Context, userID string) error
 }
 
 type roomParticipantRepository struct {
@@ -101,6 +105,24 @@ func (r *roomParticipantRepository) ListActiveByRoom(roomID string) ([]*roomsmod
 	return participants, nil
 }
 
+// ListRegisteredByRoom returns every registered participant who ever joined
+// the room, including those who left, with their user loaded.
+func (r *roomParticipantRepository) ListRegisteredByRoom(roomID string) ([]*roomsmodels.RoomParticipantModel, error) {
+	participants := make([]*roomsmodels.RoomParticipantModel, 0)
+	err := r.db.NewSelect().
+		Model(&participants).
+		Relation("User").
+		Where("rp.room_id = ?", roomID).
+		Where("rp.user_id IS NOT NULL").
+		OrderExpr("rp.joined_at ASC").
+		Scan(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
 func (r *roomParticipantRepository) CountActiveByRoom(roomID string) (int, error) {
 	var count int
 	err := r.db.NewSelect().
@@ -128,3 +150,61 @@ func (r *roomParticipantRepository) Create(model *roomsmodels.RoomParticipantMod
 
 	return model, nil
 }
+
+func (r *roomParticipantRepository) UpdateRole(roomID, participantID string, role roomsmodels.RoomParticipantRole) (*roomsmodels.RoomParticipantModel, error) {
+	participant := new(roomsmodels.RoomParticipantModel)
+	err := r.db.NewUpdate().
+		Model(participant).
+		Set("role = ?", role).
+		Where("room_id = ?", roomID).
+		Where("room_participants_id = ?", participantID).
+		Where("left_at IS NULL").
+		Returning("*").
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// MarkLeft closes the participation, which also invalidates the guest token
+// issued for it.
+func (r *roomParticipantRepository) MarkLeft(roomID, participantID string) (*roomsmodels.RoomParticipantModel, error) {
+	participant := new(roomsmodels.RoomParticipantModel)
+	err := r.db.NewUpdate().
+		Model(participant).
+		Set("left_at = NOW()").
+		Where("room_id = ?", roomID).
+		Where("room_participants_id = ?", participantID).
+		Where("left_at IS NULL").
+		Returning("*").
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return participant, nil
+}
+
+// MarkLeftByUserID closes every open participation of the user across rooms.
+func (r *roomParticipantRepository) MarkLeftByUserID(ctx context.Context, userID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, err := r.db.NewUpdate().
+		Model((*roomsmodels.RoomParticipantModel)(nil)).
+		Set("left_at = NOW()").
+		Where("user_id = ?", userID).
+		Where("left_at IS NULL").
+		Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,33 @@
+package roomsrepositories
+
+import (
+	"context"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/uptrace/bun"
+)
+
+type RoomTaskReestimationRepository interface {
+	Create(model *roomsmodels.RoomTaskReestimationModel) (*roomsmodels.RoomTaskReestimationModel, error)
+}
+
+type roomTaskReestimationRepository struct {
+	db bun.IDB
+}
+
+func NewRoomTaskReestimationRepository(db bun.IDB) RoomTaskReestimationRepository {
+	return &roomTaskReestimationRepository{db: db}
+}
+
+func (r *roomTaskReestimationRepository) Create(model *roomsmodels.RoomTaskReestimationModel) (*roomsmodels.RoomTaskReestimationModel, error) {
+	_, err := r.db.NewInsert().
+		Model(model).
+		Column("task_reestimation_id", "task_id", "round_number", "previous_status", "previous_final_estimate_value", "reopened_by_user_id").
+		Returning("*").
+		Exec(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
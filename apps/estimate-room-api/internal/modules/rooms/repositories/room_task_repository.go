@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"time"
 
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
@@ -12,28 +14,48 @@ import (
 
 type RoomTaskRepository interface {
 	Create(model *roomsmodels.RoomTaskModel) (*roomsmodels.RoomTaskModel, error)
+	CreateMany(ctx context.Context, models []*roomsmodels.RoomTaskModel) ([]*roomsmodels.RoomTaskModel, error)
 	FindByRoomID(roomID string) ([]*roomsmodels.RoomTaskModel, error)
+	Reorder(ctx context.Context, roomID string, taskIDs []string, position int) ([]*roomsmodels.RoomTaskModel, error)
 	FindByID(roomID, taskID string) (*roomsmodels.RoomTaskModel, error)
 	FindCurrentVotingTask(roomID string) (*roomsmodels.RoomTaskModel, error)
 	SetCurrentVotingTask(roomID, taskID string) (updatedTask *roomsmodels.RoomTaskModel, previousTask *roomsmodels.RoomTaskModel, err error)
+	ReopenTask(roomID, taskID string) (*ReopenedTask, error)
 	Update(roomID string, model *roomsmodels.RoomTaskModel) (*roomsmodels.RoomTaskModel, error)
 	Delete(roomID, taskID string) error
 }
 
+// ReopenedTask is an estimated or skipped task moved back to voting. Replaced
+// is the task as it was before, PreviousTask the task that was current.
+type ReopenedTask struct {
+	Task         *roomsmodels.RoomTaskModel
+	Replaced     *roomsmodels.RoomTaskModel
+	PreviousTask *roomsmodels.RoomTaskModel
+}
+
 type roomTaskRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
-func NewRoomTaskRepository(db *bun.DB) RoomTaskRepository {
+func NewRoomTaskRepository(db bun.IDB) RoomTaskRepository {
 	return &roomTaskRepository{db: db}
 }
 
+// Create appends the task to the end of the room's backlog.
 func (r *roomTaskRepository) Create(model *roomsmodels.RoomTaskModel) (*roomsmodels.RoomTaskModel, error) {
-	_, err := r.db.NewInsert().
-		Model(model).
-		Column("task_id", "room_id", "title", "description", "external_key", "status", "is_active", "final_estimate_value").
-		Returning("*").
-		Exec(context.Background())
+	err := r.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockRoomBacklog(ctx, tx, model.RoomID); err != nil {
+			return err
+		}
+
+		_, err := tx.NewInsert().
+			Model(model).
+			Column("task_id", "room_id", "title", "description", "external_key", "status", "is_active", "final_estimate_value", "position").
+			Value("position", "(SELECT COALESCE(MAX(position) + 1, 0) FROM tasks WHERE room_id = ?)", model.RoomID).
+			Returning("*").
+			Exec(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -41,12 +63,70 @@ func (r *roomTaskRepository) Create(model *roomsmodels.RoomTaskModel) (*roomsmod
 	return model, nil
 }
 
+// CreateMany appends the tasks to the end of the room's backlog in one
+// transaction, so either every task is created or none is.
+func (r *roomTaskRepository) CreateMany(ctx context.Context, models []*roomsmodels.RoomTaskModel) ([]*roomsmodels.RoomTaskModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(models) == 0 {
+		return models, nil
+	}
+
+	createdAt := time.Now().UTC()
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockRoomBacklog(ctx, tx, models[0].RoomID); err != nil {
+			return err
+		}
+
+		var nextPosition int
+		err := tx.NewSelect().
+			Model((*roomsmodels.RoomTaskModel)(nil)).
+			ColumnExpr("COALESCE(MAX(t.position) + 1, 0)").
+			Where("t.room_id = ?", models[0].RoomID).
+			Scan(ctx, &nextPosition)
+		if err != nil {
+			return err
+		}
+
+		for idx, model := range models {
+			model.Position = nextPosition + idx
+			model.CreatedAt = createdAt
+			model.UpdatedAt = createdAt
+		}
+
+		_, err = tx.NewInsert().
+			Model(&models).
+			Column("task_id", "room_id", "title", "description", "external_key", "status", "is_active", "final_estimate_value", "position", "created_at", "updated_at").
+			Returning("*").
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return models, nil
+}
+
+// lockRoomBacklog locks the room row until the transaction ends, so writers
+// of backlog positions run one at a time and never hand out the same one.
+func lockRoomBacklog(ctx context.Context, tx bun.Tx, roomID string) error {
+	_, err := tx.NewSelect().
+		Model((*roomsmodels.RoomsModel)(nil)).
+		Column("r.room_id").
+		Where("r.room_id = ?", roomID).
+		For("UPDATE").
+		Exec(ctx)
+	return err
+}
+
 func (r *roomTaskRepository) FindByRoomID(roomID string) ([]*roomsmodels.RoomTaskModel, error) {
 	tasks := make([]*roomsmodels.RoomTaskModel, 0)
 	err := r.db.NewSelect().
 		Model(&tasks).
 		Where("t.room_id = ?", roomID).
-		OrderExpr("t.created_at ASC").
+		OrderExpr("t.position ASC, t.created_at ASC").
 		Scan(context.Background())
 	if err != nil {
 		return nil, err
@@ -55,6 +135,88 @@ func (r *roomTaskRepository) FindByRoomID(roomID string) ([]*roomsmodels.RoomTas
 	return tasks, nil
 }
 
+// Reorder moves the given tasks, in the given order, so the first of them
+// lands at position among the room's tasks, then renumbers the backlog from
+// zero. The room's backlog is locked for the duration of the transaction.
+func (r *roomTaskRepository) Reorder(ctx context.Context, roomID string, taskIDs []string, position int) ([]*roomsmodels.RoomTaskModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ordered := make([]*roomsmodels.RoomTaskModel, 0)
+	err := r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := lockRoomBacklog(ctx, tx, roomID); err != nil {
+			return err
+		}
+
+		tasks := make([]*roomsmodels.RoomTaskModel, 0)
+		err := tx.NewSelect().
+			Model(&tasks).
+			Where("t.room_id = ?", roomID).
+			OrderExpr("t.position ASC, t.created_at ASC").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		tasksByID := make(map[string]*roomsmodels.RoomTaskModel, len(tasks))
+		for _, task := range tasks {
+			tasksByID[task.TaskID] = task
+		}
+
+		moved := make([]*roomsmodels.RoomTaskModel, 0, len(taskIDs))
+		movedIDs := make(map[string]struct{}, len(taskIDs))
+		for _, taskID := range taskIDs {
+			task, ok := tasksByID[taskID]
+			if !ok {
+				return fmt.Errorf("%w: task %s is not in this room", apperrors.ErrBadRequest, taskID)
+			}
+			if _, ok := movedIDs[taskID]; ok {
+				return fmt.Errorf("%w: task %s is listed more than once", apperrors.ErrBadRequest, taskID)
+			}
+			movedIDs[taskID] = struct{}{}
+			moved = append(moved, task)
+		}
+
+		remaining := make([]*roomsmodels.RoomTaskModel, 0, len(tasks)-len(moved))
+		for _, task := range tasks {
+			if _, ok := movedIDs[task.TaskID]; !ok {
+				remaining = append(remaining, task)
+			}
+		}
+
+		position = min(max(position, 0), len(remaining))
+		ordered = append(ordered, remaining[:position]...)
+		ordered = append(ordered, moved...)
+		ordered = append(ordered, remaining[position:]...)
+
+		for idx, task := range ordered {
+			if task.Position == idx {
+				continue
+			}
+
+			task.Position = idx
+			_, err := tx.NewUpdate().
+				Model((*roomsmodels.RoomTaskModel)(nil)).
+				Set("position = ?", idx).
+				Where("room_id = ?", roomID).
+				Where("task_id = ?", task.TaskID).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
 func (r *roomTaskRepository) FindByID(roomID, taskID string) (*roomsmodels.RoomTaskModel, error) {
 	task := new(roomsmodels.RoomTaskModel)
 	err := r.db.NewSelect().
@@ -93,12 +255,30 @@ func (r *roomTaskRepository) FindCurrentVotingTask(roomID string) (*roomsmodels.
 }
 
 func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskModel, error) {
+	activated, err := r.activateVotingTask(roomID, taskID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return activated.Task, activated.PreviousTask, nil
+}
+
+// ReopenTask makes an estimated or skipped task the current voting task again
+// and clears its final estimate.
+func (r *roomTaskRepository) ReopenTask(roomID, taskID string) (*ReopenedTask, error) {
+	return r.activateVotingTask(roomID, taskID, true)
+}
+
+// activateVotingTask moves the current voting task back to pending and makes
+// the target task current. Only reopen may activate an estimated or skipped
+// task, and it requires one.
+func (r *roomTaskRepository) activateVotingTask(roomID, taskID string, reopen bool) (*ReopenedTask, error) {
 	var previousTask *roomsmodels.RoomTaskModel
 	committed := false
 
 	tx, err := r.db.BeginTx(context.Background(), nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer func() {
 		if !committed {
@@ -115,12 +295,16 @@ func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*rooms
 		Scan(context.Background())
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil, apperrors.ErrNotFound
+			return nil, apperrors.ErrNotFound
 		}
-		return nil, nil, err
+		return nil, err
+	}
+	closed := targetTask.Status == "ESTIMATED" || targetTask.Status == "SKIPPED"
+	if closed && !reopen {
+		return nil, apperrors.ErrBadRequest
 	}
-	if targetTask.Status == "ESTIMATED" || targetTask.Status == "SKIPPED" {
-		return nil, nil, apperrors.ErrBadRequest
+	if !closed && reopen {
+		return nil, fmt.Errorf("%w: only estimated or skipped tasks can be re-estimated", apperrors.ErrBadRequest)
 	}
 
 	currentVotingTask := new(roomsmodels.RoomTaskModel)
@@ -132,7 +316,7 @@ func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*rooms
 		Limit(1).
 		Scan(context.Background())
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if currentVotingTask.TaskID != "" && currentVotingTask.TaskID != taskID {
@@ -145,11 +329,11 @@ func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*rooms
 			Where("task_id = ?", currentVotingTask.TaskID).
 			Exec(context.Background())
 		if updateErr != nil {
-			return nil, nil, updateErr
+			return nil, updateErr
 		}
 		rows, rowsErr := result.RowsAffected()
 		if rowsErr != nil {
-			return nil, nil, rowsErr
+			return nil, rowsErr
 		}
 		if rows > 0 {
 			currentVotingTask.Status = "PENDING"
@@ -158,24 +342,27 @@ func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*rooms
 		}
 	}
 
-	result, err := tx.NewUpdate().
+	update := tx.NewUpdate().
 		Model((*roomsmodels.RoomTaskModel)(nil)).
 		Set("status = ?", "VOTING").
 		Set("is_active = TRUE").
 		Set("updated_at = NOW()").
 		Where("room_id = ?", roomID).
-		Where("task_id = ?", taskID).
-		Exec(context.Background())
+		Where("task_id = ?", taskID)
+	if reopen {
+		update = update.Set("final_estimate_value = NULL").Set("final_dimension_estimates = NULL")
+	}
+	result, err := update.Exec(context.Background())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	if rowsAffected == 0 {
-		return nil, nil, apperrors.ErrNotFound
+		return nil, apperrors.ErrNotFound
 	}
 
 	updatedTask := new(roomsmodels.RoomTaskModel)
@@ -186,21 +373,25 @@ func (r *roomTaskRepository) SetCurrentVotingTask(roomID, taskID string) (*rooms
 		Limit(1).
 		Scan(context.Background())
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	committed = true
 
-	return updatedTask, previousTask, nil
+	return &ReopenedTask{
+		Task:         updatedTask,
+		Replaced:     targetTask,
+		PreviousTask: previousTask,
+	}, nil
 }
 
 func (r *roomTaskRepository) Update(roomID string, model *roomsmodels.RoomTaskModel) (*roomsmodels.RoomTaskModel, error) {
 	result, err := r.db.NewUpdate().
 		Model(model).
-		Column("title", "description", "external_key", "status", "is_active", "final_estimate_value").
+		Column("title", "description", "external_key", "status", "is_active", "final_estimate_value", "final_dimension_estimates").
 		Set("updated_at = NOW()").
 		WherePK().
 		Where("room_id = ?", roomID).
@@ -3,7 +3,9 @@ package roomsrepositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"time"
 
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
@@ -15,13 +17,41 @@ type RoomTaskRoundRepository interface {
 	GetOrCreateCurrent(taskID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskRoundModel, error)
 	Advance(taskID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskRoundModel, error)
 	MarkRevealed(taskID string, roundNumber int) (*roomsmodels.RoomTaskRoundModel, error)
+	MarkRevealedIfActive(taskID string, roundNumber int) (*roomsmodels.RoomTaskRoundModel, bool, error)
+	SetEligibleParticipants(taskID string, roundNumber int, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskRoundModel, error)
+	AddEligibleParticipant(taskID string, roundNumber int, participantID string) (*roomsmodels.RoomTaskRoundModel, error)
+	StartTimer(taskID string, roundNumber int, duration time.Duration, endsAt time.Time) (*roomsmodels.RoomTaskRoundModel, error)
+	ListActiveTimers(ctx context.Context) ([]ActiveRoundTimer, error)
+	ClaimDueReminders(ctx context.Context) ([]DueRoundReminder, error)
+}
+
+// ActiveRoundTimer is a running voting timer of the current round in an
+// active room.
+type ActiveRoundTimer struct {
+	RoomID               string    `bun:"room_id"`
+	TaskID               string    `bun:"task_id"`
+	RoundNumber          int       `bun:"round_number"`
+	TimerDurationSeconds int       `bun:"timer_duration_seconds"`
+	TimerEndsAt          time.Time `bun:"timer_ends_at"`
+}
+
+// DueRoundReminder is an open async round whose deadline is close enough to
+// remind the participants who have not voted yet.
+type DueRoundReminder struct {
+	RoomID                 string    `bun:"room_id"`
+	RoomName               string    `bun:"room_name"`
+	TaskID                 string    `bun:"task_id"`
+	TaskTitle              string    `bun:"task_title"`
+	RoundNumber            int       `bun:"round_number"`
+	EligibleParticipantIDs []string  `bun:"eligible_participant_ids,type:jsonb"`
+	TimerEndsAt            time.Time `bun:"timer_ends_at"`
 }
 
 type roomTaskRoundRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
-func NewRoomTaskRoundRepository(db *bun.DB) RoomTaskRoundRepository {
+func NewRoomTaskRoundRepository(db bun.IDB) RoomTaskRoundRepository {
 	return &roomTaskRoundRepository{db: db}
 }
 
@@ -132,3 +162,208 @@ func (r *roomTaskRoundRepository) MarkRevealed(taskID string, roundNumber int) (
 
 	return model, nil
 }
+
+// MarkRevealedIfActive reveals the round only while it is still active and
+// reports whether this call performed the reveal, so concurrent automatic
+// reveals on several instances broadcast once.
+func (r *roomTaskRoundRepository) MarkRevealedIfActive(taskID string, roundNumber int) (*roomsmodels.RoomTaskRoundModel, bool, error) {
+	model := new(roomsmodels.RoomTaskRoundModel)
+	err := r.db.NewUpdate().
+		Model(model).
+		Set("status = ?", roomsmodels.RoomTaskRoundStatusRevealed).
+		Set("updated_at = NOW()").
+		Where("task_id = ?", taskID).
+		Where("round_number = ?", roundNumber).
+		Where("status = ?", roomsmodels.RoomTaskRoundStatusActive).
+		Returning("*").
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	return model, true, nil
+}
+
+// SetEligibleParticipants replaces the voters of a round that is still active.
+func (r *roomTaskRoundRepository) SetEligibleParticipants(
+	taskID string,
+	roundNumber int,
+	eligibleParticipantIDs []string,
+) (*roomsmodels.RoomTaskRoundModel, error) {
+	if eligibleParticipantIDs == nil {
+		eligibleParticipantIDs = []string{}
+	}
+
+	eligibleJSON, err := json.Marshal(eligibleParticipantIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	model := new(roomsmodels.RoomTaskRoundModel)
+	err = r.db.NewUpdate().
+		Model(model).
+		Set("eligible_participant_ids = ?::jsonb", string(eligibleJSON)).
+		Set("updated_at = NOW()").
+		Where("task_id = ?", taskID).
+		Where("round_number = ?", roundNumber).
+		Where("status = ?", roomsmodels.RoomTaskRoundStatusActive).
+		Returning("*").
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// AddEligibleParticipant appends a voter to a round that is still active in a
+// single update, so voters joining at the same time all stay eligible.
+func (r *roomTaskRoundRepository) AddEligibleParticipant(
+	taskID string,
+	roundNumber int,
+	participantID string,
+) (*roomsmodels.RoomTaskRoundModel, error) {
+	model := new(roomsmodels.RoomTaskRoundModel)
+	err := r.db.NewUpdate().
+		Model(model).
+		Set("eligible_participant_ids = eligible_participant_ids || jsonb_build_array(?::text)", participantID).
+		Set("updated_at = NOW()").
+		Where("task_id = ?", taskID).
+		Where("round_number = ?", roundNumber).
+		Where("status = ?", roomsmodels.RoomTaskRoundStatusActive).
+		Where("NOT eligible_participant_ids @> jsonb_build_array(?::text)", participantID).
+		Returning("*").
+		Scan(context.Background())
+	if err == nil {
+		return model, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	// Either the participant is already eligible or the round was revealed.
+	err = r.db.NewSelect().
+		Model(model).
+		Where("tr.task_id = ?", taskID).
+		Where("tr.round_number = ?", roundNumber).
+		Where("tr.status = ?", roomsmodels.RoomTaskRoundStatusActive).
+		Limit(1).
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return model, nil
+}
+
+// StartTimer sets the deadline of an active round. A moved deadline gets a
+// fresh reminder.
+func (r *roomTaskRoundRepository) StartTimer(
+	taskID string,
+	roundNumber int,
+	duration time.Duration,
+	endsAt time.Time,
+) (*roomsmodels.RoomTaskRoundModel, error) {
+	model := new(roomsmodels.RoomTaskRoundModel)
+	err := r.db.NewUpdate().
+		Model(model).
+		Set("timer_duration_seconds = ?", int(duration/time.Second)).
+		Set("timer_ends_at = ?", endsAt).
+		Set("reminder_sent_at = NULL").
+		Set("updated_at = NOW()").
+		Where("task_id = ?", taskID).
+		Where("round_number = ?", roundNumber).
+		Where("status = ?", roomsmodels.RoomTaskRoundStatusActive).
+		Returning("*").
+		Scan(context.Background())
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (r *roomTaskRoundRepository) ListActiveTimers(ctx context.Context) ([]ActiveRoundTimer, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	timers := make([]ActiveRoundTimer, 0)
+	err := r.db.NewRaw(`
+		SELECT
+			t.room_id,
+			tr.task_id,
+			tr.round_number,
+			tr.timer_duration_seconds,
+			tr.timer_ends_at
+		FROM task_rounds AS tr
+		JOIN tasks AS t ON t.task_id = tr.task_id
+		JOIN rooms AS r ON r.room_id = t.room_id
+		WHERE tr.status = 'ACTIVE'
+		  AND tr.timer_ends_at IS NOT NULL
+		  AND t.is_active = TRUE
+		  AND r.status = 'ACTIVE'
+	`).Scan(ctx, &timers)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return timers, nil
+		}
+		return nil, err
+	}
+
+	return timers, nil
+}
+
+// ClaimDueReminders marks the reminder of every open async round that reached
+// the last half of its voting window, capped at one day, and returns those
+// rounds. Each round is claimed once, so only one instance sends its
+// reminders.
+func (r *roomTaskRoundRepository) ClaimDueReminders(ctx context.Context) ([]DueRoundReminder, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reminders := make([]DueRoundReminder, 0)
+	err := r.db.NewRaw(`
+		UPDATE task_rounds AS tr
+		SET reminder_sent_at = NOW()
+		FROM tasks AS t
+		JOIN rooms AS r ON r.room_id = t.room_id
+		WHERE t.task_id = tr.task_id
+		  AND tr.status = 'ACTIVE'
+		  AND tr.reminder_sent_at IS NULL
+		  AND tr.timer_ends_at > NOW()
+		  AND tr.timer_ends_at - NOW() <= LEAST(interval '24 hours', tr.timer_duration_seconds * interval '1 second' / 2)
+		  AND t.is_active = TRUE
+		  AND r.status = 'ACTIVE'
+		  AND r.mode = 'ASYNC'
+		RETURNING
+			t.room_id,
+			r.name AS room_name,
+			tr.task_id,
+			t.title AS task_title,
+			tr.round_number,
+			tr.eligible_participant_ids,
+			tr.timer_ends_at
+	`).Scan(ctx, &reminders)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return reminders, nil
+		}
+		return nil, err
+	}
+
+	return reminders, nil
+}
@@ -11,56 +11,156 @@ import (
 )
 
 type RoomVoteRepository interface {
-	Upsert(taskID, participantID string, roundNumber int, value string) (*roomsmodels.RoomVoteModel, error)
+	Upsert(taskID, participantID string, roundNumber int, dimension, value string) (*roomsmodels.RoomVoteModel, error)
+	Retract(taskID, participantID string, roundNumber int, dimension *string) (bool, error)
 	ListByTaskAndRound(taskID string, roundNumber int) ([]*roomsmodels.RoomVoteModel, error)
+	DeleteByParticipant(taskID, participantID string, roundNumber int) error
 	CountDistinctParticipantsByTaskAndRound(taskID string, roundNumber int) (int, error)
 }
 
 type roomVoteRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
-func NewRoomVoteRepository(db *bun.DB) RoomVoteRepository {
+func NewRoomVoteRepository(db bun.IDB) RoomVoteRepository {
 	return &roomVoteRepository{db: db}
 }
 
-func (r *roomVoteRepository) Upsert(taskID, participantID string, roundNumber int, value string) (*roomsmodels.RoomVoteModel, error) {
-	model := &roomsmodels.RoomVoteModel{
-		VoteID:        uuid.NewString(),
-		TaskID:        taskID,
-		ParticipantID: participantID,
-		RoundNumber:   roundNumber,
-		Value:         value,
-	}
+// Upsert stores the participant's vote for the round and dimension and
+// appends the cast or change to vote_changes. Casting the same value again
+// records nothing.
+func (r *roomVoteRepository) Upsert(taskID, participantID string, roundNumber int, dimension, value string) (*roomsmodels.RoomVoteModel, error) {
+	updated := new(roomsmodels.RoomVoteModel)
+	err := r.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		previous := new(roomsmodels.RoomVoteModel)
+		err := tx.NewSelect().
+			Model(previous).
+			Where("v.task_id = ?", taskID).
+			Where("v.participant_id = ?", participantID).
+			Where("v.round_number = ?", roundNumber).
+			Where("v.dimension = ?", dimension).
+			For("UPDATE").
+			Limit(1).
+			Scan(ctx)
+		if err != nil {
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+			previous = nil
+		}
 
-	_, err := r.db.NewInsert().
-		Model(model).
-		Column("votes_id", "task_id", "participant_id", "value", "round_number").
-		On("CONFLICT (task_id, participant_id, round_number) DO UPDATE").
-		Set("value = EXCLUDED.value").
-		Set("created_at = NOW()").
-		Returning("*").
-		Exec(context.Background())
+		model := &roomsmodels.RoomVoteModel{
+			VoteID:        uuid.NewString(),
+			TaskID:        taskID,
+			ParticipantID: participantID,
+			RoundNumber:   roundNumber,
+			Dimension:     dimension,
+			Value:         value,
+		}
+
+		_, err = tx.NewInsert().
+			Model(model).
+			Column("votes_id", "task_id", "participant_id", "value", "round_number", "dimension").
+			On("CONFLICT (task_id, participant_id, round_number, dimension) DO UPDATE").
+			Set("value = EXCLUDED.value").
+			Set("created_at = NOW()").
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = tx.NewSelect().
+			Model(updated).
+			Where("v.task_id = ?", taskID).
+			Where("v.participant_id = ?", participantID).
+			Where("v.round_number = ?", roundNumber).
+			Where("v.dimension = ?", dimension).
+			Limit(1).
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		change := &roomsmodels.RoomVoteChangeModel{
+			TaskID:        taskID,
+			ParticipantID: participantID,
+			RoundNumber:   roundNumber,
+			Dimension:     dimension,
+			Action:        roomsmodels.RoomVoteChangeActionCast,
+			Value:         &value,
+		}
+		if previous != nil {
+			if previous.Value == value {
+				return nil
+			}
+			change.Action = roomsmodels.RoomVoteChangeActionChanged
+			change.PreviousValue = &previous.Value
+		}
+
+		return insertVoteChange(ctx, tx, change)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	updated := new(roomsmodels.RoomVoteModel)
-	err = r.db.NewSelect().
-		Model(updated).
-		Where("v.task_id = ?", taskID).
-		Where("v.participant_id = ?", participantID).
-		Where("v.round_number = ?", roundNumber).
-		Limit(1).
-		Scan(context.Background())
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, err
+	return updated, nil
+}
+
+// Retract deletes the participant's vote for the round and appends each
+// retraction to vote_changes. A nil dimension retracts the votes of every
+// dimension. It reports false when there was no vote.
+func (r *roomVoteRepository) Retract(taskID, participantID string, roundNumber int, dimension *string) (bool, error) {
+	retracted := false
+	err := r.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		var previous []struct {
+			Dimension string `bun:"dimension"`
+			Value     string `bun:"value"`
 		}
-		return nil, err
+		query := tx.NewDelete().
+			Model((*roomsmodels.RoomVoteModel)(nil)).
+			Where("task_id = ?", taskID).
+			Where("participant_id = ?", participantID).
+			Where("round_number = ?", roundNumber)
+		if dimension != nil {
+			query = query.Where("dimension = ?", *dimension)
+		}
+		if err := query.Returning("dimension, value").Scan(ctx, &previous); err != nil {
+			return err
+		}
+
+		for _, vote := range previous {
+			previousValue := vote.Value
+			if err := insertVoteChange(ctx, tx, &roomsmodels.RoomVoteChangeModel{
+				TaskID:        taskID,
+				ParticipantID: participantID,
+				RoundNumber:   roundNumber,
+				Dimension:     vote.Dimension,
+				Action:        roomsmodels.RoomVoteChangeActionRetracted,
+				PreviousValue: &previousValue,
+			}); err != nil {
+				return err
+			}
+		}
+
+		retracted = len(previous) > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
 
-	return updated, nil
+	return retracted, nil
+}
+
+func insertVoteChange(ctx context.Context, db bun.IDB, change *roomsmodels.RoomVoteChangeModel) error {
+	_, err := db.NewInsert().
+		Model(change).
+		Column("task_id", "participant_id", "round_number", "dimension", "action", "previous_value", "value").
+		Returning("*").
+		Exec(ctx)
+
+	return err
 }
 
 func (r *roomVoteRepository) ListByTaskAndRound(taskID string, roundNumber int) ([]*roomsmodels.RoomVoteModel, error) {
@@ -69,7 +169,7 @@ func (r *roomVoteRepository) ListByTaskAndRound(taskID string, roundNumber int)
 		Model(&votes).
 		Where("v.task_id = ?", taskID).
 		Where("v.round_number = ?", roundNumber).
-		OrderExpr("v.created_at ASC").
+		OrderExpr("v.created_at ASC, v.dimension ASC").
 		Scan(context.Background())
 	if err != nil {
 		return nil, err
@@ -78,6 +178,17 @@ func (r *roomVoteRepository) ListByTaskAndRound(taskID string, roundNumber int)
 	return votes, nil
 }
 
+func (r *roomVoteRepository) DeleteByParticipant(taskID, participantID string, roundNumber int) error {
+	_, err := r.db.NewDelete().
+		Model((*roomsmodels.RoomVoteModel)(nil)).
+		Where("task_id = ?", taskID).
+		Where("participant_id = ?", participantID).
+		Where("round_number = ?", roundNumber).
+		Exec(context.Background())
+
+	return err
+}
+
 func (r *roomVoteRepository) CountDistinctParticipantsByTaskAndRound(taskID string, roundNumber int) (int, error) {
 	var count int
 	err := r.db.NewSelect().
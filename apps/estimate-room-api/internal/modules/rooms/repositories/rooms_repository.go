@@ -3,6 +3,7 @@ package roomsrepositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -24,8 +25,11 @@ type roomsRepository struct {
 }
 
 type UpdateRoomFields struct {
-	Name   *string
-	Status *string
+	Name        *string
+	Status      *string
+	Options     *roomsmodels.RoomOptions
+	AdminUserID *string
+	Locked      *bool
 }
 
 func NewRoomsRepository(db bun.IDB) *roomsRepository {
@@ -39,7 +43,7 @@ func (r *roomsRepository) Create(ctx context.Context, model *roomsmodels.RoomsMo
 
 	_, err := r.db.NewInsert().
 		Model(model).
-		Column("code", "name", "admin_user_id", "team_id", "deck").
+		Column("code", "name", "admin_user_id", "team_id", "deck", "options", "dimensions", "mode").
 		Returning("*").
 		Exec(ctx)
 	if err != nil {
@@ -65,7 +69,7 @@ func (r *roomsRepository) FindByID(roomID string) (*roomsmodels.RoomsModel, erro
 		}).
 		Relation("Tasks", func(q *bun.SelectQuery) *bun.SelectQuery {
 			return q.
-				OrderExpr("t.created_at ASC").
+				OrderExpr("t.position ASC, t.created_at ASC").
 				Relation("Votes", func(vq *bun.SelectQuery) *bun.SelectQuery {
 					return vq.OrderExpr("v.created_at ASC")
 				})
@@ -92,6 +96,26 @@ func (r *roomsRepository) Update(roomID string, input UpdateRoomFields) (*roomsm
 		query = query.Set("name = ?", *input.Name)
 	}
 
+	if input.AdminUserID != nil {
+		query = query.Set("admin_user_id = ?", *input.AdminUserID)
+	}
+
+	if input.Locked != nil {
+		if *input.Locked {
+			query = query.Set("locked_at = COALESCE(locked_at, NOW())")
+		} else {
+			query = query.Set("locked_at = NULL")
+		}
+	}
+
+	if input.Options != nil {
+		options, err := json.Marshal(input.Options)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Set("options = ?::jsonb", string(options))
+	}
+
 	if input.Status != nil {
 		query = query.Set("status = ?", *input.Status)
 		if isTerminalRoomStatus(*input.Status) {
@@ -126,6 +150,8 @@ func (r *roomsRepository) TouchActivity(roomID string) error {
 	return err
 }
 
+// ExpireInactiveRooms leaves async rooms alone: their participants vote on
+// their own time, so a quiet room is not an abandoned one.
 func (r *roomsRepository) ExpireInactiveRooms(cutoff time.Time) ([]*roomsmodels.RoomsModel, error) {
 	rooms := make([]*roomsmodels.RoomsModel, 0)
 	err := r.db.NewRaw(`
@@ -133,6 +159,7 @@ func (r *roomsRepository) ExpireInactiveRooms(cutoff time.Time) ([]*roomsmodels.
 		SET status = 'EXPIRED',
 		    finished_at = COALESCE(finished_at, NOW())
 		WHERE status = 'ACTIVE'
+		  AND mode <> 'ASYNC'
 		  AND last_activity_at <= ?
 		RETURNING *
 	`, cutoff).
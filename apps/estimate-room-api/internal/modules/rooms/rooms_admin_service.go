@@ -0,0 +1,363 @@
+package rooms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// RoomsAdminService hands the room over to another participant, manages
+// co-facilitators and controls who may stay in the room. Changes are
+// broadcast to the room from here so the REST and WS entry points behave the
+// same.
+type RoomsAdminService interface {
+	TransferAdmin(roomID, userID, participantID string) (*TransferAdminResult, error)
+	SetFacilitator(roomID, userID, participantID string, facilitator bool) (*roomsmodels.RoomParticipantModel, error)
+	SetLocked(roomID, userID string, locked bool) (*roomsmodels.RoomsModel, error)
+	KickParticipant(roomID, userID, participantID string) (*roomsmodels.RoomParticipantModel, error)
+}
+
+type TransferAdminResult struct {
+	Room          *roomsmodels.RoomsModel
+	Admin         *roomsmodels.RoomParticipantModel
+	PreviousAdmin *roomsmodels.RoomParticipantModel
+}
+
+type roomAdminChangedPayload struct {
+	AdminUserID                string `json:"adminUserId"`
+	AdminParticipantID         string `json:"adminParticipantId"`
+	PreviousAdminUserID        string `json:"previousAdminUserId"`
+	PreviousAdminParticipantID string `json:"previousAdminParticipantId"`
+}
+
+type roomLockChangedPayload struct {
+	Locked   bool       `json:"locked"`
+	LockedAt *time.Time `json:"lockedAt,omitempty"`
+}
+
+type roomsAdminService struct {
+	db              *bun.DB
+	roomsRepo       roomsrepositories.RoomsRepository
+	participantRepo roomsrepositories.RoomParticipantRepository
+	wsService       *ws.Service
+	voteService     RoomsVoteService
+	expiryService   RoomsExpiryService
+	logger          *slog.Logger
+}
+
+func NewRoomsAdminService(
+	db *bun.DB,
+	roomsRepo roomsrepositories.RoomsRepository,
+	participantRepo roomsrepositories.RoomParticipantRepository,
+	wsService *ws.Service,
+	voteService RoomsVoteService,
+	expiryService RoomsExpiryService,
+) RoomsAdminService {
+	return &roomsAdminService{
+		db:              db,
+		roomsRepo:       roomsRepo,
+		participantRepo: participantRepo,
+		wsService:       wsService,
+		voteService:     voteService,
+		expiryService:   expiryService,
+		logger:          logger.L().With(slog.String("service", "rooms-admin")),
+	}
+}
+
+// TransferAdmin makes a registered participant the room admin. The previous
+// admin stays on as a co-facilitator.
+func (s *roomsAdminService) TransferAdmin(roomID, userID, participantID string) (*TransferAdminResult, error) {
+	room, admin, err := s.ensureActiveRoomAdmin(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.participantRepo.FindActiveByID(roomID, strings.TrimSpace(participantID))
+	if err != nil {
+		return nil, err
+	}
+	if target.RoomParticipantID == admin.RoomParticipantID {
+		return nil, fmt.Errorf("%w: participant is already the room admin", apperrors.ErrBadRequest)
+	}
+	if target.IsGuest() {
+		return nil, fmt.Errorf("%w: guests cannot become the room admin", apperrors.ErrBadRequest)
+	}
+
+	result := &TransferAdminResult{}
+	err = s.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		roomsRepo := roomsrepositories.NewRoomsRepository(tx)
+		participantRepo := roomsrepositories.NewRoomParticipantRepository(tx)
+
+		result.Admin, err = participantRepo.UpdateRole(roomID, target.RoomParticipantID, roomsmodels.RoomParticipantRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		result.PreviousAdmin, err = participantRepo.UpdateRole(roomID, admin.RoomParticipantID, roomsmodels.RoomParticipantRoleFacilitator)
+		if err != nil {
+			return err
+		}
+
+		result.Room, err = roomsRepo.Update(roomID, roomsrepositories.UpdateRoomFields{
+			AdminUserID: target.UserID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	if err := s.broadcast(roomID, RoomsAdminChanged, roomAdminChangedPayload{
+		AdminUserID:                *result.Admin.UserID,
+		AdminParticipantID:         result.Admin.RoomParticipantID,
+		PreviousAdminUserID:        room.AdminUserID,
+		PreviousAdminParticipantID: result.PreviousAdmin.RoomParticipantID,
+	}); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast admin changed"), "room_id", roomID, "err", err)
+	}
+
+	s.logger.Info(roomsAdminLog("Room admin transferred"), "room_id", roomID, "previous_admin_user_id", room.AdminUserID, "admin_user_id", *result.Admin.UserID)
+
+	return result, nil
+}
+
+// SetFacilitator promotes a registered participant to co-facilitator or
+// demotes a co-facilitator back to a member.
+func (s *roomsAdminService) SetFacilitator(roomID, userID, participantID string, facilitator bool) (*roomsmodels.RoomParticipantModel, error) {
+	if _, _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.FindActiveByID(roomID, strings.TrimSpace(participantID))
+	if err != nil {
+		return nil, err
+	}
+	if participant.Role == roomsmodels.RoomParticipantRoleAdmin {
+		return nil, fmt.Errorf("%w: room admin role cannot be changed", apperrors.ErrBadRequest)
+	}
+	if participant.IsGuest() {
+		return nil, fmt.Errorf("%w: guests cannot be facilitators", apperrors.ErrBadRequest)
+	}
+	if !facilitator && participant.Role != roomsmodels.RoomParticipantRoleFacilitator {
+		return nil, fmt.Errorf("%w: participant is not a facilitator", apperrors.ErrBadRequest)
+	}
+
+	role := roomsmodels.RoomParticipantRoleMember
+	if facilitator {
+		role = roomsmodels.RoomParticipantRoleFacilitator
+	}
+	if participant.Role == role {
+		return participant, nil
+	}
+
+	participant, err = s.participantRepo.UpdateRole(roomID, participant.RoomParticipantID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	if err := s.broadcast(roomID, RoomsParticipantRole, roomParticipantRoleChangedPayload{
+		ParticipantID: participant.RoomParticipantID,
+		UserID:        participant.UserID,
+		GuestName:     participant.GuestName,
+		Role:          participant.Role,
+	}); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast participant role changed"), "room_id", roomID, "participant_id", participant.RoomParticipantID, "err", err)
+	}
+
+	return participant, nil
+}
+
+// SetLocked stops or resumes admitting new participants. Everyone already in
+// the room keeps their seat and can reconnect while it is locked.
+func (s *roomsAdminService) SetLocked(roomID, userID string, locked bool) (*roomsmodels.RoomsModel, error) {
+	room, _, err := s.ensureActiveRoomAdmin(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if room.IsLocked() == locked {
+		return room, nil
+	}
+
+	room, err = s.roomsRepo.Update(roomID, roomsrepositories.UpdateRoomFields{
+		Locked: &locked,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	if err := s.broadcast(roomID, RoomsLockChanged, roomLockChangedPayload{
+		Locked:   room.IsLocked(),
+		LockedAt: room.LockedAt,
+	}); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast lock changed"), "room_id", roomID, "err", err)
+	}
+
+	s.logger.Info(roomsAdminLog("Room lock changed"), "room_id", roomID, "locked", locked)
+
+	return room, nil
+}
+
+// KickParticipant removes a participant from the room. Closing their
+// participation also invalidates a guest token, so they can only come back
+// through a new invitation.
+func (s *roomsAdminService) KickParticipant(roomID, userID, participantID string) (*roomsmodels.RoomParticipantModel, error) {
+	_, admin, err := s.ensureActiveRoomAdmin(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.participantRepo.FindActiveByID(roomID, strings.TrimSpace(participantID))
+	if err != nil {
+		return nil, err
+	}
+	if target.RoomParticipantID == admin.RoomParticipantID || target.Role == roomsmodels.RoomParticipantRoleAdmin {
+		return nil, fmt.Errorf("%w: room admin cannot be removed", apperrors.ErrBadRequest)
+	}
+
+	var (
+		change      *RoundEligibilityChange
+		participant *roomsmodels.RoomParticipantModel
+	)
+	err = s.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		participantRepo := roomsrepositories.NewRoomParticipantRepository(tx)
+
+		change, err = s.voteService.DropFromActiveRoundWithDB(tx, roomID, target.RoomParticipantID)
+		if err != nil {
+			return err
+		}
+
+		participant, err = participantRepo.MarkLeft(roomID, target.RoomParticipantID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.wsService != nil {
+		if err := s.wsService.DisconnectParticipant(roomID, participant.RoomParticipantID, "removed from room"); err != nil {
+			s.logger.Error(roomsAdminLog("Failed to disconnect removed participant"), "room_id", roomID, "participant_id", participant.RoomParticipantID, "err", err)
+		}
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	left := roomParticipantKickedPayload{
+		ParticipantID: participant.RoomParticipantID,
+		UserID:        participant.UserID,
+		GuestName:     participant.GuestName,
+		Role:          participant.Role,
+		Reason:        roomParticipantLeftReasonKicked,
+	}
+	if change.Round != nil {
+		left.TaskID = change.Task.TaskID
+		left.RoundNumber = change.Round.RoundNumber
+		left.EligibleParticipantIDs = append([]string{}, change.Round.EligibleParticipantIDs...)
+	}
+	if err := s.broadcast(roomID, RoomsParticipantLeft, left); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast participant left"), "room_id", roomID, "participant_id", participant.RoomParticipantID, "err", err)
+	}
+
+	s.logger.Info(roomsAdminLog("Participant removed"), "room_id", roomID, "participant_id", participant.RoomParticipantID, "admin_user_id", userID)
+
+	if change.AllVotesCast {
+		s.finishRound(roomID, change)
+	}
+
+	return participant, nil
+}
+
+// finishRound announces that the remaining voters have all voted and reveals
+// the round when the room reveals automatically.
+func (s *roomsAdminService) finishRound(roomID string, change *RoundEligibilityChange) {
+	if err := s.broadcast(roomID, RoomsVotesAllCast, roomVotesAllCastPayload{
+		TaskID:                 change.Task.TaskID,
+		RoundNumber:            change.Round.RoundNumber,
+		EligibleParticipantIDs: append([]string(nil), change.Round.EligibleParticipantIDs...),
+		VotedParticipantIDs:    append([]string(nil), change.VotedParticipantIDs...),
+	}); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast votes all cast"), "room_id", roomID, "task_id", change.Task.TaskID, "err", err)
+	}
+
+	if !change.AutoReveal {
+		return
+	}
+
+	result, err := s.voteService.AutoRevealRound(roomID, change.Task.TaskID, change.Round.RoundNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			s.logger.Debug(roomsAdminLog("Auto reveal skipped"), "room_id", roomID, "task_id", change.Task.TaskID, "reason", err.Error())
+		default:
+			s.logger.Error(roomsAdminLog("Auto reveal failed"), "room_id", roomID, "task_id", change.Task.TaskID, "err", err)
+		}
+		return
+	}
+
+	if err := s.broadcast(roomID, RoomsVotesRevealed, newRoomVotesRevealedPayload(result, roomRevealTriggerAllVoted)); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to broadcast votes revealed"), "room_id", roomID, "task_id", change.Task.TaskID, "err", err)
+	}
+	if err := sendRevealedVotersToAdmin(s.wsService, roomID, result); err != nil {
+		s.logger.Error(roomsAdminLog("Failed to send revealed voters to admin"), "room_id", roomID, "task_id", change.Task.TaskID, "err", err)
+	}
+}
+
+func (s *roomsAdminService) ensureActiveRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, *roomsmodels.RoomParticipantModel, error) {
+	room, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participant, err := s.participantRepo.FindActiveByUserID(roomID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, nil, apperrors.ErrForbidden
+		}
+		return nil, nil, err
+	}
+	if participant.Role != roomsmodels.RoomParticipantRoleAdmin || room.AdminUserID != userID {
+		return nil, nil, apperrors.ErrForbidden
+	}
+	if room.Status != "ACTIVE" {
+		return nil, nil, apperrors.ErrForbidden
+	}
+
+	return room, participant, nil
+}
+
+func (s *roomsAdminService) broadcast(roomID, eventType string, payload any) error {
+	if s.wsService == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.wsService.Broadcast(ws.Event{
+		Type:    eventType,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func roomsAdminLog(message string) string {
+	return logger.Prefix("MODULE", "ROOMS", "ADMIN", message)
+}
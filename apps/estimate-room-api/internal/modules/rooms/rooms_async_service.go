@@ -0,0 +1,413 @@
+package rooms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/master-bogdan/estimate-room-api/internal/infra/email"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+)
+
+const roomReminderSweepInterval = 5 * time.Minute
+
+// RoomsAsyncService runs async rooms over REST: facilitators open tasks with
+// a deadline and participants vote without joining the realtime session.
+// Every change is still broadcast to whoever is connected, and participants
+// who have not voted get one email reminder before the deadline.
+type RoomsAsyncService interface {
+	OpenTask(roomID, taskID, userID string, deadline time.Time) (*AsyncVotingState, error)
+	CastVote(roomID, taskID, userID, dimension, value string) (*AsyncVotingState, error)
+	RetractVote(roomID, taskID, userID, dimension string) (*AsyncVotingState, error)
+	SendDueReminders(ctx context.Context) (int, error)
+	Start(ctx context.Context)
+}
+
+// AsyncVotingState is the open round of an async task. VotedDimensions are
+// the dimensions the caller has voted in.
+type AsyncVotingState struct {
+	Task                *roomsmodels.RoomTaskModel
+	Round               *roomsmodels.RoomTaskRoundModel
+	VotedParticipantIDs []string
+	VotedDimensions     []string
+}
+
+type roomsAsyncService struct {
+	roomsRepo       roomsrepositories.RoomsRepository
+	taskRepo        roomsrepositories.RoomTaskRepository
+	voteRepo        roomsrepositories.RoomVoteRepository
+	roundRepo       roomsrepositories.RoomTaskRoundRepository
+	participantRepo roomsrepositories.RoomParticipantRepository
+	voteService     RoomsVoteService
+	wsService       *ws.Service
+	emailClient     email.Client
+	frontendBaseURL string
+	logger          *slog.Logger
+}
+
+func NewRoomsAsyncService(
+	roomsRepo roomsrepositories.RoomsRepository,
+	taskRepo roomsrepositories.RoomTaskRepository,
+	voteRepo roomsrepositories.RoomVoteRepository,
+	roundRepo roomsrepositories.RoomTaskRoundRepository,
+	participantRepo roomsrepositories.RoomParticipantRepository,
+	voteService RoomsVoteService,
+	wsService *ws.Service,
+	emailClient email.Client,
+	frontendBaseURL string,
+) RoomsAsyncService {
+	if emailClient == nil {
+		emailClient = email.NewNoopClient()
+	}
+
+	return &roomsAsyncService{
+		roomsRepo:       roomsRepo,
+		taskRepo:        taskRepo,
+		voteRepo:        voteRepo,
+		roundRepo:       roundRepo,
+		participantRepo: participantRepo,
+		voteService:     voteService,
+		wsService:       wsService,
+		emailClient:     emailClient,
+		frontendBaseURL: strings.TrimRight(strings.TrimSpace(frontendBaseURL), "/"),
+		logger:          logger.L().With(slog.String("service", "rooms-async")),
+	}
+}
+
+func (s *roomsAsyncService) OpenTask(roomID, taskID, userID string, deadline time.Time) (*AsyncVotingState, error) {
+	result, err := s.voteService.OpenTask(roomID, taskID, userID, deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	var previousTaskID *string
+	if result.PreviousTask != nil {
+		id := result.PreviousTask.TaskID
+		previousTaskID = &id
+	}
+
+	if err := s.broadcast(roomID, RoomsTaskCurrentChanged, roomCurrentTaskChangedPayload{
+		CurrentTaskID:          result.Task.TaskID,
+		PreviousTaskID:         previousTaskID,
+		RoundNumber:            result.Round.RoundNumber,
+		RoundStatus:            string(result.Round.Status),
+		EligibleParticipantIDs: append([]string(nil), result.Round.EligibleParticipantIDs...),
+	}); err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to broadcast current task changed"), "room_id", roomID, "task_id", taskID, "err", err)
+	}
+
+	s.logger.Info(roomsAsyncLog("Task opened"), "room_id", roomID, "task_id", result.Task.TaskID, "round", result.Round.RoundNumber, "deadline", deadline)
+
+	return s.votingState(roomID, result.Task, result.Round, "")
+}
+
+// CastVote stores the caller's vote in the open round and reveals the round
+// once everyone eligible has voted.
+func (s *roomsAsyncService) CastVote(roomID, taskID, userID, dimension, value string) (*AsyncVotingState, error) {
+	participant, err := s.ensureOpenTaskVoter(roomID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.voteService.CastVote(roomID, participant, dimension, value)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.broadcast(roomID, RoomsVoteStatusChanged, roomVoteStatusChangedPayload{
+		TaskID:          result.Task.TaskID,
+		ParticipantID:   participant.RoomParticipantID,
+		RoundNumber:     result.Round.RoundNumber,
+		Voted:           containsParticipantID(result.VotedParticipantIDs, participant.RoomParticipantID),
+		VotedDimensions: result.VotedDimensions,
+	}); err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to broadcast vote status changed"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+	}
+
+	round := result.Round
+	if result.AllVotesCast {
+		if err := s.broadcast(roomID, RoomsVotesAllCast, roomVotesAllCastPayload{
+			TaskID:                 result.Task.TaskID,
+			RoundNumber:            result.Round.RoundNumber,
+			EligibleParticipantIDs: append([]string(nil), result.EligibleParticipantIDs...),
+			VotedParticipantIDs:    append([]string(nil), result.VotedParticipantIDs...),
+		}); err != nil {
+			s.logger.Error(roomsAsyncLog("Failed to broadcast votes all cast"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+		}
+
+		if result.AutoReveal {
+			if revealed := s.autoReveal(roomID, result.Task.TaskID, result.Round.RoundNumber); revealed != nil {
+				round = revealed.Round
+			}
+		}
+	}
+
+	return s.votingState(roomID, result.Task, round, participant.RoomParticipantID)
+}
+
+// RetractVote clears the caller's vote while the round is open. A blank
+// dimension clears every dimension.
+func (s *roomsAsyncService) RetractVote(roomID, taskID, userID, dimension string) (*AsyncVotingState, error) {
+	participant, err := s.ensureOpenTaskVoter(roomID, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.voteService.RetractVote(roomID, participant, dimension)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.broadcast(roomID, RoomsVoteStatusChanged, roomVoteStatusChangedPayload{
+		TaskID:          result.Task.TaskID,
+		ParticipantID:   participant.RoomParticipantID,
+		RoundNumber:     result.Round.RoundNumber,
+		Voted:           false,
+		VotedDimensions: result.VotedDimensions,
+	}); err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to broadcast vote status changed"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+	}
+
+	return s.votingState(roomID, result.Task, result.Round, participant.RoomParticipantID)
+}
+
+// SendDueReminders emails the registered participants who have not voted in
+// an async round nearing its deadline and returns how many were reminded.
+// Guests have no email address and are left out.
+func (s *roomsAsyncService) SendDueReminders(ctx context.Context) (int, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	reminders, err := s.roundRepo.ClaimDueReminders(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, reminder := range reminders {
+		recipients, err := s.reminderRecipients(reminder)
+		if err != nil {
+			s.logger.Error(roomsAsyncLog("Failed to load reminder recipients"), "room_id", reminder.RoomID, "task_id", reminder.TaskID, "err", err)
+			continue
+		}
+
+		for _, recipient := range recipients {
+			if err := s.emailClient.Send(ctx, s.reminderMessage(reminder, recipient)); err != nil {
+				s.logger.Warn(roomsAsyncLog("Failed to send vote reminder"), "room_id", reminder.RoomID, "task_id", reminder.TaskID, "err", err)
+				continue
+			}
+			sent++
+		}
+	}
+
+	return sent, nil
+}
+
+func (s *roomsAsyncService) Start(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	go func() {
+		s.runReminderSweep(ctx)
+
+		ticker := time.NewTicker(roomReminderSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runReminderSweep(ctx)
+			}
+		}
+	}()
+}
+
+func (s *roomsAsyncService) runReminderSweep(ctx context.Context) {
+	sent, err := s.SendDueReminders(ctx)
+	if err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to send vote reminders"), "err", err)
+		return
+	}
+
+	if sent > 0 {
+		s.logger.Info(roomsAsyncLog("Sent vote reminders"), "count", sent)
+	}
+}
+
+// ensureOpenTaskVoter resolves the caller's participant in an async room
+// whose open task is taskID.
+func (s *roomsAsyncService) ensureOpenTaskVoter(roomID, taskID, userID string) (*roomsmodels.RoomParticipantModel, error) {
+	room, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.IsAsync() {
+		return nil, fmt.Errorf("%w: only async rooms take votes over REST", apperrors.ErrBadRequest)
+	}
+
+	participant, err := s.participantRepo.FindActiveByUserID(roomID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrForbidden
+		}
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindCurrentVotingTask(roomID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: task is not open for voting", apperrors.ErrConflict)
+		}
+		return nil, err
+	}
+	if task.TaskID != strings.TrimSpace(taskID) {
+		return nil, fmt.Errorf("%w: task is not open for voting", apperrors.ErrConflict)
+	}
+
+	return participant, nil
+}
+
+func (s *roomsAsyncService) autoReveal(roomID, taskID string, roundNumber int) *RevealVotesResult {
+	result, err := s.voteService.AutoRevealRound(roomID, taskID, roundNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			s.logger.Debug(roomsAsyncLog("Auto reveal skipped"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "reason", err.Error())
+		default:
+			s.logger.Error(roomsAsyncLog("Auto reveal failed"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "err", err)
+		}
+		return nil
+	}
+
+	if err := s.broadcast(roomID, RoomsVotesRevealed, newRoomVotesRevealedPayload(result, roomRevealTriggerAllVoted)); err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to broadcast votes revealed"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "err", err)
+	}
+	if err := sendRevealedVotersToAdmin(s.wsService, roomID, result); err != nil {
+		s.logger.Error(roomsAsyncLog("Failed to send revealed voters to admin"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "err", err)
+	}
+
+	return result
+}
+
+func (s *roomsAsyncService) votingState(
+	roomID string,
+	task *roomsmodels.RoomTaskModel,
+	round *roomsmodels.RoomTaskRoundModel,
+	participantID string,
+) (*AsyncVotingState, error) {
+	room, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := s.voteRepo.ListByTaskAndRound(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &AsyncVotingState{
+		Task:                task,
+		Round:               round,
+		VotedParticipantIDs: filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs),
+		VotedDimensions:     []string{},
+	}
+	if participantID != "" {
+		state.VotedDimensions = votedDimensions(votes, room.Dimensions, participantID)
+	}
+
+	return state, nil
+}
+
+// reminderRecipients lists the emails of eligible registered participants
+// who are still in the room and have not finished voting.
+func (s *roomsAsyncService) reminderRecipients(reminder roomsrepositories.DueRoundReminder) ([]string, error) {
+	room, err := s.roomsRepo.FindByID(reminder.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := s.voteRepo.ListByTaskAndRound(reminder.TaskID, reminder.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+	votedParticipantIDs := completeVoterIDs(votes, room.Dimensions)
+
+	participants, err := s.participantRepo.ListRegisteredByRoom(reminder.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]string, 0, len(participants))
+	seen := make(map[string]struct{}, len(participants))
+	for _, participant := range participants {
+		if participant.LeftAt != nil ||
+			!containsParticipantID(reminder.EligibleParticipantIDs, participant.RoomParticipantID) ||
+			containsParticipantID(votedParticipantIDs, participant.RoomParticipantID) {
+			continue
+		}
+		if participant.User == nil || participant.User.DeletedAt != nil || participant.User.Email == nil {
+			continue
+		}
+
+		address := strings.ToLower(strings.TrimSpace(*participant.User.Email))
+		if address == "" {
+			continue
+		}
+		if _, exists := seen[address]; exists {
+			continue
+		}
+
+		seen[address] = struct{}{}
+		recipients = append(recipients, address)
+	}
+
+	return recipients, nil
+}
+
+func (s *roomsAsyncService) reminderMessage(reminder roomsrepositories.DueRoundReminder, recipient string) email.Message {
+	body := fmt.Sprintf("Voting on %q in %q closes at %s and your estimate is still missing.\n\n",
+		reminder.TaskTitle, reminder.RoomName, reminder.TimerEndsAt.UTC().Format("2006-01-02 15:04 UTC"))
+	if s.frontendBaseURL != "" {
+		body += "Cast your vote here:\n" + s.frontendBaseURL + "/rooms/" + reminder.RoomID + "\n\n"
+	}
+	body += "Votes are revealed automatically at the deadline or once everyone has voted.\n"
+
+	return email.Message{
+		To:       []string{recipient},
+		Subject:  fmt.Sprintf("Your vote is needed: %s", reminder.TaskTitle),
+		TextBody: body,
+	}
+}
+
+func (s *roomsAsyncService) broadcast(roomID, eventType string, payload any) error {
+	if s.wsService == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.wsService.Broadcast(ws.Event{
+		Type:    eventType,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func roomsAsyncLog(message string) string {
+	return logger.Prefix("MODULE", "ROOMS", "ASYNC", message)
+}
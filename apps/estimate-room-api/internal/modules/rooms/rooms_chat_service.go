@@ -0,0 +1,140 @@
+package rooms
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+)
+
+// roomSnapshotChatMessages is how many of the latest chat messages a room
+// snapshot carries.
+const roomSnapshotChatMessages = 50
+
+// RoomsChatService stores the room chat. Every room has one room-wide channel
+// and one discussion thread per task.
+type RoomsChatService interface {
+	SendMessage(roomID string, participant *roomsmodels.RoomParticipantModel, taskID *string, body string) (*roomsmodels.RoomChatMessageModel, error)
+	ListMessages(roomID string, taskID *string, beforeMessageID string, limit int) ([]*roomsmodels.RoomChatMessageModel, bool, error)
+	ListRecentMessages(roomID string) ([]*roomsmodels.RoomChatMessageModel, error)
+}
+
+type roomsChatService struct {
+	roomsRepo     roomsrepositories.RoomsRepository
+	taskRepo      roomsrepositories.RoomTaskRepository
+	chatRepo      roomsrepositories.RoomChatRepository
+	expiryService RoomsExpiryService
+}
+
+func NewRoomsChatService(
+	roomsRepo roomsrepositories.RoomsRepository,
+	taskRepo roomsrepositories.RoomTaskRepository,
+	chatRepo roomsrepositories.RoomChatRepository,
+	expiryService RoomsExpiryService,
+) RoomsChatService {
+	return &roomsChatService{
+		roomsRepo:     roomsRepo,
+		taskRepo:      taskRepo,
+		chatRepo:      chatRepo,
+		expiryService: expiryService,
+	}
+}
+
+// SendMessage posts to the room channel, or to the task's thread when taskID
+// is set. Any active participant may post, including observers and guests.
+func (s *roomsChatService) SendMessage(
+	roomID string,
+	participant *roomsmodels.RoomParticipantModel,
+	taskID *string,
+	body string,
+) (*roomsmodels.RoomChatMessageModel, error) {
+	if participant == nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	room, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status != "ACTIVE" {
+		return nil, apperrors.ErrForbidden
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, fmt.Errorf("%w: message is required", apperrors.ErrBadRequest)
+	}
+	if utf8.RuneCountInString(body) > roomsmodels.MaxChatMessageLength {
+		return nil, fmt.Errorf("%w: message is longer than %d characters", apperrors.ErrBadRequest, roomsmodels.MaxChatMessageLength)
+	}
+
+	taskID, err = s.resolveThread(roomID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := s.chatRepo.Create(&roomsmodels.RoomChatMessageModel{
+		ChatMessageID: uuid.NewString(),
+		RoomID:        roomID,
+		TaskID:        taskID,
+		ParticipantID: participant.RoomParticipantID,
+		Body:          body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return message, nil
+}
+
+// ListMessages returns up to limit messages of a thread older than
+// beforeMessageID, oldest first, and whether older messages remain.
+func (s *roomsChatService) ListMessages(roomID string, taskID *string, beforeMessageID string, limit int) ([]*roomsmodels.RoomChatMessageModel, bool, error) {
+	if limit < 1 {
+		return nil, false, apperrors.ErrBadRequest
+	}
+
+	taskID, err := s.resolveThread(roomID, taskID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	messages, err := s.chatRepo.ListThread(roomID, taskID, strings.TrimSpace(beforeMessageID), limit+1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	hasMore := len(messages) > limit
+	if hasMore {
+		messages = messages[:limit]
+	}
+	slices.Reverse(messages)
+
+	return messages, hasMore, nil
+}
+
+func (s *roomsChatService) ListRecentMessages(roomID string) ([]*roomsmodels.RoomChatMessageModel, error) {
+	return s.chatRepo.ListRecent(roomID, roomSnapshotChatMessages)
+}
+
+// resolveThread checks that a task thread belongs to the room. A blank task
+// ID selects the room channel.
+func (s *roomsChatService) resolveThread(roomID string, taskID *string) (*string, error) {
+	if taskID == nil || strings.TrimSpace(*taskID) == "" {
+		return nil, nil
+	}
+
+	task, err := s.taskRepo.FindByID(roomID, strings.TrimSpace(*taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &task.TaskID, nil
+}
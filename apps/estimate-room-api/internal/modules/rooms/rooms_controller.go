@@ -3,7 +3,9 @@ package rooms
 import (
 	"encoding/json"
 	stdErrors "errors"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"strings"
 
@@ -18,20 +20,38 @@ import (
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
 )
 
+const maxTaskImportBytes = 1 << 20
+
 type RoomsController interface {
 	CreateRoom(w http.ResponseWriter, r *http.Request)
 	GetRoom(w http.ResponseWriter, r *http.Request)
 	UpdateRoom(w http.ResponseWriter, r *http.Request)
+	CloneRoom(w http.ResponseWriter, r *http.Request)
+	TransferAdmin(w http.ResponseWriter, r *http.Request)
+	AddFacilitator(w http.ResponseWriter, r *http.Request)
+	RemoveFacilitator(w http.ResponseWriter, r *http.Request)
+	LockRoom(w http.ResponseWriter, r *http.Request)
+	UnlockRoom(w http.ResponseWriter, r *http.Request)
+	KickParticipant(w http.ResponseWriter, r *http.Request)
+	ListChatMessages(w http.ResponseWriter, r *http.Request)
 	CreateTask(w http.ResponseWriter, r *http.Request)
+	ImportTasks(w http.ResponseWriter, r *http.Request)
 	ListTasks(w http.ResponseWriter, r *http.Request)
+	ReorderTasks(w http.ResponseWriter, r *http.Request)
 	GetTask(w http.ResponseWriter, r *http.Request)
 	UpdateTask(w http.ResponseWriter, r *http.Request)
 	DeleteTask(w http.ResponseWriter, r *http.Request)
+	OpenTask(w http.ResponseWriter, r *http.Request)
+	CastTaskVote(w http.ResponseWriter, r *http.Request)
+	RetractTaskVote(w http.ResponseWriter, r *http.Request)
 }
 
 type roomsController struct {
 	service       RoomsService
 	taskService   RoomsTaskService
+	adminService  RoomsAdminService
+	chatService   RoomsChatService
+	asyncService  RoomsAsyncService
 	inviteService invites.InvitesService
 	authService   oauth2.Oauth2SessionAuthService
 	logger        *slog.Logger
@@ -40,12 +60,18 @@ type roomsController struct {
 func NewRoomsController(
 	service RoomsService,
 	taskService RoomsTaskService,
+	adminService RoomsAdminService,
+	chatService RoomsChatService,
+	asyncService RoomsAsyncService,
 	inviteService invites.InvitesService,
 	authService oauth2.Oauth2SessionAuthService,
 ) RoomsController {
 	return &roomsController{
 		service:       service,
 		taskService:   taskService,
+		adminService:  adminService,
+		chatService:   chatService,
+		asyncService:  asyncService,
 		inviteService: inviteService,
 		authService:   authService,
 		logger:        logger.L().With(slog.String("controller", "rooms")),
@@ -79,8 +105,23 @@ func (c *roomsController) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		"invite_emails_count", len(dto.InviteEmails),
 		"create_share_link", dto.CreateShareLink,
 		"has_deck", dto.Deck != nil,
+		"deck_id", dto.DeckID,
+		"has_options", dto.Options != nil,
+		"dimensions_count", len(dto.Dimensions),
+		"mode", dto.Mode,
 	)
 
+	var options *roomsmodels.RoomOptions
+	if dto.Options != nil {
+		options = &roomsmodels.RoomOptions{
+			CreateShareLink:    dto.Options.CreateShareLink,
+			AutoReveal:         dto.Options.AutoReveal,
+			VotingTimerSeconds: dto.Options.VotingTimerSeconds,
+			AnonymousVoting:    dto.Options.AnonymousVoting,
+			AdminSeesVotes:     dto.Options.AdminSeesVotes,
+		}
+	}
+
 	deck := roomsmodels.RoomDeck{}
 	if dto.Deck != nil {
 		deck = roomsmodels.RoomDeck{
@@ -90,13 +131,30 @@ func (c *roomsController) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	dimensions := make(roomsmodels.RoomDimensions, 0, len(dto.Dimensions))
+	for _, dimension := range dto.Dimensions {
+		dimensions = append(dimensions, roomsmodels.RoomDimension{
+			Key:  dimension.Key,
+			Name: dimension.Name,
+			Deck: roomsmodels.RoomDeck{
+				Name:   dimension.Deck.Name,
+				Kind:   dimension.Deck.Kind,
+				Values: dimension.Deck.Values,
+			},
+		})
+	}
+
 	createdRoom, err := c.service.CreateRoom(r.Context(), CreateRoomInput{
 		Name:            dto.Name,
 		Deck:            deck,
+		DeckID:          stringPointerOrNil(dto.DeckID),
 		AdminUserID:     userID,
 		InviteTeamID:    stringPointerOrNil(dto.InviteTeamID),
 		InviteEmails:    dto.InviteEmails,
 		CreateShareLink: dto.CreateShareLink,
+		Options:         options,
+		Dimensions:      dimensions,
+		Mode:            roomsmodels.RoomMode(dto.Mode),
 	})
 	if err != nil {
 		switch {
@@ -112,23 +170,60 @@ func (c *roomsController) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	httputils.WriteResponse(w, newCreateRoomResponse(createdRoom))
+}
+
+// CloneRoom starts a follow-up session from a finished or expired room. The
+// body is optional.
+func (c *roomsController) CloneRoom(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+
+	dto := roomsdto.CloneRoomDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil && !stdErrors.Is(err, io.EOF) {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	clonedRoom, err := c.service.CloneRoom(r.Context(), roomID, userID, CloneRoomInput{
+		Name:               dto.Name,
+		InviteParticipants: dto.InviteParticipants,
+	})
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newCreateRoomResponse(clonedRoom))
+}
+
+func newCreateRoomResponse(result *CreateRoomResult) roomsdto.CreateRoomResponse {
 	response := roomsdto.CreateRoomResponse{
-		Room:              createdRoom.Room,
-		EmailInvites:      make([]invitesdto.InvitationWithTokenResponse, 0, len(createdRoom.EmailInvitations)),
-		SkippedRecipients: make([]roomsdto.CreateRoomSkippedRecipientResponse, 0, len(createdRoom.SkippedRecipients)),
+		Room:              result.Room,
+		EmailInvites:      make([]invitesdto.InvitationWithTokenResponse, 0, len(result.EmailInvitations)),
+		SkippedRecipients: make([]roomsdto.CreateRoomSkippedRecipientResponse, 0, len(result.SkippedRecipients)),
 	}
 
-	for _, invite := range createdRoom.EmailInvitations {
+	for _, invite := range result.EmailInvitations {
 		response.EmailInvites = append(response.EmailInvites, invitesdto.NewInvitationWithTokenResponse(invite.Invitation, invite.Token))
 	}
 
-	if createdRoom.ShareLink != nil {
-		shareLink := invitesdto.NewInvitationWithTokenResponse(createdRoom.ShareLink.Invitation, createdRoom.ShareLink.Token)
+	if result.ShareLink != nil {
+		shareLink := invitesdto.NewInvitationWithTokenResponse(result.ShareLink.Invitation, result.ShareLink.Token)
 		response.ShareLink = &shareLink
-		response.InviteToken = createdRoom.ShareLink.Token
+		response.InviteToken = result.ShareLink.Token
 	}
 
-	for _, skipped := range createdRoom.SkippedRecipients {
+	for _, skipped := range result.SkippedRecipients {
 		response.SkippedRecipients = append(response.SkippedRecipients, roomsdto.CreateRoomSkippedRecipientResponse{
 			UserID: skipped.UserID,
 			Email:  skipped.Email,
@@ -136,7 +231,7 @@ func (c *roomsController) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	httputils.WriteResponse(w, response)
+	return response
 }
 
 func (c *roomsController) GetRoom(w http.ResponseWriter, r *http.Request) {
@@ -179,24 +274,157 @@ func (c *roomsController) UpdateRoom(w http.ResponseWriter, r *http.Request) {
 		"path", r.URL.Path,
 		"name_provided", dto.Name != nil,
 		"status", dto.Status,
+		"options_provided", dto.Options != nil,
 	)
 
+	var options *UpdateRoomOptionsInput
+	if dto.Options != nil {
+		options = &UpdateRoomOptionsInput{
+			AutoReveal:         dto.Options.AutoReveal,
+			VotingTimerSeconds: dto.Options.VotingTimerSeconds,
+			AnonymousVoting:    dto.Options.AnonymousVoting,
+			AdminSeesVotes:     dto.Options.AdminSeesVotes,
+		}
+	}
+
 	room, err := c.service.UpdateRoom(roomID, userID, UpdateRoomInput{
-		Name:   dto.Name,
-		Status: dto.Status,
+		Name:    dto.Name,
+		Status:  dto.Status,
+		Options: options,
 	})
 	if err != nil {
 		c.writeRoomError(w, r, err)
 		return
 	}
 
-	if dto.Status != nil && *dto.Status == "FINISHED" {
-		// TODO: emit websocket event when room is finished.
+	httputils.WriteResponse(w, room)
+}
+
+func (c *roomsController) TransferAdmin(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+
+	dto := roomsdto.TransferRoomAdminDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	result, err := c.adminService.TransferAdmin(roomID, userID, dto.ParticipantID)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, result.Room)
+}
+
+func (c *roomsController) AddFacilitator(w http.ResponseWriter, r *http.Request) {
+	c.setFacilitator(w, r, true)
+}
+
+func (c *roomsController) RemoveFacilitator(w http.ResponseWriter, r *http.Request) {
+	c.setFacilitator(w, r, false)
+}
+
+func (c *roomsController) setFacilitator(w http.ResponseWriter, r *http.Request, facilitator bool) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	participantID := chi.URLParam(r, "participantId")
+
+	participant, err := c.adminService.SetFacilitator(roomID, userID, participantID, facilitator)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, participant)
+}
+
+func (c *roomsController) LockRoom(w http.ResponseWriter, r *http.Request) {
+	c.setLocked(w, r, true)
+}
+
+func (c *roomsController) UnlockRoom(w http.ResponseWriter, r *http.Request) {
+	c.setLocked(w, r, false)
+}
+
+func (c *roomsController) setLocked(w http.ResponseWriter, r *http.Request, locked bool) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+
+	room, err := c.adminService.SetLocked(roomID, userID, locked)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
 	}
 
 	httputils.WriteResponse(w, room)
 }
 
+func (c *roomsController) KickParticipant(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	participantID := chi.URLParam(r, "participantId")
+
+	participant, err := c.adminService.KickParticipant(roomID, userID, participantID)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, participant)
+}
+
+// ListChatMessages pages back through the room channel, or a task thread with
+// ?taskId=. Guests can read the chat of the room they joined.
+func (c *roomsController) ListChatMessages(w http.ResponseWriter, r *http.Request) {
+	roomID := chi.URLParam(r, "id")
+
+	if err := c.ensureRoomReadable(r, roomID); err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	query, err := roomsdto.ParseRoomChatMessagesQuery(r.URL.Query())
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	messages, hasMore, err := c.chatService.ListMessages(roomID, query.TaskID, query.Before, query.Limit)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, roomsdto.RoomChatMessagesResponse{
+		Items:   roomsdto.NewRoomChatMessageResponses(messages),
+		HasMore: hasMore,
+	})
+}
+
 func (c *roomsController) CreateTask(w http.ResponseWriter, r *http.Request) {
 	userID, ok := c.requireUserID(w, r)
 	if !ok {
@@ -241,6 +469,63 @@ func (c *roomsController) CreateTask(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteResponse(w, task)
 }
 
+// ImportTasks accepts a JSON array of tasks or, with a text/csv content
+// type, a CSV backlog with title, description and external_key columns.
+func (c *roomsController) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	body := http.MaxBytesReader(w, r.Body, maxTaskImportBytes)
+
+	dto := &roomsdto.ImportRoomTasksDTO{}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		parsed, err := roomsdto.ParseImportRoomTasksCSV(body)
+		if err != nil {
+			c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+			return
+		}
+		dto = parsed
+	default:
+		if err := json.NewDecoder(body).Decode(&dto.Tasks); err != nil {
+			c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+			return
+		}
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	logger.FromRequest(r, c.logger).Info("import tasks dto accepted",
+		"path", r.URL.Path,
+		"format", mediaType,
+		"count", len(dto.Tasks),
+	)
+
+	inputs := make([]CreateTaskInput, 0, len(dto.Tasks))
+	for _, task := range dto.Tasks {
+		inputs = append(inputs, CreateTaskInput{
+			Title:       task.Title,
+			Description: task.Description,
+			ExternalKey: task.ExternalKey,
+		})
+	}
+
+	tasks, err := c.taskService.ImportTasks(roomID, userID, inputs)
+	if err != nil {
+		c.writeTaskError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, tasks)
+}
+
 func (c *roomsController) ListTasks(w http.ResponseWriter, r *http.Request) {
 	userID, ok := c.requireUserID(w, r)
 	if !ok {
@@ -258,6 +543,34 @@ func (c *roomsController) ListTasks(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteResponse(w, tasks)
 }
 
+func (c *roomsController) ReorderTasks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+
+	dto := roomsdto.ReorderRoomTasksDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	tasks, err := c.taskService.ReorderTasks(roomID, userID, dto.TaskIDs, dto.Position)
+	if err != nil {
+		c.writeTaskError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, tasks)
+}
+
 func (c *roomsController) GetTask(w http.ResponseWriter, r *http.Request) {
 	userID, ok := c.requireUserID(w, r)
 	if !ok {
@@ -302,6 +615,7 @@ func (c *roomsController) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		"status", dto.Status,
 		"is_active", dto.IsActive,
 		"final_estimate_value_provided", dto.FinalEstimateValue != nil,
+		"final_dimension_estimates_count", len(dto.FinalDimensionEstimates),
 	)
 
 	if dto.Title != nil && strings.TrimSpace(*dto.Title) == "" {
@@ -310,12 +624,13 @@ func (c *roomsController) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task, err := c.taskService.UpdateTask(roomID, taskID, userID, UpdateTaskInput{
-		Title:              dto.Title,
-		Description:        dto.Description,
-		ExternalKey:        dto.ExternalKey,
-		Status:             dto.Status,
-		IsActive:           dto.IsActive,
-		FinalEstimateValue: dto.FinalEstimateValue,
+		Title:                   dto.Title,
+		Description:             dto.Description,
+		ExternalKey:             dto.ExternalKey,
+		Status:                  dto.Status,
+		IsActive:                dto.IsActive,
+		FinalEstimateValue:      dto.FinalEstimateValue,
+		FinalDimensionEstimates: dto.FinalDimensionEstimates,
 	})
 	if err != nil {
 		c.writeTaskError(w, r, err)
@@ -342,6 +657,103 @@ func (c *roomsController) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	httputils.WriteResponse(w, map[string]bool{"ok": true})
 }
 
+// OpenTask opens a task of an async room for voting until the given deadline.
+func (c *roomsController) OpenTask(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	dto := roomsdto.OpenRoomTaskDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	state, err := c.asyncService.OpenTask(roomID, taskID, userID, dto.Deadline)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newRoomTaskVotingResponse(state))
+}
+
+// CastTaskVote votes on the open task of an async room.
+func (c *roomsController) CastTaskVote(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	dto := roomsdto.CastRoomTaskVoteDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	state, err := c.asyncService.CastVote(roomID, taskID, userID, dto.Dimension, dto.Value)
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newRoomTaskVotingResponse(state))
+}
+
+// RetractTaskVote clears the caller's vote on the open task of an async room,
+// in one dimension with ?dimension= or in all of them.
+func (c *roomsController) RetractTaskVote(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	roomID := chi.URLParam(r, "id")
+	taskID := chi.URLParam(r, "taskId")
+
+	state, err := c.asyncService.RetractVote(roomID, taskID, userID, r.URL.Query().Get("dimension"))
+	if err != nil {
+		c.writeRoomError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newRoomTaskVotingResponse(state))
+}
+
+func newRoomTaskVotingResponse(state *AsyncVotingState) roomsdto.RoomTaskVotingResponse {
+	eligibleParticipantIDs := state.Round.EligibleParticipantIDs
+	if eligibleParticipantIDs == nil {
+		eligibleParticipantIDs = []string{}
+	}
+
+	return roomsdto.RoomTaskVotingResponse{
+		TaskID:                 state.Task.TaskID,
+		RoundNumber:            state.Round.RoundNumber,
+		RoundStatus:            string(state.Round.Status),
+		Deadline:               state.Round.TimerEndsAt,
+		EligibleParticipantIDs: eligibleParticipantIDs,
+		VotedParticipantIDs:    state.VotedParticipantIDs,
+		VotedDimensions:        state.VotedDimensions,
+	}
+}
+
 func (c *roomsController) writeError(w http.ResponseWriter, r *http.Request, errType error, detail string, cause error) {
 	logArgs := []any{
 		"path", r.URL.Path,
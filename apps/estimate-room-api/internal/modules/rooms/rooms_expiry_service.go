@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/master-bogdan/estimate-room-api/internal/modules/gamification"
@@ -24,15 +25,22 @@ const (
 type RoomsExpiryService interface {
 	TouchActivity(roomID string)
 	ExpireInactiveRooms(cutoff time.Time) ([]*roomsmodels.RoomsModel, error)
+	OnExpire(handler RoomExpiredHandler)
 	Start(ctx context.Context)
 }
 
+// RoomExpiredHandler runs for every room the expiry sweep expires.
+type RoomExpiredHandler func(room *roomsmodels.RoomsModel)
+
 type roomsExpiryService struct {
 	db        *bun.DB
 	roomsRepo roomsrepositories.RoomsRepository
 	wsService *ws.Service
 	rewardSvc gamification.RoomRewardService
 	logger    *slog.Logger
+
+	mu             sync.RWMutex
+	expireHandlers []RoomExpiredHandler
 }
 
 type roomExpiredPayload struct {
@@ -94,11 +102,32 @@ func (s *roomsExpiryService) ExpireInactiveRooms(cutoff time.Time) ([]*roomsmode
 		if err := s.broadcastRoomExpired(room); err != nil {
 			s.logger.Error(roomsExpiryLog("Failed to broadcast room expired"), "room_id", room.RoomID, "err", err)
 		}
+		s.notifyExpired(room)
 	}
 
 	return expiredRooms, nil
 }
 
+func (s *roomsExpiryService) OnExpire(handler RoomExpiredHandler) {
+	if handler == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.expireHandlers = append(s.expireHandlers, handler)
+	s.mu.Unlock()
+}
+
+func (s *roomsExpiryService) notifyExpired(room *roomsmodels.RoomsModel) {
+	s.mu.RLock()
+	handlers := append([]RoomExpiredHandler(nil), s.expireHandlers...)
+	s.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(room)
+	}
+}
+
 func (s *roomsExpiryService) Start(ctx context.Context) {
 	if ctx == nil {
 		return
@@ -140,13 +169,20 @@ func (s *roomsExpiryService) applyTerminalRewardsBestEffort(room *roomsmodels.Ro
 	}
 
 	appliedRewards := make([]gamification.AppliedRoomReward, 0)
+	var appliedTeamReward *gamification.AppliedTeamReward
 	err := s.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
 		rewards, err := s.rewardSvc.ApplyRoomTerminalRewards(ctx, tx, room)
 		if err != nil {
 			return err
 		}
 
+		teamReward, err := s.rewardSvc.ApplyTeamTerminalRewards(ctx, tx, room)
+		if err != nil {
+			return err
+		}
+
 		appliedRewards = rewards
+		appliedTeamReward = teamReward
 		return nil
 	})
 	if err != nil {
@@ -154,12 +190,16 @@ func (s *roomsExpiryService) applyTerminalRewardsBestEffort(room *roomsmodels.Ro
 		return
 	}
 
-	if len(appliedRewards) == 0 {
-		return
+	if len(appliedRewards) > 0 {
+		if err := s.rewardSvc.NotifyAppliedRewards(context.Background(), appliedRewards); err != nil {
+			s.logger.Error(roomsExpiryLog("Failed to notify room expiry rewards"), "room_id", room.RoomID, "count", len(appliedRewards), "err", err)
+		}
 	}
 
-	if err := s.rewardSvc.NotifyAppliedRewards(context.Background(), appliedRewards); err != nil {
-		s.logger.Error(roomsExpiryLog("Failed to notify room expiry rewards"), "room_id", room.RoomID, "count", len(appliedRewards), "err", err)
+	if appliedTeamReward != nil {
+		if err := s.rewardSvc.NotifyAppliedTeamReward(context.Background(), appliedTeamReward); err != nil {
+			s.logger.Error(roomsExpiryLog("Failed to notify team expiry rewards"), "room_id", room.RoomID, "team_id", appliedTeamReward.TeamID, "err", err)
+		}
 	}
 }
 
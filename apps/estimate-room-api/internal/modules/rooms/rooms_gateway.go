@@ -3,9 +3,12 @@ package rooms
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
+	roomsdto "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/dto"
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
@@ -22,6 +25,10 @@ type roomsGateway struct {
 	roundRepo       roomsrepositories.RoomTaskRoundRepository
 	voteService     RoomsVoteService
 	expiryService   RoomsExpiryService
+	adminService    RoomsAdminService
+	chatService     RoomsChatService
+	signalService   RoomsSignalService
+	roomsService    RoomsService
 }
 
 func NewRoomsGateway(
@@ -33,6 +40,10 @@ func NewRoomsGateway(
 	roundRepo roomsrepositories.RoomTaskRoundRepository,
 	voteService RoomsVoteService,
 	expiryService RoomsExpiryService,
+	adminService RoomsAdminService,
+	chatService RoomsChatService,
+	signalService RoomsSignalService,
+	roomsService RoomsService,
 ) *roomsGateway {
 	return &roomsGateway{
 		wsService:       wsService,
@@ -43,38 +54,140 @@ func NewRoomsGateway(
 		roundRepo:       roundRepo,
 		voteService:     voteService,
 		expiryService:   expiryService,
+		adminService:    adminService,
+		chatService:     chatService,
+		signalService:   signalService,
+		roomsService:    roomsService,
 	}
 }
 
 const (
-	RoomsJoin           = "ROOMS_JOIN"
-	RoomsTaskSetCurrent = "ROOMS_TASK_SET_CURRENT"
-	RoomsVoteCast       = "ROOMS_VOTE_CAST"
-	RoomsVoteReveal     = "ROOMS_VOTE_REVEAL"
-	RoomsRoundNext      = "ROOMS_ROUND_NEXT"
-	RoomsTaskFinalize   = "ROOMS_TASK_FINALIZE"
-
-	RoomsParticipantJoined  = "ROOMS_PARTICIPANT_JOINED"
-	RoomsParticipantLeft    = "ROOMS_PARTICIPANT_LEFT"
-	RoomsTaskCurrentChanged = "ROOMS_TASK_CURRENT_CHANGED"
-	RoomsVoteStatusChanged  = "ROOMS_VOTE_STATUS_CHANGED"
-	RoomsVotesAllCast       = "ROOMS_VOTES_ALL_CAST"
-	RoomsVotesRevealed      = "ROOMS_VOTES_REVEALED"
-	RoomsRoundChanged       = "ROOMS_ROUND_CHANGED"
-	RoomsTaskFinalized      = "ROOMS_TASK_FINALIZED"
-	RoomsExpired            = "ROOMS_EXPIRED"
-	RoomsSnapshot           = "ROOMS_SNAPSHOT"
+	RoomsJoin            = "ROOMS_JOIN"
+	RoomsResume          = "ROOMS_RESUME"
+	RoomsTaskSetCurrent  = "ROOMS_TASK_SET_CURRENT"
+	RoomsTaskReestimate  = "ROOMS_TASK_REESTIMATE"
+	RoomsVoteCast        = "ROOMS_VOTE_CAST"
+	RoomsVoteRetract     = "ROOMS_VOTE_RETRACT"
+	RoomsVoteReveal      = "ROOMS_VOTE_REVEAL"
+	RoomsRoundNext       = "ROOMS_ROUND_NEXT"
+	RoomsTaskFinalize    = "ROOMS_TASK_FINALIZE"
+	RoomsTaskSkip        = "ROOMS_TASK_SKIP"
+	RoomsFinish          = "ROOMS_FINISH"
+	RoomsObserverSet     = "ROOMS_PARTICIPANT_OBSERVER_SET"
+	RoomsFacilitatorSet  = "ROOMS_PARTICIPANT_FACILITATOR_SET"
+	RoomsAdminTransfer   = "ROOMS_ADMIN_TRANSFER"
+	RoomsLockSet         = "ROOMS_LOCK_SET"
+	RoomsParticipantKick = "ROOMS_PARTICIPANT_KICK"
+	RoomsChatSend        = "ROOMS_CHAT_SEND"
+	RoomsReactionSend    = "ROOMS_REACTION_SEND"
+	RoomsFlagSet         = "ROOMS_FLAG_SET"
+	RoomsFlagsClear      = "ROOMS_FLAGS_CLEAR"
+
+	RoomsParticipantJoined   = "ROOMS_PARTICIPANT_JOINED"
+	RoomsParticipantLeft     = "ROOMS_PARTICIPANT_LEFT"
+	RoomsParticipantRole     = "ROOMS_PARTICIPANT_ROLE_CHANGED"
+	RoomsAdminChanged        = "ROOMS_ADMIN_CHANGED"
+	RoomsLockChanged         = "ROOMS_LOCK_CHANGED"
+	RoomsTasksImported       = "ROOMS_TASKS_IMPORTED"
+	RoomsTasksReordered      = "ROOMS_TASKS_REORDERED"
+	RoomsTaskCurrentChanged  = "ROOMS_TASK_CURRENT_CHANGED"
+	RoomsTaskReopened        = "ROOMS_TASK_REOPENED"
+	RoomsVoteStatusChanged   = "ROOMS_VOTE_STATUS_CHANGED"
+	RoomsVotesAllCast        = "ROOMS_VOTES_ALL_CAST"
+	RoomsVotesRevealed       = "ROOMS_VOTES_REVEALED"
+	RoomsVotesRevealedVoters = "ROOMS_VOTES_REVEALED_VOTERS"
+	RoomsRoundChanged        = "ROOMS_ROUND_CHANGED"
+	RoomsTaskFinalized       = "ROOMS_TASK_FINALIZED"
+	RoomsTaskSkipped         = "ROOMS_TASK_SKIPPED"
+	RoomsFinished            = "ROOMS_FINISHED"
+	RoomsExpired             = "ROOMS_EXPIRED"
+	RoomsSnapshot            = "ROOMS_SNAPSHOT"
+	RoomsTimerStarted        = "ROOMS_TIMER_STARTED"
+	RoomsTimerCancelled      = "ROOMS_TIMER_CANCELLED"
+	RoomsTimerExpired        = "ROOMS_TIMER_EXPIRED"
+	RoomsChatMessage         = "ROOMS_CHAT_MESSAGE"
+	RoomsReaction            = "ROOMS_REACTION"
+	RoomsFlagChanged         = "ROOMS_FLAG_CHANGED"
+	RoomsFlagsCleared        = "ROOMS_FLAGS_CLEARED"
+)
+
+const (
+	roomRevealTriggerAdmin    = "ADMIN"
+	roomRevealTriggerAllVoted = "ALL_VOTED"
+	roomRevealTriggerTimer    = "TIMER"
+)
+
+// Command handlers return the error their sender is told about. A command is
+// acknowledged once its change is stored, even if a broadcast about it fails.
+var (
+	errRoomCommandMissingRoomID        = fmt.Errorf("%w: room ID is required", apperrors.ErrBadRequest)
+	errRoomCommandMissingTaskID        = fmt.Errorf("%w: task ID is required", apperrors.ErrBadRequest)
+	errRoomCommandMissingParticipantID = fmt.Errorf("%w: participant ID is required", apperrors.ErrBadRequest)
+	errRoomCommandInvalidPayload       = fmt.Errorf("%w: invalid payload", apperrors.ErrBadRequest)
+)
+
+const (
+	roomParticipantLeftReasonDisconnected = "DISCONNECTED"
+	roomParticipantLeftReasonKicked       = "KICKED"
 )
 
 type roomJoinPayload struct {
 	RoomID string `json:"roomId"`
 }
 
+type roomResumePayload struct {
+	LastSeq int64 `json:"lastSeq"`
+}
+
 type roomPresencePayload struct {
 	ParticipantID string                          `json:"participantId,omitempty"`
 	UserID        *string                         `json:"userId,omitempty"`
 	GuestName     *string                         `json:"guestName,omitempty"`
 	Role          roomsmodels.RoomParticipantRole `json:"role,omitempty"`
+	Reason        string                          `json:"reason,omitempty"`
+}
+
+type roomObserverSetPayload struct {
+	ParticipantID string `json:"participantId"`
+	Observer      bool   `json:"observer"`
+}
+
+type roomFacilitatorSetPayload struct {
+	ParticipantID string `json:"participantId"`
+	Facilitator   bool   `json:"facilitator"`
+}
+
+type roomAdminTransferPayload struct {
+	ParticipantID string `json:"participantId"`
+}
+
+type roomLockSetPayload struct {
+	Locked bool `json:"locked"`
+}
+
+type roomParticipantKickPayload struct {
+	ParticipantID string `json:"participantId"`
+}
+
+type roomParticipantKickedPayload struct {
+	ParticipantID          string                          `json:"participantId"`
+	UserID                 *string                         `json:"userId,omitempty"`
+	GuestName              *string                         `json:"guestName,omitempty"`
+	Role                   roomsmodels.RoomParticipantRole `json:"role"`
+	Reason                 string                          `json:"reason"`
+	TaskID                 string                          `json:"taskId,omitempty"`
+	RoundNumber            int                             `json:"roundNumber,omitempty"`
+	EligibleParticipantIDs []string                        `json:"eligibleParticipantIds,omitempty"`
+}
+
+type roomParticipantRoleChangedPayload struct {
+	ParticipantID          string                          `json:"participantId"`
+	UserID                 *string                         `json:"userId,omitempty"`
+	GuestName              *string                         `json:"guestName,omitempty"`
+	Role                   roomsmodels.RoomParticipantRole `json:"role"`
+	TaskID                 string                          `json:"taskId,omitempty"`
+	RoundNumber            int                             `json:"roundNumber,omitempty"`
+	EligibleParticipantIDs []string                        `json:"eligibleParticipantIds,omitempty"`
 }
 
 type roomSetCurrentTaskPayload struct {
@@ -89,15 +202,36 @@ type roomCurrentTaskChangedPayload struct {
 	EligibleParticipantIDs []string `json:"eligibleParticipantIds"`
 }
 
+// roomTaskReopenedPayload announces a re-estimate and carries the outcome it
+// replaced. It is followed by ROOMS_TASK_CURRENT_CHANGED for the new round.
+type roomTaskReopenedPayload struct {
+	TaskID                     string  `json:"taskId"`
+	PreviousStatus             string  `json:"previousStatus"`
+	PreviousFinalEstimateValue *string `json:"previousFinalEstimateValue,omitempty"`
+	RoundNumber                int     `json:"roundNumber"`
+}
+
+// roomVoteCastPayload names the dimension the vote is for in a
+// multi-criteria room; classic rooms leave it blank.
 type roomVoteCastPayload struct {
-	Value string `json:"value"`
+	Dimension string `json:"dimension"`
+	Value     string `json:"value"`
+}
+
+// roomVoteRetractPayload retracts the vote of one dimension, or every vote of
+// the participant when the dimension is blank.
+type roomVoteRetractPayload struct {
+	Dimension string `json:"dimension"`
 }
 
+// roomVoteStatusChangedPayload reports Voted once the participant has voted in
+// every dimension of the room.
 type roomVoteStatusChangedPayload struct {
-	TaskID        string `json:"taskId"`
-	ParticipantID string `json:"participantId"`
-	RoundNumber   int    `json:"roundNumber"`
-	Voted         bool   `json:"voted"`
+	TaskID          string   `json:"taskId"`
+	ParticipantID   string   `json:"participantId"`
+	RoundNumber     int      `json:"roundNumber"`
+	Voted           bool     `json:"voted"`
+	VotedDimensions []string `json:"votedDimensions,omitempty"`
 }
 
 type roomVotesAllCastPayload struct {
@@ -109,12 +243,20 @@ type roomVotesAllCastPayload struct {
 
 type roomRevealedVote struct {
 	ParticipantID string `json:"participantId"`
+	Dimension     string `json:"dimension,omitempty"`
 	Value         string `json:"value"`
 }
 
 type roomVoteSummary struct {
 	TotalVotes int            `json:"totalVotes"`
 	Counts     map[string]int `json:"counts"`
+	roomsmodels.VoteStats
+}
+
+type roomDimensionVoteSummary struct {
+	Key     string          `json:"key"`
+	Name    string          `json:"name"`
+	Summary roomVoteSummary `json:"summary"`
 }
 
 type roomVotesRevealedPayload struct {
@@ -122,8 +264,22 @@ type roomVotesRevealedPayload struct {
 	RoundNumber int                `json:"roundNumber"`
 	RoundStatus string             `json:"roundStatus"`
 	AllVoted    bool               `json:"allVoted"`
+	Trigger     string             `json:"trigger"`
+	Anonymous   bool               `json:"anonymous"`
 	Votes       []roomRevealedVote `json:"votes"`
 	Summary     roomVoteSummary    `json:"summary"`
+	// DimensionSummaries holds one summary per dimension of a
+	// multi-criteria room, whose Summary stays empty.
+	DimensionSummaries []roomDimensionVoteSummary `json:"dimensionSummaries,omitempty"`
+}
+
+// roomVotesRevealedVotersPayload pairs the votes of an anonymous round with
+// their participants. Only the room admin receives it, and only when the room
+// allows it.
+type roomVotesRevealedVotersPayload struct {
+	TaskID      string             `json:"taskId"`
+	RoundNumber int                `json:"roundNumber"`
+	Votes       []roomRevealedVote `json:"votes"`
 }
 
 type roomRoundChangedPayload struct {
@@ -133,23 +289,86 @@ type roomRoundChangedPayload struct {
 	EligibleParticipantIDs []string `json:"eligibleParticipantIds"`
 }
 
+// roomChatSendPayload posts to the task's thread when TaskID is set and to the
+// room channel otherwise.
+type roomChatSendPayload struct {
+	TaskID *string `json:"taskId"`
+	Body   string  `json:"body"`
+}
+
+type roomReactionSendPayload struct {
+	Emoji string `json:"emoji"`
+}
+
+type roomReactionPayload struct {
+	ParticipantID string `json:"participantId"`
+	Emoji         string `json:"emoji"`
+}
+
+type roomFlagSetPayload struct {
+	Flag   roomsmodels.RoomFlag `json:"flag"`
+	Raised bool                 `json:"raised"`
+}
+
+type roomFlagChangedPayload struct {
+	ParticipantID string               `json:"participantId"`
+	Flag          roomsmodels.RoomFlag `json:"flag"`
+	Raised        bool                 `json:"raised"`
+}
+
+type roomFlagsClearedPayload struct {
+	ClearedBy string `json:"clearedBy"`
+}
+
+// roomTaskFinalizePayload carries the overall estimate and, in a
+// multi-criteria room, one estimate per dimension key.
 type roomTaskFinalizePayload struct {
-	Value string `json:"value"`
+	Value      string            `json:"value"`
+	Dimensions map[string]string `json:"dimensions"`
 }
 
 type roomTaskFinalizedPayload struct {
-	TaskID             string `json:"taskId"`
-	FinalEstimateValue string `json:"finalEstimateValue"`
-	Status             string `json:"status"`
+	TaskID                  string            `json:"taskId"`
+	FinalEstimateValue      string            `json:"finalEstimateValue"`
+	FinalDimensionEstimates map[string]string `json:"finalDimensionEstimates,omitempty"`
+	Status                  string            `json:"status"`
+}
+
+// roomTaskSkipPayload names the task to skip; a blank task ID skips the
+// current task.
+type roomTaskSkipPayload struct {
+	TaskID string `json:"taskId"`
+}
+
+type roomTaskSkippedPayload struct {
+	TaskID string `json:"taskId"`
+	Status string `json:"status"`
+}
+
+// roomFinishedPayload is the final session summary. EstimatedTotal adds up
+// the numeric final estimates only.
+type roomFinishedPayload struct {
+	RoomID             string    `json:"roomId"`
+	Status             string    `json:"status"`
+	FinishedAt         time.Time `json:"finishedAt"`
+	DurationSeconds    int64     `json:"durationSeconds"`
+	TaskCount          int       `json:"taskCount"`
+	EstimatedTaskCount int       `json:"estimatedTaskCount"`
+	SkippedTaskCount   int       `json:"skippedTaskCount"`
+	EstimatedTotal     float64   `json:"estimatedTotal"`
 }
 
 type roomSnapshotRoom struct {
-	RoomID      string               `json:"roomId"`
-	Code        string               `json:"code"`
-	Name        string               `json:"name"`
-	Status      string               `json:"status"`
-	AdminUserID string               `json:"adminUserId"`
-	Deck        roomsmodels.RoomDeck `json:"deck"`
+	RoomID      string                     `json:"roomId"`
+	Code        string                     `json:"code"`
+	Name        string                     `json:"name"`
+	Status      string                     `json:"status"`
+	AdminUserID string                     `json:"adminUserId"`
+	Locked      bool                       `json:"locked"`
+	Mode        roomsmodels.RoomMode       `json:"mode"`
+	Deck        roomsmodels.RoomDeck       `json:"deck"`
+	Dimensions  roomsmodels.RoomDimensions `json:"dimensions,omitempty"`
+	Options     roomsmodels.RoomOptions    `json:"options"`
 }
 
 type roomSnapshotParticipant struct {
@@ -161,49 +380,127 @@ type roomSnapshotParticipant struct {
 }
 
 type roomSnapshotTask struct {
-	TaskID             string  `json:"taskId"`
-	Title              string  `json:"title"`
-	Description        *string `json:"description,omitempty"`
-	ExternalKey        *string `json:"externalKey,omitempty"`
-	Status             string  `json:"status"`
-	FinalEstimateValue *string `json:"finalEstimateValue,omitempty"`
+	TaskID                  string            `json:"taskId"`
+	Title                   string            `json:"title"`
+	Description             *string           `json:"description,omitempty"`
+	ExternalKey             *string           `json:"externalKey,omitempty"`
+	Status                  string            `json:"status"`
+	FinalEstimateValue      *string           `json:"finalEstimateValue,omitempty"`
+	FinalDimensionEstimates map[string]string `json:"finalDimensionEstimates,omitempty"`
+	Position                int               `json:"position"`
+}
+
+type roomTasksImportedPayload struct {
+	Tasks []roomSnapshotTask `json:"tasks"`
+}
+
+type roomTasksReorderedPayload struct {
+	TaskIDs []string `json:"taskIds"`
 }
 
 type roomSnapshotPayload struct {
-	Room                   roomSnapshotRoom          `json:"room"`
-	Participants           []roomSnapshotParticipant `json:"participants"`
-	Tasks                  []roomSnapshotTask        `json:"tasks"`
-	CurrentTaskID          *string                   `json:"currentTaskId,omitempty"`
-	CurrentRoundNumber     int                       `json:"currentRoundNumber"`
-	RoundStatus            string                    `json:"roundStatus"`
-	EligibleParticipantIDs []string                  `json:"eligibleParticipantIds"`
-	VotedParticipantIDs    []string                  `json:"votedParticipantIds"`
-	RevealedVotes          []roomRevealedVote        `json:"revealedVotes,omitempty"`
-	Summary                *roomVoteSummary          `json:"summary,omitempty"`
-}
-
-func (g *roomsGateway) handleRoomJoin(client ws.ClientInfo, event ws.Event) {
+	Room                   roomSnapshotRoom                   `json:"room"`
+	Participants           []roomSnapshotParticipant          `json:"participants"`
+	Tasks                  []roomSnapshotTask                 `json:"tasks"`
+	CurrentTaskID          *string                            `json:"currentTaskId,omitempty"`
+	CurrentRoundNumber     int                                `json:"currentRoundNumber"`
+	RoundStatus            string                             `json:"roundStatus"`
+	EligibleParticipantIDs []string                           `json:"eligibleParticipantIds"`
+	VotedParticipantIDs    []string                           `json:"votedParticipantIds"`
+	RevealedVotes          []roomRevealedVote                 `json:"revealedVotes,omitempty"`
+	Summary                *roomVoteSummary                   `json:"summary,omitempty"`
+	DimensionSummaries     []roomDimensionVoteSummary         `json:"dimensionSummaries,omitempty"`
+	Timer                  *roomTimerStartedPayload           `json:"timer,omitempty"`
+	ChatMessages           []roomsdto.RoomChatMessageResponse `json:"chatMessages"`
+	Flags                  []RoomRaisedFlag                   `json:"flags"`
+}
+
+func (g *roomsGateway) handleRoomJoin(client ws.ClientInfo, event ws.Event) error {
 	roomID := resolveRoomID(event)
 	if roomID == "" {
 		logger.L().Warn(roomsGatewayLog("Room join ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
+		return errRoomCommandMissingRoomID
+	}
+
+	if err := g.enterRoom(client, roomID); err != nil {
+		return err
+	}
+
+	if err := g.sendRoomSnapshot(client, roomID); err != nil {
+		return err
+	}
+
+	logger.L().Info(roomsGatewayLog("Room join accepted"), "room_id", roomID, "user_id", client.UserID, "conn_id", client.ConnID)
+
+	return nil
+}
+
+// handleRoomResume joins the room like ROOMS_JOIN but replays the room events
+// numbered after lastSeq instead of sending a snapshot. When they can no
+// longer all be replayed, the client gets a snapshot after all.
+func (g *roomsGateway) handleRoomResume(client ws.ClientInfo, event ws.Event) error {
+	roomID := resolveRoomID(event)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Room resume ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomResumePayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Room resume ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	if err := g.enterRoom(client, roomID); err != nil {
+		return err
+	}
+
+	missed, ok := g.wsService.RoomEventsSince(roomID, payload.LastSeq)
+	if !ok {
+		if err := g.sendRoomSnapshot(client, roomID); err != nil {
+			return err
+		}
+		logger.L().Info(roomsGatewayLog("Room resume fell back to snapshot"), "room_id", roomID, "last_seq", payload.LastSeq, "user_id", client.UserID, "conn_id", client.ConnID)
+		return nil
+	}
+
+	for _, missedEvent := range missed {
+		if err := g.wsService.SendToConnection(client.ConnID, missedEvent); err != nil {
+			logger.L().Error(roomsGatewayLog("Failed to replay room event"), "err", err, "room_id", roomID, "seq", missedEvent.Seq, "conn_id", client.ConnID)
+			return err
+		}
 	}
 
+	logger.L().Info(roomsGatewayLog("Room resume accepted"), "room_id", roomID, "last_seq", payload.LastSeq, "replayed", len(missed), "user_id", client.UserID, "conn_id", client.ConnID)
+
+	return nil
+}
+
+// enterRoom binds the connection to its participant and the room, and
+// announces the participant when this is their first connection in it.
+func (g *roomsGateway) enterRoom(client ws.ClientInfo, roomID string) error {
 	participant, err := g.resolveParticipant(client, roomID)
 	if err != nil {
 		logJoinDenied(client, roomID, err)
-		return
+		return err
+	}
+
+	if err := g.ensureJoinAllowed(roomID, participant); err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
 	}
 
 	if err := g.wsService.SetParticipantID(client.ConnID, participant.RoomParticipantID); err != nil {
 		logger.L().Error(roomsGatewayLog("Failed to bind WS participant"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-		return
+		return err
 	}
 
 	joinResult, err := g.wsService.JoinRoom(client.ConnID, roomID)
 	if err != nil {
 		logger.L().Error(roomsGatewayLog("Room join failed"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-		return
+		return err
 	}
 
 	g.expiryService.TouchActivity(roomID)
@@ -219,65 +516,74 @@ func (g *roomsGateway) handleRoomJoin(client ws.ClientInfo, event ws.Event) {
 		}
 	}
 
-	snapshot, err := g.buildSnapshot(roomID)
+	return nil
+}
+
+// sendRoomSnapshot reads the room sequence number before building the
+// snapshot, so an event racing the snapshot is replayed on the next resume
+// rather than lost.
+func (g *roomsGateway) sendRoomSnapshot(client ws.ClientInfo, roomID string) error {
+	seq := g.wsService.RoomSeq(roomID)
+
+	snapshot, err := g.buildSnapshot(roomID, client.UserID)
 	if err != nil {
 		logger.L().Error(roomsGatewayLog("Failed to build room snapshot"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-		return
+		return err
 	}
 
-	if err := g.sendSnapshot(client.ConnID, roomID, snapshot); err != nil {
+	if err := g.sendSnapshot(client.ConnID, roomID, seq, snapshot); err != nil {
 		logger.L().Error(roomsGatewayLog("Failed to send room snapshot"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-		return
+		return err
 	}
 
-	logger.L().Info(roomsGatewayLog("Room join accepted"), "room_id", roomID, "user_id", client.UserID, "conn_id", client.ConnID)
+	return nil
 }
 
-func (g *roomsGateway) handleTaskSetCurrent(client ws.ClientInfo, event ws.Event) {
+func (g *roomsGateway) handleTaskSetCurrent(client ws.ClientInfo, event ws.Event) error {
 	roomID := strings.TrimSpace(event.RoomID)
 	if roomID == "" {
 		logger.L().Warn(roomsGatewayLog("Task set current ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
+		return errRoomCommandMissingRoomID
 	}
 
 	payload := roomSetCurrentTaskPayload{}
 	if len(event.Payload) > 0 {
 		if err := json.Unmarshal(event.Payload, &payload); err != nil {
 			logger.L().Warn(roomsGatewayLog("Task set current ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-			return
+			return errRoomCommandInvalidPayload
 		}
 	}
 
 	taskID := strings.TrimSpace(payload.TaskID)
 	if taskID == "" {
 		logger.L().Warn(roomsGatewayLog("Task set current ignored: missing task ID"), "room_id", roomID, "conn_id", client.ConnID)
-		return
+		return errRoomCommandMissingTaskID
 	}
 
 	participant, err := g.resolveParticipant(client, roomID)
 	if err != nil {
 		logJoinDenied(client, roomID, err)
-		return
+		return err
 	}
-	if participant.Role != roomsmodels.RoomParticipantRoleAdmin {
-		logger.L().Warn(roomsGatewayLog("Task set current denied: admin only"), "room_id", roomID, "conn_id", client.ConnID)
-		return
+	if !participant.Role.CanFacilitate() {
+		logger.L().Warn(roomsGatewayLog("Task set current denied: facilitator only"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: only a facilitator can change the current task", apperrors.ErrForbidden)
 	}
 
 	eligibleParticipantIDs, err := g.currentEligibleParticipantIDs(roomID)
 	if err != nil {
 		logger.L().Error(roomsGatewayLog("Task set current failed: eligible participants lookup failed"), "room_id", roomID, "task_id", taskID, "err", err)
-		return
+		return err
 	}
 
 	currentTask, previousTask, roundState, err := g.voteService.SetCurrentTask(roomID, taskID, client.UserID, eligibleParticipantIDs)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrForbidden) || errors.Is(err, apperrors.ErrBadRequest) {
 			logger.L().Warn(roomsGatewayLog("Task set current denied"), "room_id", roomID, "task_id", taskID, "reason", err.Error())
-			return
+			return err
 		}
 		logger.L().Error(roomsGatewayLog("Task set current failed"), "room_id", roomID, "task_id", taskID, "err", err)
-		return
+		return err
 	}
 
 	var previousTaskID *string
@@ -286,202 +592,862 @@ func (g *roomsGateway) handleTaskSetCurrent(client ws.ClientInfo, event ws.Event
 		previousTaskID = &id
 	}
 
-	if err := g.broadcastCurrentTaskChanged(roomID, roomCurrentTaskChangedPayload{
-		CurrentTaskID:          currentTask.TaskID,
-		PreviousTaskID:         previousTaskID,
-		RoundNumber:            roundState.RoundNumber,
-		RoundStatus:            string(roundState.Status),
-		EligibleParticipantIDs: append([]string(nil), roundState.EligibleParticipantIDs...),
+	if err := g.broadcastCurrentTaskChanged(roomID, roomCurrentTaskChangedPayload{
+		CurrentTaskID:          currentTask.TaskID,
+		PreviousTaskID:         previousTaskID,
+		RoundNumber:            roundState.RoundNumber,
+		RoundStatus:            string(roundState.Status),
+		EligibleParticipantIDs: append([]string(nil), roundState.EligibleParticipantIDs...),
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast current task changed"), "room_id", roomID, "task_id", taskID, "err", err)
+		return nil
+	}
+
+	logger.L().Info(roomsGatewayLog("Task current changed"), "room_id", roomID, "task_id", currentTask.TaskID, "conn_id", client.ConnID)
+
+	return nil
+}
+
+func (g *roomsGateway) handleTaskReestimate(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Task reestimate ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomSetCurrentTaskPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Task reestimate ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	taskID := strings.TrimSpace(payload.TaskID)
+	if taskID == "" {
+		logger.L().Warn(roomsGatewayLog("Task reestimate ignored: missing task ID"), "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandMissingTaskID
+	}
+
+	eligibleParticipantIDs, err := g.currentEligibleParticipantIDs(roomID)
+	if err != nil {
+		logger.L().Error(roomsGatewayLog("Task reestimate failed: eligible participants lookup failed"), "room_id", roomID, "task_id", taskID, "err", err)
+		return err
+	}
+
+	result, err := g.voteService.ReopenTask(roomID, taskID, client.UserID, eligibleParticipantIDs)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrForbidden) || errors.Is(err, apperrors.ErrBadRequest) {
+			logger.L().Warn(roomsGatewayLog("Task reestimate denied"), "room_id", roomID, "task_id", taskID, "reason", err.Error())
+			return err
+		}
+		logger.L().Error(roomsGatewayLog("Task reestimate failed"), "room_id", roomID, "task_id", taskID, "err", err)
+		return err
+	}
+
+	if err := g.broadcastTaskReopened(roomID, roomTaskReopenedPayload{
+		TaskID:                     result.Task.TaskID,
+		PreviousStatus:             result.PreviousStatus,
+		PreviousFinalEstimateValue: result.PreviousFinalEstimateValue,
+		RoundNumber:                result.Round.RoundNumber,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast task reopened"), "room_id", roomID, "task_id", taskID, "err", err)
+	}
+
+	var previousTaskID *string
+	if result.PreviousTask != nil && strings.TrimSpace(result.PreviousTask.TaskID) != "" {
+		id := result.PreviousTask.TaskID
+		previousTaskID = &id
+	}
+
+	if err := g.broadcastCurrentTaskChanged(roomID, roomCurrentTaskChangedPayload{
+		CurrentTaskID:          result.Task.TaskID,
+		PreviousTaskID:         previousTaskID,
+		RoundNumber:            result.Round.RoundNumber,
+		RoundStatus:            string(result.Round.Status),
+		EligibleParticipantIDs: append([]string(nil), result.Round.EligibleParticipantIDs...),
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast current task changed"), "room_id", roomID, "task_id", taskID, "err", err)
+	}
+
+	logger.L().Info(roomsGatewayLog("Task reopened"), "room_id", roomID, "task_id", result.Task.TaskID, "round", result.Round.RoundNumber, "conn_id", client.ConnID)
+
+	return nil
+}
+
+func (g *roomsGateway) handleVoteCast(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Vote cast ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomVoteCastPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Vote cast ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	voteValue := strings.TrimSpace(payload.Value)
+	if voteValue == "" {
+		logger.L().Warn(roomsGatewayLog("Vote cast ignored: empty vote value"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: vote value is required", apperrors.ErrBadRequest)
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+
+	result, err := g.voteService.CastVote(roomID, participant, payload.Dimension, voteValue)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Vote cast denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Vote cast failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastVoteStatusChanged(roomID, roomVoteStatusChangedPayload{
+		TaskID:          result.Task.TaskID,
+		ParticipantID:   participant.RoomParticipantID,
+		RoundNumber:     result.Round.RoundNumber,
+		Voted:           containsParticipantID(result.VotedParticipantIDs, participant.RoomParticipantID),
+		VotedDimensions: result.VotedDimensions,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast vote status changed"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+	}
+
+	if result.AllVotesCast {
+		if err := g.broadcastVotesAllCast(roomID, roomVotesAllCastPayload{
+			TaskID:                 result.Task.TaskID,
+			RoundNumber:            result.Round.RoundNumber,
+			EligibleParticipantIDs: append([]string(nil), result.EligibleParticipantIDs...),
+			VotedParticipantIDs:    append([]string(nil), result.VotedParticipantIDs...),
+		}); err != nil {
+			logger.L().Error(roomsGatewayLog("Failed to broadcast votes all cast"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+		}
+
+		if result.AutoReveal {
+			g.autoReveal(roomID, result.Task.TaskID, result.Round.RoundNumber)
+		}
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleVoteRetract(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Vote retract ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomVoteRetractPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Vote retract ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+
+	result, err := g.voteService.RetractVote(roomID, participant, payload.Dimension)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Vote retract denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Vote retract failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastVoteStatusChanged(roomID, roomVoteStatusChangedPayload{
+		TaskID:          result.Task.TaskID,
+		ParticipantID:   participant.RoomParticipantID,
+		RoundNumber:     result.Round.RoundNumber,
+		Voted:           false,
+		VotedDimensions: result.VotedDimensions,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast vote status changed"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleChatSend(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Chat send ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomChatSendPayload{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		logger.L().Warn(roomsGatewayLog("Chat send ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandInvalidPayload
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+
+	message, err := g.chatService.SendMessage(roomID, participant, payload.TaskID, payload.Body)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Chat send denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Chat send failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastChatMessage(roomID, roomsdto.NewRoomChatMessageResponse(message)); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast chat message"), "room_id", roomID, "message_id", message.ChatMessageID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleReactionSend(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Reaction ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomReactionSendPayload{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		logger.L().Warn(roomsGatewayLog("Reaction ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandInvalidPayload
+	}
+
+	emoji := strings.TrimSpace(payload.Emoji)
+	if !roomsmodels.IsRoomReaction(emoji) {
+		logger.L().Warn(roomsGatewayLog("Reaction ignored: unknown emoji"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: unknown reaction", apperrors.ErrBadRequest)
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+
+	if err := g.broadcastReaction(roomID, roomReactionPayload{
+		ParticipantID: participant.RoomParticipantID,
+		Emoji:         emoji,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast reaction"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleFlagSet(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Flag set ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomFlagSetPayload{}
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		logger.L().Warn(roomsGatewayLog("Flag set ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandInvalidPayload
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+
+	changed, err := g.signalService.SetFlag(roomID, participant.RoomParticipantID, payload.Flag, payload.Raised)
+	if err != nil {
+		logger.L().Warn(roomsGatewayLog("Flag set denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := g.broadcastFlagChanged(roomID, roomFlagChangedPayload{
+		ParticipantID: participant.RoomParticipantID,
+		Flag:          payload.Flag,
+		Raised:        payload.Raised,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast flag changed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleFlagsClear(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Flags clear ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+	if !participant.Role.CanFacilitate() {
+		logger.L().Warn(roomsGatewayLog("Flags clear denied: facilitator only"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: only a facilitator can clear flags", apperrors.ErrForbidden)
+	}
+
+	if err := g.signalService.ClearRoomFlags(roomID); err != nil {
+		logger.L().Error(roomsGatewayLog("Flags clear failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		return err
+	}
+
+	if err := g.broadcastFlagsCleared(roomID, roomFlagsClearedPayload{
+		ClearedBy: participant.RoomParticipantID,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast flags cleared"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) autoReveal(roomID, taskID string, roundNumber int) {
+	result, err := g.voteService.AutoRevealRound(roomID, taskID, roundNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Debug(roomsGatewayLog("Auto reveal skipped"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Auto reveal failed"), "room_id", roomID, "task_id", taskID, "round", roundNumber, "err", err)
+		}
+		return
+	}
+
+	g.broadcastRevealResult(roomID, result, roomRevealTriggerAllVoted)
+}
+
+func (g *roomsGateway) handleTimerExpired(timer RoomRoundTimer) {
+	result, err := g.voteService.RevealExpiredRound(timer.RoomID, timer.TaskID, timer.RoundNumber)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrConflict), errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden):
+			logger.L().Debug(roomsGatewayLog("Timer reveal skipped"), "room_id", timer.RoomID, "task_id", timer.TaskID, "round", timer.RoundNumber, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Timer reveal failed"), "room_id", timer.RoomID, "task_id", timer.TaskID, "round", timer.RoundNumber, "err", err)
+		}
+		return
+	}
+
+	if err := g.broadcastTimerExpired(timer.RoomID, roomTimerStoppedPayload{
+		TaskID:      timer.TaskID,
+		RoundNumber: timer.RoundNumber,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast timer expired"), "room_id", timer.RoomID, "task_id", timer.TaskID, "err", err)
+	}
+
+	g.broadcastRevealResult(timer.RoomID, result, roomRevealTriggerTimer)
+}
+
+func (g *roomsGateway) handleVoteReveal(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Vote reveal ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+	if !participant.Role.CanFacilitate() {
+		logger.L().Warn(roomsGatewayLog("Vote reveal denied: facilitator only"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: only a facilitator can reveal votes", apperrors.ErrForbidden)
+	}
+
+	result, err := g.voteService.RevealCurrentRound(roomID, client.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest), errors.Is(err, apperrors.ErrConflict):
+			logger.L().Warn(roomsGatewayLog("Vote reveal denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Vote reveal failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	g.broadcastRevealResult(roomID, result, roomRevealTriggerAdmin)
+
+	return nil
+}
+
+func (g *roomsGateway) broadcastRevealResult(roomID string, result *RevealVotesResult, trigger string) {
+	if err := g.broadcastVotesRevealed(roomID, newRoomVotesRevealedPayload(result, trigger)); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast votes revealed"), "room_id", roomID, "task_id", result.Task.TaskID, "round", result.Round.RoundNumber, "err", err)
+	}
+	if err := sendRevealedVotersToAdmin(g.wsService, roomID, result); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to send revealed voters to admin"), "room_id", roomID, "task_id", result.Task.TaskID, "round", result.Round.RoundNumber, "err", err)
+	}
+}
+
+// newRoomVotesRevealedPayload leaves out who voted what in an anonymous room;
+// the summary still carries the value distribution.
+func newRoomVotesRevealedPayload(result *RevealVotesResult, trigger string) roomVotesRevealedPayload {
+	votes := make([]roomRevealedVote, 0)
+	if result.Options.ShowsVoters(false) {
+		votes = mapVotes(result.Votes)
+	}
+
+	return roomVotesRevealedPayload{
+		TaskID:             result.Task.TaskID,
+		RoundNumber:        result.Round.RoundNumber,
+		RoundStatus:        string(result.Round.Status),
+		AllVoted:           result.AllVoted,
+		Trigger:            trigger,
+		Anonymous:          result.Options.AnonymousVoting,
+		Votes:              votes,
+		Summary:            buildVoteSummary(result.Deck, votesInDimension(result.Votes, "")),
+		DimensionSummaries: buildDimensionVoteSummaries(result.Dimensions, result.Votes),
+	}
+}
+
+func sendRevealedVotersToAdmin(wsService *ws.Service, roomID string, result *RevealVotesResult) error {
+	if wsService == nil || result.Options.ShowsVoters(false) || !result.Options.ShowsVoters(true) {
+		return nil
+	}
+
+	data, err := json.Marshal(roomVotesRevealedVotersPayload{
+		TaskID:      result.Task.TaskID,
+		RoundNumber: result.Round.RoundNumber,
+		Votes:       mapVotes(result.Votes),
+	})
+	if err != nil {
+		return err
+	}
+
+	return wsService.SendToRoomUser(roomID, result.AdminUserID, ws.Event{
+		Type:    RoomsVotesRevealedVoters,
+		Payload: data,
+	})
+}
+
+func (g *roomsGateway) handleRoundNext(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Round next ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	participant, err := g.resolveParticipant(client, roomID)
+	if err != nil {
+		logJoinDenied(client, roomID, err)
+		return err
+	}
+	if !participant.Role.CanFacilitate() {
+		logger.L().Warn(roomsGatewayLog("Round next denied: facilitator only"), "room_id", roomID, "conn_id", client.ConnID)
+		return fmt.Errorf("%w: only a facilitator can start the next round", apperrors.ErrForbidden)
+	}
+
+	eligibleParticipantIDs, err := g.currentEligibleParticipantIDs(roomID)
+	if err != nil {
+		logger.L().Error(roomsGatewayLog("Round next failed: eligible participants lookup failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		return err
+	}
+
+	currentTask, nextRound, err := g.voteService.StartNextRound(roomID, client.UserID, eligibleParticipantIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Round next denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Round next failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastRoundChanged(roomID, roomRoundChangedPayload{
+		TaskID:                 currentTask.TaskID,
+		RoundNumber:            nextRound.RoundNumber,
+		RoundStatus:            string(nextRound.Status),
+		EligibleParticipantIDs: append([]string(nil), nextRound.EligibleParticipantIDs...),
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast round changed"), "room_id", roomID, "task_id", currentTask.TaskID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleTaskFinalize(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Task finalize ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomTaskFinalizePayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Task finalize ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	updatedTask, err := g.voteService.FinalizeCurrentTask(roomID, client.UserID, payload.Value, payload.Dimensions)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Task finalize denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Task finalize failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastTaskFinalized(roomID, roomTaskFinalizedPayload{
+		TaskID:                  updatedTask.TaskID,
+		FinalEstimateValue:      strings.TrimSpace(*updatedTask.FinalEstimateValue),
+		FinalDimensionEstimates: updatedTask.FinalDimensionEstimates,
+		Status:                  updatedTask.Status,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast task finalized"), "room_id", roomID, "task_id", updatedTask.TaskID, "err", err)
+	}
+
+	return nil
+}
+
+func (g *roomsGateway) handleTaskSkip(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Task skip ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomTaskSkipPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Task skip ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	var (
+		updatedTask *roomsmodels.RoomTaskModel
+		err         error
+	)
+	if taskID := strings.TrimSpace(payload.TaskID); taskID != "" {
+		updatedTask, err = g.voteService.SkipTask(roomID, taskID, client.UserID)
+	} else {
+		updatedTask, err = g.voteService.SkipCurrentTask(roomID, client.UserID)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Task skip denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Task skip failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	if err := g.broadcastTaskSkipped(roomID, roomTaskSkippedPayload{
+		TaskID: updatedTask.TaskID,
+		Status: updatedTask.Status,
+	}); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast task skipped"), "room_id", roomID, "task_id", updatedTask.TaskID, "err", err)
+	}
+
+	return nil
+}
+
+// handleRoomFinish only finishes the room; the summary is broadcast by
+// handleRoomFinished, which also covers rooms finished over REST.
+func (g *roomsGateway) handleRoomFinish(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Room finish ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	if _, err := g.roomsService.FinishRoom(roomID, client.UserID); err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Room finish denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Room finish failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// handleRoomExpired drops what an expired room no longer needs; the expiry
+// itself is broadcast by the expiry service.
+func (g *roomsGateway) handleRoomExpired(room *roomsmodels.RoomsModel) {
+	g.dropRoomFlags(room)
+}
+
+// dropRoomFlags lowers the flags of a room that reached a terminal status.
+func (g *roomsGateway) dropRoomFlags(room *roomsmodels.RoomsModel) {
+	if err := g.signalService.ClearRoomFlags(room.RoomID); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to drop room flags"), "room_id", room.RoomID, "err", err)
+	}
+}
+
+func (g *roomsGateway) handleRoomFinished(room *roomsmodels.RoomsModel) {
+	g.dropRoomFlags(room)
+
+	tasks, err := g.taskRepo.FindByRoomID(room.RoomID)
+	if err != nil {
+		logger.L().Error(roomsGatewayLog("Room summary failed: tasks lookup failed"), "room_id", room.RoomID, "err", err)
+		return
+	}
+
+	finishedAt := room.LastActivityAt
+	if room.FinishedAt != nil {
+		finishedAt = *room.FinishedAt
+	}
+
+	payload := roomFinishedPayload{
+		RoomID:          room.RoomID,
+		Status:          room.Status,
+		FinishedAt:      finishedAt,
+		DurationSeconds: int64(finishedAt.Sub(room.CreatedAt).Seconds()),
+		TaskCount:       len(tasks),
+	}
+	for _, task := range tasks {
+		switch task.Status {
+		case "ESTIMATED":
+			payload.EstimatedTaskCount++
+			if task.FinalEstimateValue != nil {
+				if value, ok := roomsmodels.NumericDeckValue(*task.FinalEstimateValue); ok {
+					payload.EstimatedTotal += value
+				}
+			}
+		case "SKIPPED":
+			payload.SkippedTaskCount++
+		}
+	}
+
+	if err := g.broadcastRoomFinished(room.RoomID, payload); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast room finished"), "room_id", room.RoomID, "err", err)
+	}
+}
+
+func (g *roomsGateway) handleObserverSet(client ws.ClientInfo, event ws.Event) error {
+	roomID := strings.TrimSpace(event.RoomID)
+	if roomID == "" {
+		logger.L().Warn(roomsGatewayLog("Observer set ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
+	}
+
+	payload := roomObserverSetPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Observer set ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
+	}
+
+	participantID := strings.TrimSpace(payload.ParticipantID)
+	if participantID == "" {
+		logger.L().Warn(roomsGatewayLog("Observer set ignored: missing participant ID"), "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandMissingParticipantID
+	}
+
+	result, err := g.voteService.SetParticipantObserver(roomID, client.UserID, participantID, payload.Observer)
+	if err != nil {
+		switch {
+		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
+			logger.L().Warn(roomsGatewayLog("Observer set denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+		default:
+			logger.L().Error(roomsGatewayLog("Observer set failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+		}
+		return err
+	}
+
+	changed := roomParticipantRoleChangedPayload{
+		ParticipantID: result.Participant.RoomParticipantID,
+		UserID:        result.Participant.UserID,
+		GuestName:     result.Participant.GuestName,
+		Role:          result.Participant.Role,
+	}
+	if result.Round != nil {
+		changed.TaskID = result.Task.TaskID
+		changed.RoundNumber = result.Round.RoundNumber
+		changed.EligibleParticipantIDs = append([]string{}, result.Round.EligibleParticipantIDs...)
+	}
+
+	if err := g.broadcastParticipantRoleChanged(roomID, changed); err != nil {
+		logger.L().Error(roomsGatewayLog("Failed to broadcast participant role changed"), "room_id", roomID, "participant_id", participantID, "err", err)
+		return nil
+	}
+
+	if !result.AllVotesCast {
+		return nil
+	}
+
+	if err := g.broadcastVotesAllCast(roomID, roomVotesAllCastPayload{
+		TaskID:                 result.Task.TaskID,
+		RoundNumber:            result.Round.RoundNumber,
+		EligibleParticipantIDs: append([]string(nil), result.Round.EligibleParticipantIDs...),
+		VotedParticipantIDs:    append([]string(nil), result.VotedParticipantIDs...),
 	}); err != nil {
-		logger.L().Error(roomsGatewayLog("Failed to broadcast current task changed"), "room_id", roomID, "task_id", taskID, "err", err)
-		return
+		logger.L().Error(roomsGatewayLog("Failed to broadcast votes all cast"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
 	}
 
-	logger.L().Info(roomsGatewayLog("Task current changed"), "room_id", roomID, "task_id", currentTask.TaskID, "conn_id", client.ConnID)
+	if result.AutoReveal {
+		g.autoReveal(roomID, result.Task.TaskID, result.Round.RoundNumber)
+	}
+
+	return nil
 }
 
-func (g *roomsGateway) handleVoteCast(client ws.ClientInfo, event ws.Event) {
+func (g *roomsGateway) handleFacilitatorSet(client ws.ClientInfo, event ws.Event) error {
 	roomID := strings.TrimSpace(event.RoomID)
 	if roomID == "" {
-		logger.L().Warn(roomsGatewayLog("Vote cast ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
+		logger.L().Warn(roomsGatewayLog("Facilitator set ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
 	}
 
-	payload := roomVoteCastPayload{}
+	payload := roomFacilitatorSetPayload{}
 	if len(event.Payload) > 0 {
 		if err := json.Unmarshal(event.Payload, &payload); err != nil {
-			logger.L().Warn(roomsGatewayLog("Vote cast ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-			return
+			logger.L().Warn(roomsGatewayLog("Facilitator set ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
 		}
 	}
 
-	voteValue := strings.TrimSpace(payload.Value)
-	if voteValue == "" {
-		logger.L().Warn(roomsGatewayLog("Vote cast ignored: empty vote value"), "room_id", roomID, "conn_id", client.ConnID)
-		return
-	}
-
-	participant, err := g.resolveParticipant(client, roomID)
-	if err != nil {
-		logJoinDenied(client, roomID, err)
-		return
+	participantID := strings.TrimSpace(payload.ParticipantID)
+	if participantID == "" {
+		logger.L().Warn(roomsGatewayLog("Facilitator set ignored: missing participant ID"), "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandMissingParticipantID
 	}
 
-	result, err := g.voteService.CastVote(roomID, participant, voteValue)
-	if err != nil {
+	if _, err := g.adminService.SetFacilitator(roomID, client.UserID, participantID, payload.Facilitator); err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
-			logger.L().Warn(roomsGatewayLog("Vote cast denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+			logger.L().Warn(roomsGatewayLog("Facilitator set denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
 		default:
-			logger.L().Error(roomsGatewayLog("Vote cast failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+			logger.L().Error(roomsGatewayLog("Facilitator set failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
 		}
-		return
-	}
-
-	if err := g.broadcastVoteStatusChanged(roomID, roomVoteStatusChangedPayload{
-		TaskID:        result.Task.TaskID,
-		ParticipantID: participant.RoomParticipantID,
-		RoundNumber:   result.Round.RoundNumber,
-		Voted:         true,
-	}); err != nil {
-		logger.L().Error(roomsGatewayLog("Failed to broadcast vote status changed"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
-		return
+		return err
 	}
 
-	if result.AllVotesCast {
-		if err := g.broadcastVotesAllCast(roomID, roomVotesAllCastPayload{
-			TaskID:                 result.Task.TaskID,
-			RoundNumber:            result.Round.RoundNumber,
-			EligibleParticipantIDs: append([]string(nil), result.EligibleParticipantIDs...),
-			VotedParticipantIDs:    append([]string(nil), result.VotedParticipantIDs...),
-		}); err != nil {
-			logger.L().Error(roomsGatewayLog("Failed to broadcast votes all cast"), "room_id", roomID, "task_id", result.Task.TaskID, "err", err)
-		}
-	}
+	return nil
 }
 
-func (g *roomsGateway) handleVoteReveal(client ws.ClientInfo, event ws.Event) {
+func (g *roomsGateway) handleAdminTransfer(client ws.ClientInfo, event ws.Event) error {
 	roomID := strings.TrimSpace(event.RoomID)
 	if roomID == "" {
-		logger.L().Warn(roomsGatewayLog("Vote reveal ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
+		logger.L().Warn(roomsGatewayLog("Admin transfer ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
 	}
 
-	participant, err := g.resolveParticipant(client, roomID)
-	if err != nil {
-		logJoinDenied(client, roomID, err)
-		return
+	payload := roomAdminTransferPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Admin transfer ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
 	}
-	if participant.Role != roomsmodels.RoomParticipantRoleAdmin {
-		logger.L().Warn(roomsGatewayLog("Vote reveal denied: admin only"), "room_id", roomID, "conn_id", client.ConnID)
-		return
+
+	participantID := strings.TrimSpace(payload.ParticipantID)
+	if participantID == "" {
+		logger.L().Warn(roomsGatewayLog("Admin transfer ignored: missing participant ID"), "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandMissingParticipantID
 	}
 
-	result, err := g.voteService.RevealCurrentRound(roomID, client.UserID)
-	if err != nil {
+	if _, err := g.adminService.TransferAdmin(roomID, client.UserID, participantID); err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
-			logger.L().Warn(roomsGatewayLog("Vote reveal denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+			logger.L().Warn(roomsGatewayLog("Admin transfer denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
 		default:
-			logger.L().Error(roomsGatewayLog("Vote reveal failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+			logger.L().Error(roomsGatewayLog("Admin transfer failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
 		}
-		return
-	}
-
-	revealedVotes := mapVotes(result.Votes)
-	summary := buildVoteSummary(result.Votes)
-	payload := roomVotesRevealedPayload{
-		TaskID:      result.Task.TaskID,
-		RoundNumber: result.Round.RoundNumber,
-		RoundStatus: string(result.Round.Status),
-		AllVoted:    result.AllVoted,
-		Votes:       revealedVotes,
-		Summary:     summary,
+		return err
 	}
 
-	if err := g.broadcastVotesRevealed(roomID, payload); err != nil {
-		logger.L().Error(roomsGatewayLog("Failed to broadcast votes revealed"), "room_id", roomID, "task_id", result.Task.TaskID, "round", result.Round.RoundNumber, "err", err)
-		return
-	}
+	return nil
 }
 
-func (g *roomsGateway) handleRoundNext(client ws.ClientInfo, event ws.Event) {
+func (g *roomsGateway) handleLockSet(client ws.ClientInfo, event ws.Event) error {
 	roomID := strings.TrimSpace(event.RoomID)
 	if roomID == "" {
-		logger.L().Warn(roomsGatewayLog("Round next ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
-	}
-
-	participant, err := g.resolveParticipant(client, roomID)
-	if err != nil {
-		logJoinDenied(client, roomID, err)
-		return
-	}
-	if participant.Role != roomsmodels.RoomParticipantRoleAdmin {
-		logger.L().Warn(roomsGatewayLog("Round next denied: admin only"), "room_id", roomID, "conn_id", client.ConnID)
-		return
+		logger.L().Warn(roomsGatewayLog("Lock set ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
 	}
 
-	eligibleParticipantIDs, err := g.currentEligibleParticipantIDs(roomID)
-	if err != nil {
-		logger.L().Error(roomsGatewayLog("Round next failed: eligible participants lookup failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
-		return
+	payload := roomLockSetPayload{}
+	if len(event.Payload) > 0 {
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			logger.L().Warn(roomsGatewayLog("Lock set ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
+		}
 	}
 
-	currentTask, nextRound, err := g.voteService.StartNextRound(roomID, client.UserID, eligibleParticipantIDs)
-	if err != nil {
+	if _, err := g.adminService.SetLocked(roomID, client.UserID, payload.Locked); err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
-			logger.L().Warn(roomsGatewayLog("Round next denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+			logger.L().Warn(roomsGatewayLog("Lock set denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
 		default:
-			logger.L().Error(roomsGatewayLog("Round next failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+			logger.L().Error(roomsGatewayLog("Lock set failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
 		}
-		return
+		return err
 	}
 
-	if err := g.broadcastRoundChanged(roomID, roomRoundChangedPayload{
-		TaskID:                 currentTask.TaskID,
-		RoundNumber:            nextRound.RoundNumber,
-		RoundStatus:            string(nextRound.Status),
-		EligibleParticipantIDs: append([]string(nil), nextRound.EligibleParticipantIDs...),
-	}); err != nil {
-		logger.L().Error(roomsGatewayLog("Failed to broadcast round changed"), "room_id", roomID, "task_id", currentTask.TaskID, "err", err)
-	}
+	return nil
 }
 
-func (g *roomsGateway) handleTaskFinalize(client ws.ClientInfo, event ws.Event) {
+func (g *roomsGateway) handleParticipantKick(client ws.ClientInfo, event ws.Event) error {
 	roomID := strings.TrimSpace(event.RoomID)
 	if roomID == "" {
-		logger.L().Warn(roomsGatewayLog("Task finalize ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
-		return
+		logger.L().Warn(roomsGatewayLog("Participant kick ignored: missing room ID"), "user_id", client.UserID, "conn_id", client.ConnID)
+		return errRoomCommandMissingRoomID
 	}
 
-	payload := roomTaskFinalizePayload{}
+	payload := roomParticipantKickPayload{}
 	if len(event.Payload) > 0 {
 		if err := json.Unmarshal(event.Payload, &payload); err != nil {
-			logger.L().Warn(roomsGatewayLog("Task finalize ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
-			return
+			logger.L().Warn(roomsGatewayLog("Participant kick ignored: invalid payload"), "err", err, "room_id", roomID, "conn_id", client.ConnID)
+			return errRoomCommandInvalidPayload
 		}
 	}
 
-	updatedTask, err := g.voteService.FinalizeCurrentTask(roomID, client.UserID, payload.Value)
-	if err != nil {
+	participantID := strings.TrimSpace(payload.ParticipantID)
+	if participantID == "" {
+		logger.L().Warn(roomsGatewayLog("Participant kick ignored: missing participant ID"), "room_id", roomID, "conn_id", client.ConnID)
+		return errRoomCommandMissingParticipantID
+	}
+
+	if _, err := g.adminService.KickParticipant(roomID, client.UserID, participantID); err != nil {
 		switch {
 		case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrForbidden), errors.Is(err, apperrors.ErrBadRequest):
-			logger.L().Warn(roomsGatewayLog("Task finalize denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
+			logger.L().Warn(roomsGatewayLog("Participant kick denied"), "room_id", roomID, "conn_id", client.ConnID, "reason", err.Error())
 		default:
-			logger.L().Error(roomsGatewayLog("Task finalize failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
+			logger.L().Error(roomsGatewayLog("Participant kick failed"), "room_id", roomID, "conn_id", client.ConnID, "err", err)
 		}
-		return
+		return err
 	}
 
-	if err := g.broadcastTaskFinalized(roomID, roomTaskFinalizedPayload{
-		TaskID:             updatedTask.TaskID,
-		FinalEstimateValue: strings.TrimSpace(*updatedTask.FinalEstimateValue),
-		Status:             updatedTask.Status,
-	}); err != nil {
-		logger.L().Error(roomsGatewayLog("Failed to broadcast task finalized"), "room_id", roomID, "task_id", updatedTask.TaskID, "err", err)
-	}
+	return nil
 }
 
 func (g *roomsGateway) handleDisconnect(info ws.DisconnectInfo) {
@@ -491,8 +1457,17 @@ func (g *roomsGateway) handleDisconnect(info ws.DisconnectInfo) {
 	}
 
 	participantID := strings.TrimSpace(info.Client.ParticipantID)
+	g.signalService.ClearParticipantFlags(roomID, participantID)
+
+	// A participant removed from the room was already announced with a reason.
+	if _, err := g.participantRepo.FindActiveByID(roomID, participantID); errors.Is(err, apperrors.ErrNotFound) {
+		g.expiryService.TouchActivity(roomID)
+		return
+	}
+
 	payload := roomPresencePayload{
 		ParticipantID: participantID,
+		Reason:        roomParticipantLeftReasonDisconnected,
 	}
 
 	if info.Client.UserID != "" {
@@ -524,7 +1499,7 @@ func (g *roomsGateway) resolveParticipant(client ws.ClientInfo, roomID string) (
 		if err != nil {
 			return nil, err
 		}
-		if participant.Role != roomsmodels.RoomParticipantRoleGuest {
+		if !participant.IsGuest() {
 			return nil, apperrors.ErrForbidden
 		}
 		return participant, nil
@@ -533,7 +1508,24 @@ func (g *roomsGateway) resolveParticipant(client ws.ClientInfo, roomID string) (
 	}
 }
 
-func (g *roomsGateway) buildSnapshot(roomID string) (*roomSnapshotPayload, error) {
+// ensureJoinAllowed keeps a locked room closed to participants admitted
+// after the lock, e.g. through an email invitation. Facilitators and anyone
+// who was already in the room can still (re)join.
+func (g *roomsGateway) ensureJoinAllowed(roomID string, participant *roomsmodels.RoomParticipantModel) error {
+	room, err := g.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return err
+	}
+	if !room.IsLocked() || participant.Role.CanFacilitate() || !participant.JoinedAt.After(*room.LockedAt) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: room is locked", apperrors.ErrForbidden)
+}
+
+// buildSnapshot leaves out the revealed votes of an anonymous room unless the
+// viewer is the admin and the room allows it.
+func (g *roomsGateway) buildSnapshot(roomID, viewerUserID string) (*roomSnapshotPayload, error) {
 	room, err := g.roomsRepo.FindByID(roomID)
 	if err != nil {
 		return nil, err
@@ -560,12 +1552,14 @@ func (g *roomsGateway) buildSnapshot(roomID string) (*roomSnapshotPayload, error
 	tasks := make([]roomSnapshotTask, 0, len(room.Tasks))
 	for _, task := range room.Tasks {
 		tasks = append(tasks, roomSnapshotTask{
-			TaskID:             task.TaskID,
-			Title:              task.Title,
-			Description:        task.Description,
-			ExternalKey:        task.ExternalKey,
-			Status:             task.Status,
-			FinalEstimateValue: task.FinalEstimateValue,
+			TaskID:                  task.TaskID,
+			Title:                   task.Title,
+			Description:             task.Description,
+			ExternalKey:             task.ExternalKey,
+			Status:                  task.Status,
+			FinalEstimateValue:      task.FinalEstimateValue,
+			FinalDimensionEstimates: task.FinalDimensionEstimates,
+			Position:                task.Position,
 		})
 	}
 
@@ -576,7 +1570,11 @@ func (g *roomsGateway) buildSnapshot(roomID string) (*roomSnapshotPayload, error
 			Name:        room.Name,
 			Status:      room.Status,
 			AdminUserID: room.AdminUserID,
+			Locked:      room.IsLocked(),
+			Mode:        room.Mode,
 			Deck:        room.Deck,
+			Dimensions:  room.Dimensions,
+			Options:     room.Options,
 		},
 		Participants:           participants,
 		Tasks:                  tasks,
@@ -586,6 +1584,13 @@ func (g *roomsGateway) buildSnapshot(roomID string) (*roomSnapshotPayload, error
 		VotedParticipantIDs:    make([]string, 0),
 	}
 
+	chatMessages, err := g.chatService.ListRecentMessages(roomID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.ChatMessages = roomsdto.NewRoomChatMessageResponses(chatMessages)
+	snapshot.Flags = g.signalService.ListFlags(roomID)
+
 	currentTask, err := g.taskRepo.FindCurrentVotingTask(roomID)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrNotFound) {
@@ -610,12 +1615,26 @@ func (g *roomsGateway) buildSnapshot(roomID string) (*roomSnapshotPayload, error
 		return nil, err
 	}
 
-	snapshot.VotedParticipantIDs = filterParticipantIDs(uniqueSortedParticipantIDs(votes), currentRound.EligibleParticipantIDs)
+	snapshot.VotedParticipantIDs = filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), currentRound.EligibleParticipantIDs)
 	if currentRound.Status == roomsmodels.RoomTaskRoundStatusRevealed {
-		revealedVotes := mapVotes(votes)
-		summary := buildVoteSummary(votes)
-		snapshot.RevealedVotes = revealedVotes
+		summary := buildVoteSummary(room.Deck, votesInDimension(votes, ""))
+		viewerIsAdmin := viewerUserID != "" && viewerUserID == room.AdminUserID
+		if room.Options.ShowsVoters(viewerIsAdmin) {
+			snapshot.RevealedVotes = mapVotes(votes)
+		}
 		snapshot.Summary = &summary
+		snapshot.DimensionSummaries = buildDimensionVoteSummaries(room.Dimensions, votes)
+	}
+	if currentRound.Status == roomsmodels.RoomTaskRoundStatusActive && currentRound.TimerEndsAt != nil {
+		timer := &roomTimerStartedPayload{
+			TaskID:      currentTask.TaskID,
+			RoundNumber: currentRound.RoundNumber,
+			EndsAt:      *currentRound.TimerEndsAt,
+		}
+		if currentRound.TimerDurationSeconds != nil {
+			timer.DurationSeconds = *currentRound.TimerDurationSeconds
+		}
+		snapshot.Timer = timer
 	}
 
 	return snapshot, nil
@@ -645,7 +1664,7 @@ func (g *roomsGateway) currentEligibleParticipantIDs(roomID string) ([]string, e
 	return normalizeParticipantIDs(eligible), nil
 }
 
-func (g *roomsGateway) sendSnapshot(connID, roomID string, snapshot *roomSnapshotPayload) error {
+func (g *roomsGateway) sendSnapshot(connID, roomID string, seq int64, snapshot *roomSnapshotPayload) error {
 	data, err := json.Marshal(snapshot)
 	if err != nil {
 		return err
@@ -655,6 +1674,7 @@ func (g *roomsGateway) sendSnapshot(connID, roomID string, snapshot *roomSnapsho
 		Type:    RoomsSnapshot,
 		RoomID:  roomID,
 		Payload: data,
+		Seq:     seq,
 	})
 }
 
@@ -688,6 +1708,19 @@ func (g *roomsGateway) broadcastPresence(roomID, eventType string, payload roomP
 	})
 }
 
+func (g *roomsGateway) broadcastParticipantRoleChanged(roomID string, payload roomParticipantRoleChangedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsParticipantRole,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
 func (g *roomsGateway) broadcastCurrentTaskChanged(roomID string, payload roomCurrentTaskChangedPayload) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -701,6 +1734,19 @@ func (g *roomsGateway) broadcastCurrentTaskChanged(roomID string, payload roomCu
 	})
 }
 
+func (g *roomsGateway) broadcastTaskReopened(roomID string, payload roomTaskReopenedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsTaskReopened,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
 func (g *roomsGateway) broadcastVoteStatusChanged(roomID string, payload roomVoteStatusChangedPayload) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -714,6 +1760,58 @@ func (g *roomsGateway) broadcastVoteStatusChanged(roomID string, payload roomVot
 	})
 }
 
+func (g *roomsGateway) broadcastChatMessage(roomID string, payload roomsdto.RoomChatMessageResponse) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsChatMessage,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func (g *roomsGateway) broadcastReaction(roomID string, payload roomReactionPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsReaction,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func (g *roomsGateway) broadcastFlagChanged(roomID string, payload roomFlagChangedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsFlagChanged,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func (g *roomsGateway) broadcastFlagsCleared(roomID string, payload roomFlagsClearedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsFlagsCleared,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
 func (g *roomsGateway) broadcastVotesAllCast(roomID string, payload roomVotesAllCastPayload) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -740,6 +1838,19 @@ func (g *roomsGateway) broadcastVotesRevealed(roomID string, payload roomVotesRe
 	})
 }
 
+func (g *roomsGateway) broadcastTimerExpired(roomID string, payload roomTimerStoppedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsTimerExpired,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
 func (g *roomsGateway) broadcastRoundChanged(roomID string, payload roomRoundChangedPayload) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -766,41 +1877,116 @@ func (g *roomsGateway) broadcastTaskFinalized(roomID string, payload roomTaskFin
 	})
 }
 
+func (g *roomsGateway) broadcastTaskSkipped(roomID string, payload roomTaskSkippedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsTaskSkipped,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func (g *roomsGateway) broadcastRoomFinished(roomID string, payload roomFinishedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return g.wsService.Broadcast(ws.Event{
+		Type:    RoomsFinished,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
 func mapVotes(votes []*roomsmodels.RoomVoteModel) []roomRevealedVote {
 	revealed := make([]roomRevealedVote, 0, len(votes))
 	for _, vote := range votes {
 		revealed = append(revealed, roomRevealedVote{
 			ParticipantID: vote.ParticipantID,
+			Dimension:     vote.Dimension,
 			Value:         vote.Value,
 		})
 	}
 	return revealed
 }
 
-func buildVoteSummary(votes []*roomsmodels.RoomVoteModel) roomVoteSummary {
+func buildVoteSummary(deck roomsmodels.RoomDeck, votes []*roomsmodels.RoomVoteModel) roomVoteSummary {
 	counts := make(map[string]int, len(votes))
+	values := make([]string, 0, len(votes))
 	for _, vote := range votes {
 		counts[vote.Value]++
+		values = append(values, vote.Value)
 	}
 
 	return roomVoteSummary{
 		TotalVotes: len(votes),
 		Counts:     counts,
+		VoteStats:  roomsmodels.ComputeVoteStats(deck, values),
+	}
+}
+
+// buildDimensionVoteSummaries summarizes each dimension against its own deck.
+// It is nil for classic rooms.
+func buildDimensionVoteSummaries(dimensions roomsmodels.RoomDimensions, votes []*roomsmodels.RoomVoteModel) []roomDimensionVoteSummary {
+	if len(dimensions) == 0 {
+		return nil
+	}
+
+	summaries := make([]roomDimensionVoteSummary, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		summaries = append(summaries, roomDimensionVoteSummary{
+			Key:     dimension.Key,
+			Name:    dimension.Name,
+			Summary: buildVoteSummary(dimension.Deck, votesInDimension(votes, dimension.Key)),
+		})
+	}
+
+	return summaries
+}
+
+func votesInDimension(votes []*roomsmodels.RoomVoteModel, dimension string) []*roomsmodels.RoomVoteModel {
+	filtered := make([]*roomsmodels.RoomVoteModel, 0, len(votes))
+	for _, vote := range votes {
+		if vote.Dimension == dimension {
+			filtered = append(filtered, vote)
+		}
 	}
+	return filtered
 }
 
-func uniqueSortedParticipantIDs(votes []*roomsmodels.RoomVoteModel) []string {
-	set := make(map[string]struct{}, len(votes))
+// completeVoterIDs returns the sorted participants who voted in every
+// dimension of the room, or simply voted in a classic room.
+func completeVoterIDs(votes []*roomsmodels.RoomVoteModel, dimensions roomsmodels.RoomDimensions) []string {
+	keys := dimensions.Keys()
+	voted := make(map[string]map[string]struct{}, len(votes))
 	for _, vote := range votes {
 		participantID := strings.TrimSpace(vote.ParticipantID)
-		if participantID != "" {
-			set[participantID] = struct{}{}
+		if participantID == "" {
+			continue
+		}
+		if voted[participantID] == nil {
+			voted[participantID] = make(map[string]struct{}, len(keys))
 		}
+		voted[participantID][vote.Dimension] = struct{}{}
 	}
 
-	ids := make([]string, 0, len(set))
-	for id := range set {
-		ids = append(ids, id)
+	ids := make([]string, 0, len(voted))
+	for id, votedKeys := range voted {
+		complete := true
+		for _, key := range keys {
+			if _, ok := votedKeys[key]; !ok {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			ids = append(ids, id)
+		}
 	}
 	sort.Strings(ids)
 	return ids
@@ -3,6 +3,9 @@ package rooms
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/master-bogdan/estimate-room-api/internal/infra/email"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
+	decksrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/decks/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/gamification"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/invites"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
@@ -10,6 +13,7 @@ import (
 	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
 	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	"github.com/redis/go-redis/v9"
 	"github.com/uptrace/bun"
 )
 
@@ -20,15 +24,27 @@ type RoomsModule struct {
 	TaskService   RoomsTaskService
 	VoteService   RoomsVoteService
 	ExpiryService RoomsExpiryService
+	TimerService  RoomsTimerService
+	AdminService  RoomsAdminService
+	ChatService   RoomsChatService
+	SignalService RoomsSignalService
+	AsyncService  RoomsAsyncService
 }
 
 type RoomsModuleDeps struct {
-	Router         chi.Router
-	DB             *bun.DB
-	WsService      *ws.Service
+	Router    chi.Router
+	DB        *bun.DB
+	WsService *ws.Service
+	PubSub    ws.PubSub
+	// Redis holds the state every instance shares, such as raised flags.
+	Redis          *redis.Client
 	AuthService    oauth2.Oauth2SessionAuthService
 	InvitesService invites.InvitesService
 	RewardService  gamification.RoomRewardService
+	// EmailClient sends async vote reminders; FrontendBaseURL links them
+	// back to the room.
+	EmailClient     email.Client
+	FrontendBaseURL string
 }
 
 func NewRoomsModule(deps RoomsModuleDeps) *RoomsModule {
@@ -37,36 +53,74 @@ func NewRoomsModule(deps RoomsModuleDeps) *RoomsModule {
 	voteRepo := roomsrepositories.NewRoomVoteRepository(deps.DB)
 	roundRepo := roomsrepositories.NewRoomTaskRoundRepository(deps.DB)
 	participantRepo := roomsrepositories.NewRoomParticipantRepository(deps.DB)
+	chatRepo := roomsrepositories.NewRoomChatRepository(deps.DB)
 	teamRepo := teamsrepositories.NewTeamRepository(deps.DB)
 	memberRepo := teamsrepositories.NewTeamMemberRepository(deps.DB)
 	userRepo := usersrepositories.NewUserRepository(deps.DB)
+	settingsRepo := usersrepositories.NewUserSettingsRepository(deps.DB)
+	decksSvc := decks.NewDecksService(decksrepositories.NewDeckRepository(deps.DB), teamRepo, memberRepo)
 	expirySvc := NewRoomsExpiryService(deps.DB, roomsRepo, deps.WsService, deps.RewardService)
-	svc := NewRoomsService(deps.DB, roomsRepo, participantRepo, teamRepo, memberRepo, userRepo, deps.InvitesService, deps.RewardService)
-	voteSvc := NewRoomsVoteService(roomsRepo, taskRepo, voteRepo, roundRepo, participantRepo, expirySvc)
-	taskSvc := NewRoomsTaskService(roomsRepo, taskRepo, voteSvc, participantRepo, expirySvc)
-	ctrl := NewRoomsController(svc, taskSvc, deps.InvitesService, deps.AuthService)
-	gw := NewRoomsGateway(deps.WsService, roomsRepo, participantRepo, taskRepo, voteRepo, roundRepo, voteSvc, expirySvc)
+	svc := NewRoomsService(deps.DB, roomsRepo, participantRepo, teamRepo, memberRepo, userRepo, settingsRepo, deps.InvitesService, deps.RewardService, decksSvc)
+	timerSvc := NewRoomsTimerService(deps.PubSub, deps.WsService, roundRepo)
+	voteSvc := NewRoomsVoteService(deps.DB, roomsRepo, taskRepo, voteRepo, roundRepo, participantRepo, expirySvc, timerSvc)
+	taskSvc := NewRoomsTaskService(roomsRepo, taskRepo, voteSvc, participantRepo, expirySvc, deps.WsService)
+	adminSvc := NewRoomsAdminService(deps.DB, roomsRepo, participantRepo, deps.WsService, voteSvc, expirySvc)
+	chatSvc := NewRoomsChatService(roomsRepo, taskRepo, chatRepo, expirySvc)
+	signalSvc := NewRoomsSignalService(deps.Redis)
+	asyncSvc := NewRoomsAsyncService(roomsRepo, taskRepo, voteRepo, roundRepo, participantRepo, voteSvc, deps.WsService, deps.EmailClient, deps.FrontendBaseURL)
+	ctrl := NewRoomsController(svc, taskSvc, adminSvc, chatSvc, asyncSvc, deps.InvitesService, deps.AuthService)
+	gw := NewRoomsGateway(deps.WsService, roomsRepo, participantRepo, taskRepo, voteRepo, roundRepo, voteSvc, expirySvc, adminSvc, chatSvc, signalSvc, svc)
 
 	deps.Router.Route("/rooms", func(r chi.Router) {
 		r.Post("/", ctrl.CreateRoom)
 		r.Get("/{id}", ctrl.GetRoom)
 		r.Patch("/{id}", ctrl.UpdateRoom)
+		r.Post("/{id}/clone", ctrl.CloneRoom)
+		r.Post("/{id}/admin", ctrl.TransferAdmin)
+		r.Put("/{id}/facilitators/{participantId}", ctrl.AddFacilitator)
+		r.Delete("/{id}/facilitators/{participantId}", ctrl.RemoveFacilitator)
+		r.Put("/{id}/lock", ctrl.LockRoom)
+		r.Delete("/{id}/lock", ctrl.UnlockRoom)
+		r.Delete("/{id}/participants/{participantId}", ctrl.KickParticipant)
+		r.Get("/{id}/chat", ctrl.ListChatMessages)
 		r.Route("/{id}/tasks", func(taskRouter chi.Router) {
 			taskRouter.Post("/", ctrl.CreateTask)
+			taskRouter.Post("/import", ctrl.ImportTasks)
 			taskRouter.Get("/", ctrl.ListTasks)
+			taskRouter.Put("/order", ctrl.ReorderTasks)
 			taskRouter.Get("/{taskId}", ctrl.GetTask)
 			taskRouter.Patch("/{taskId}", ctrl.UpdateTask)
 			taskRouter.Delete("/{taskId}", ctrl.DeleteTask)
+			taskRouter.Post("/{taskId}/open", ctrl.OpenTask)
+			taskRouter.Put("/{taskId}/vote", ctrl.CastTaskVote)
+			taskRouter.Delete("/{taskId}/vote", ctrl.RetractTaskVote)
 		})
 	})
 
-	deps.WsService.Subscribe(RoomsJoin, gw.handleRoomJoin)
-	deps.WsService.Subscribe(RoomsTaskSetCurrent, gw.handleTaskSetCurrent)
-	deps.WsService.Subscribe(RoomsVoteCast, gw.handleVoteCast)
-	deps.WsService.Subscribe(RoomsVoteReveal, gw.handleVoteReveal)
-	deps.WsService.Subscribe(RoomsRoundNext, gw.handleRoundNext)
-	deps.WsService.Subscribe(RoomsTaskFinalize, gw.handleTaskFinalize)
+	deps.WsService.HandleCommand(RoomsJoin, gw.handleRoomJoin)
+	deps.WsService.HandleCommand(RoomsResume, gw.handleRoomResume)
+	deps.WsService.HandleCommand(RoomsTaskSetCurrent, gw.handleTaskSetCurrent)
+	deps.WsService.HandleCommand(RoomsTaskReestimate, gw.handleTaskReestimate)
+	deps.WsService.HandleCommand(RoomsVoteCast, gw.handleVoteCast)
+	deps.WsService.HandleCommand(RoomsVoteRetract, gw.handleVoteRetract)
+	deps.WsService.HandleCommand(RoomsVoteReveal, gw.handleVoteReveal)
+	deps.WsService.HandleCommand(RoomsRoundNext, gw.handleRoundNext)
+	deps.WsService.HandleCommand(RoomsTaskFinalize, gw.handleTaskFinalize)
+	deps.WsService.HandleCommand(RoomsTaskSkip, gw.handleTaskSkip)
+	deps.WsService.HandleCommand(RoomsFinish, gw.handleRoomFinish)
+	deps.WsService.HandleCommand(RoomsObserverSet, gw.handleObserverSet)
+	deps.WsService.HandleCommand(RoomsFacilitatorSet, gw.handleFacilitatorSet)
+	deps.WsService.HandleCommand(RoomsAdminTransfer, gw.handleAdminTransfer)
+	deps.WsService.HandleCommand(RoomsLockSet, gw.handleLockSet)
+	deps.WsService.HandleCommand(RoomsParticipantKick, gw.handleParticipantKick)
+	deps.WsService.HandleCommand(RoomsChatSend, gw.handleChatSend)
+	deps.WsService.HandleCommand(RoomsReactionSend, gw.handleReactionSend)
+	deps.WsService.HandleCommand(RoomsFlagSet, gw.handleFlagSet)
+	deps.WsService.HandleCommand(RoomsFlagsClear, gw.handleFlagsClear)
 	deps.WsService.SubscribeDisconnect(gw.handleDisconnect)
+	timerSvc.OnExpire(gw.handleTimerExpired)
+	svc.OnFinish(gw.handleRoomFinished)
+	expirySvc.OnExpire(gw.handleRoomExpired)
 
 	return &RoomsModule{
 		Controller:    ctrl,
@@ -75,5 +129,10 @@ func NewRoomsModule(deps RoomsModuleDeps) *RoomsModule {
 		TaskService:   taskSvc,
 		VoteService:   voteSvc,
 		ExpiryService: expirySvc,
+		TimerService:  timerSvc,
+		AdminService:  adminSvc,
+		ChatService:   chatSvc,
+		SignalService: signalSvc,
+		AsyncService:  asyncSvc,
 	}
 }
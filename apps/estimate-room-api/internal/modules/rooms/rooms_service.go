@@ -3,13 +3,16 @@ package rooms
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/gamification"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/invites"
 	invitesmodels "github.com/master-bogdan/estimate-room-api/internal/modules/invites/models"
@@ -29,15 +32,30 @@ type RoomsService interface {
 	GetRoom(roomID string) (*roomsmodels.RoomsModel, error)
 	ValidateUserRoomAccess(roomID, userID string) error
 	UpdateRoom(roomID, userID string, input UpdateRoomInput) (*roomsmodels.RoomsModel, error)
+	FinishRoom(roomID, userID string) (*roomsmodels.RoomsModel, error)
+	OnFinish(handler RoomFinishedHandler)
+	CloneRoom(ctx context.Context, roomID, userID string, input CloneRoomInput) (*CreateRoomResult, error)
 }
 
+// RoomFinishedHandler runs once a room is finished, before its gamification
+// rewards are applied.
+type RoomFinishedHandler func(room *roomsmodels.RoomsModel)
+
 type CreateRoomInput struct {
 	Name            string
 	Deck            roomsmodels.RoomDeck
+	DeckID          *string
 	AdminUserID     string
 	InviteTeamID    *string
 	InviteEmails    []string
 	CreateShareLink bool
+	// Options falls back to the admin's saved default room options when nil.
+	Options *roomsmodels.RoomOptions
+	// Dimensions are fixed at creation; a room without them is estimated
+	// with the room deck alone.
+	Dimensions roomsmodels.RoomDimensions
+	// Mode defaults to a live room when blank.
+	Mode roomsmodels.RoomMode
 }
 
 type CreatedRoomInvitation struct {
@@ -65,9 +83,14 @@ type roomsService struct {
 	teamRepo        teamsrepositories.TeamRepository
 	memberRepo      teamsrepositories.TeamMemberRepository
 	userRepo        usersrepositories.UserRepository
+	settingsRepo    usersrepositories.UserSettingsRepository
 	invitesService  invites.InvitesService
 	rewardService   gamification.RoomRewardService
+	decksService    decks.DecksService
 	logger          *slog.Logger
+
+	mu             sync.RWMutex
+	finishHandlers []RoomFinishedHandler
 }
 
 func NewRoomsService(
@@ -77,8 +100,10 @@ func NewRoomsService(
 	teamRepo teamsrepositories.TeamRepository,
 	memberRepo teamsrepositories.TeamMemberRepository,
 	userRepo usersrepositories.UserRepository,
+	settingsRepo usersrepositories.UserSettingsRepository,
 	invitesService invites.InvitesService,
 	rewardService gamification.RoomRewardService,
+	decksService decks.DecksService,
 ) RoomsService {
 	return &roomsService{
 		db:              db,
@@ -87,8 +112,10 @@ func NewRoomsService(
 		teamRepo:        teamRepo,
 		memberRepo:      memberRepo,
 		userRepo:        userRepo,
+		settingsRepo:    settingsRepo,
 		invitesService:  invitesService,
 		rewardService:   rewardService,
+		decksService:    decksService,
 		logger:          logger.L().With(slog.String("service", "rooms")),
 	}
 }
@@ -98,6 +125,49 @@ func (s *roomsService) CreateRoom(ctx context.Context, input CreateRoomInput) (*
 		Name:        strings.TrimSpace(input.Name),
 		Deck:        input.Deck,
 		AdminUserID: input.AdminUserID,
+		Mode:        input.Mode,
+	}
+	if model.Mode == "" {
+		model.Mode = roomsmodels.RoomModeLive
+	}
+	if !model.Mode.IsValid() {
+		return nil, fmt.Errorf("%w: invalid room mode", apperrors.ErrBadRequest)
+	}
+
+	settings, err := s.loadCreatorSettings(ctx, input.AdminUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deckID := normalizeOptionalStringValue(input.DeckID); deckID != "" {
+		if !model.Deck.IsZero() {
+			return nil, fmt.Errorf("%w: deck and deckId are mutually exclusive", apperrors.ErrBadRequest)
+		}
+		if s.decksService == nil {
+			return nil, fmt.Errorf("%w: deck lookup is unavailable", apperrors.ErrInternal)
+		}
+
+		deck, err := s.decksService.ResolveRoomDeck(ctx, deckID, input.AdminUserID)
+		if err != nil {
+			return nil, err
+		}
+		model.Deck = deck
+	} else if model.Deck.IsZero() && settings.defaultDeckID != "" {
+		model.Deck = s.resolveDefaultDeck(ctx, input.AdminUserID, settings.defaultDeckID)
+	}
+
+	options := input.Options
+	if options == nil {
+		options = settings.defaultRoomOptions
+	}
+	if options != nil {
+		if !options.IsValid() {
+			return nil, fmt.Errorf("%w: invalid room options", apperrors.ErrBadRequest)
+		}
+		if options.CreateShareLink {
+			input.CreateShareLink = true
+		}
+		model.Options = *options
 	}
 
 	if model.Deck.IsZero() {
@@ -119,6 +189,11 @@ func (s *roomsService) CreateRoom(ctx context.Context, input CreateRoomInput) (*
 		return nil, fmt.Errorf("%w: invalid deck", apperrors.ErrBadRequest)
 	}
 
+	model.Dimensions = normalizeRoomDimensions(input.Dimensions)
+	if !model.Dimensions.IsValid() {
+		return nil, fmt.Errorf("%w: invalid dimensions", apperrors.ErrBadRequest)
+	}
+
 	invitePlan, err := s.planRoomInvitations(input.AdminUserID, input.InviteTeamID, input.InviteEmails)
 	if err != nil {
 		return nil, err
@@ -127,10 +202,7 @@ func (s *roomsService) CreateRoom(ctx context.Context, input CreateRoomInput) (*
 		model.TeamID = &teamID
 	}
 
-	timestamp := time.Now().String()
-	code := base64.RawURLEncoding.EncodeToString([]byte(model.Name + timestamp))
-
-	model.Code = code
+	model.Code = newRoomCode(model.Name)
 
 	result := &CreateRoomResult{
 		EmailInvitations:  make([]CreatedRoomInvitation, 0, len(invitePlan.Emails)),
@@ -203,6 +275,253 @@ func (s *roomsService) CreateRoom(ctx context.Context, input CreateRoomInput) (*
 	return result, nil
 }
 
+// CloneRoomInput configures a follow-up session. Name falls back to the
+// source room name.
+type CloneRoomInput struct {
+	Name               *string
+	InviteParticipants bool
+}
+
+// CloneRoom starts a new active room from a finished or expired one. The deck,
+// team, options and name are copied along with every task that was not
+// estimated, in backlog order and reset to PENDING. With InviteParticipants,
+// the previous registered participants get room email invitations.
+func (s *roomsService) CloneRoom(ctx context.Context, roomID, userID string, input CloneRoomInput) (*CreateRoomResult, error) {
+	source, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+	if source.AdminUserID != userID {
+		return nil, apperrors.ErrForbidden
+	}
+	if !isTerminalRoomStatus(source.Status) {
+		return nil, fmt.Errorf("%w: only finished or expired rooms can be cloned", apperrors.ErrBadRequest)
+	}
+
+	if source.TeamID != nil {
+		if _, err := s.memberRepo.FindByTeamAndUser(*source.TeamID, userID); err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) {
+				return nil, apperrors.ErrForbidden
+			}
+			return nil, err
+		}
+	}
+
+	name := source.Name
+	if input.Name != nil {
+		name = strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, fmt.Errorf("%w: room name is required", apperrors.ErrBadRequest)
+		}
+	}
+
+	model := roomsmodels.RoomsModel{
+		Name:        name,
+		Deck:        source.Deck,
+		AdminUserID: userID,
+		TeamID:      source.TeamID,
+		Options:     source.Options,
+		Dimensions:  normalizeRoomDimensions(source.Dimensions),
+		Mode:        source.Mode,
+		Code:        newRoomCode(name),
+	}
+
+	tasks := make([]*roomsmodels.RoomTaskModel, 0, len(source.Tasks))
+	for _, task := range source.Tasks {
+		if task.Status == "ESTIMATED" {
+			continue
+		}
+
+		tasks = append(tasks, &roomsmodels.RoomTaskModel{
+			TaskID:      uuid.NewString(),
+			Title:       task.Title,
+			Description: task.Description,
+			ExternalKey: task.ExternalKey,
+			Status:      "PENDING",
+			IsActive:    false,
+		})
+	}
+
+	invitePlan := &roomInvitationPlan{
+		Emails:            make([]string, 0),
+		SkippedRecipients: make([]CreateRoomSkippedRecipient, 0),
+	}
+	if input.InviteParticipants {
+		invitePlan, err = s.planParticipantReinvitations(roomID, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &CreateRoomResult{
+		EmailInvitations:  make([]CreatedRoomInvitation, 0, len(invitePlan.Emails)),
+		SkippedRecipients: invitePlan.SkippedRecipients,
+	}
+	var clonedRoomID string
+	err = s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		roomRepo := roomsrepositories.NewRoomsRepository(tx)
+		participantRepo := roomsrepositories.NewRoomParticipantRepository(tx)
+		taskRepo := roomsrepositories.NewRoomTaskRepository(tx)
+
+		room, err := roomRepo.Create(ctx, &model)
+		if err != nil {
+			return err
+		}
+		clonedRoomID = room.RoomID
+
+		_, err = participantRepo.Create(&roomsmodels.RoomParticipantModel{
+			RoomParticipantID: uuid.NewString(),
+			RoomID:            room.RoomID,
+			UserID:            &room.AdminUserID,
+			Role:              roomsmodels.RoomParticipantRoleAdmin,
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(tasks) > 0 {
+			for _, task := range tasks {
+				task.RoomID = room.RoomID
+			}
+			if _, err := taskRepo.CreateMany(ctx, tasks); err != nil {
+				return err
+			}
+		}
+
+		for _, email := range invitePlan.Emails {
+			emailCopy := email
+			invitation, token, err := s.invitesService.CreateInvitationWithDB(ctx, tx, invites.CreateInvitationInput{
+				Kind:            invitesmodels.InvitationKindRoomEmail,
+				RoomID:          &room.RoomID,
+				InvitedEmail:    &emailCopy,
+				CreatedByUserID: userID,
+			})
+			if err != nil {
+				return err
+			}
+
+			result.EmailInvitations = append(result.EmailInvitations, CreatedRoomInvitation{
+				Invitation: invitation,
+				Token:      token,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result.Room, err = s.roomsRepo.FindByID(clonedRoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics.RecordRoomLifecycle("created")
+	logger.FromContext(ctx, s.logger).Info(roomsServiceLog("Room cloned"), "room_id", clonedRoomID, "source_room_id", roomID, "admin_user_id", userID, "tasks", len(tasks))
+
+	return result, nil
+}
+
+// planParticipantReinvitations collects the emails of the registered users who
+// took part in the room, skipping the caller and users without an email.
+func (s *roomsService) planParticipantReinvitations(roomID, userID string) (*roomInvitationPlan, error) {
+	plan := &roomInvitationPlan{
+		Emails:            make([]string, 0),
+		SkippedRecipients: make([]CreateRoomSkippedRecipient, 0),
+	}
+
+	participants, err := s.participantRepo.ListRegisteredByRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	seenEmails := make(map[string]struct{}, len(participants))
+	seenSkipped := make(map[string]struct{})
+	for _, participant := range participants {
+		if participant.UserID == nil || *participant.UserID == userID {
+			continue
+		}
+
+		participantUserID := *participant.UserID
+		if participant.User == nil || participant.User.DeletedAt != nil || participant.User.Email == nil {
+			s.addSkippedRecipient(plan, seenSkipped, CreateRoomSkippedRecipient{
+				UserID: &participantUserID,
+				Reason: "missing_email",
+			})
+			continue
+		}
+
+		email := strings.ToLower(strings.TrimSpace(*participant.User.Email))
+		if email == "" {
+			s.addSkippedRecipient(plan, seenSkipped, CreateRoomSkippedRecipient{
+				UserID: &participantUserID,
+				Reason: "missing_email",
+			})
+			continue
+		}
+		if _, exists := seenEmails[email]; exists {
+			continue
+		}
+
+		seenEmails[email] = struct{}{}
+		plan.Emails = append(plan.Emails, email)
+	}
+
+	return plan, nil
+}
+
+func newRoomCode(name string) string {
+	timestamp := time.Now().String()
+	return base64.RawURLEncoding.EncodeToString([]byte(name + timestamp))
+}
+
+type roomCreatorSettings struct {
+	defaultDeckID      string
+	defaultRoomOptions *roomsmodels.RoomOptions
+}
+
+func (s *roomsService) loadCreatorSettings(ctx context.Context, userID string) (roomCreatorSettings, error) {
+	if s.settingsRepo == nil {
+		return roomCreatorSettings{}, nil
+	}
+
+	settings, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return roomCreatorSettings{}, err
+	}
+
+	result := roomCreatorSettings{
+		defaultDeckID: normalizeOptionalStringValue(settings.DefaultDeckID),
+	}
+	if len(settings.DefaultRoomOptions) > 0 && string(settings.DefaultRoomOptions) != "null" {
+		options := &roomsmodels.RoomOptions{}
+		if err := json.Unmarshal(settings.DefaultRoomOptions, options); err != nil {
+			return roomCreatorSettings{}, err
+		}
+		result.defaultRoomOptions = options
+	}
+
+	return result, nil
+}
+
+// resolveDefaultDeck loads the saved default deck. A default deck that was
+// deleted or is no longer shared with the user falls back to the built-in
+// deck instead of failing room creation.
+func (s *roomsService) resolveDefaultDeck(ctx context.Context, userID, deckID string) roomsmodels.RoomDeck {
+	if s.decksService == nil {
+		return roomsmodels.RoomDeck{}
+	}
+
+	deck, err := s.decksService.ResolveRoomDeck(ctx, deckID, userID)
+	if err != nil {
+		logger.FromContext(ctx, s.logger).Warn(roomsServiceLog("Default deck unavailable"), "user_id", userID, "deck_id", deckID, "err", err)
+		return roomsmodels.RoomDeck{}
+	}
+
+	return deck
+}
+
 func (s *roomsService) GetRoom(roomID string) (*roomsmodels.RoomsModel, error) {
 	return s.roomsRepo.FindByID(roomID)
 }
@@ -224,12 +543,29 @@ func (s *roomsService) ValidateUserRoomAccess(roomID, userID string) error {
 }
 
 type UpdateRoomInput struct {
-	Name   *string
-	Status *string
+	Name    *string
+	Status  *string
+	Options *UpdateRoomOptionsInput
+}
+
+// UpdateRoomOptionsInput patches the voting options; nil fields are left
+// unchanged. A changed timer applies from the next round. Anonymous voting
+// cannot be turned off, so earlier anonymous rounds stay anonymous.
+type UpdateRoomOptionsInput struct {
+	AutoReveal         *bool
+	VotingTimerSeconds *int
+	AnonymousVoting    *bool
+	AdminSeesVotes     *bool
 }
 
 func (s *roomsService) UpdateRoom(roomID, userID string, input UpdateRoomInput) (*roomsmodels.RoomsModel, error) {
-	room, err := s.ensureRoomAdmin(roomID, userID)
+	// Finishing the room belongs to running the session, like finalizing or
+	// skipping tasks; every other change stays with the room admin.
+	ensureAllowed := s.ensureRoomAdmin
+	if isFinishOnlyPatch(input) {
+		ensureAllowed = s.ensureRoomFacilitator
+	}
+	room, err := ensureAllowed(roomID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -249,7 +585,37 @@ func (s *roomsService) UpdateRoom(roomID, userID string, input UpdateRoomInput)
 	if isTerminalRoomStatus(room.Status) {
 		return nil, fmt.Errorf("%w: terminal rooms cannot be updated", apperrors.ErrBadRequest)
 	}
-	if roomPatchIsNoop(room, input) {
+
+	var options *roomsmodels.RoomOptions
+	if input.Options != nil {
+		patched := room.Options
+		if input.Options.AutoReveal != nil {
+			patched.AutoReveal = *input.Options.AutoReveal
+		}
+		if input.Options.VotingTimerSeconds != nil {
+			patched.VotingTimerSeconds = *input.Options.VotingTimerSeconds
+		}
+		if input.Options.AnonymousVoting != nil {
+			if room.Options.AnonymousVoting && !*input.Options.AnonymousVoting {
+				return nil, fmt.Errorf("%w: anonymous voting cannot be turned off", apperrors.ErrBadRequest)
+			}
+			patched.AnonymousVoting = *input.Options.AnonymousVoting
+		}
+		if input.Options.AdminSeesVotes != nil {
+			if room.Options.AnonymousVoting && !room.Options.AdminSeesVotes && *input.Options.AdminSeesVotes {
+				return nil, fmt.Errorf("%w: admin vote visibility cannot be turned on in an anonymous room", apperrors.ErrBadRequest)
+			}
+			patched.AdminSeesVotes = *input.Options.AdminSeesVotes
+		}
+		if !patched.IsValid() {
+			return nil, fmt.Errorf("%w: invalid room options", apperrors.ErrBadRequest)
+		}
+		if patched != room.Options {
+			options = &patched
+		}
+	}
+
+	if roomPatchIsNoop(room, input) && options == nil {
 		return room, nil
 	}
 	if input.Status != nil && *input.Status == "EXPIRED" {
@@ -261,8 +627,9 @@ func (s *roomsService) UpdateRoom(roomID, userID string, input UpdateRoomInput)
 		roomRepo := roomsrepositories.NewRoomsRepository(tx)
 
 		updatedRoom, err = roomRepo.Update(roomID, roomsrepositories.UpdateRoomFields{
-			Name:   input.Name,
-			Status: input.Status,
+			Name:    input.Name,
+			Status:  input.Status,
+			Options: options,
 		})
 		if err != nil {
 			return err
@@ -278,41 +645,92 @@ func (s *roomsService) UpdateRoom(roomID, userID string, input UpdateRoomInput)
 		metrics.RecordRoomLifecycle(updatedRoom.Status)
 	}
 
+	if room.Status != updatedRoom.Status && updatedRoom.Status == "FINISHED" {
+		s.notifyFinished(updatedRoom)
+	}
+
 	if s.rewardService != nil && isTerminalRoomStatus(updatedRoom.Status) {
-		appliedRewards := s.applyTerminalRewardsBestEffort(updatedRoom)
+		appliedRewards, appliedTeamReward := s.applyTerminalRewardsBestEffort(updatedRoom)
 		if len(appliedRewards) > 0 {
 			if err := s.rewardService.NotifyAppliedRewards(context.Background(), appliedRewards); err != nil {
 				s.logger.Error(roomsServiceLog("Failed to notify room rewards"), "room_id", roomID, "err", err)
 			}
 		}
+		if appliedTeamReward != nil {
+			if err := s.rewardService.NotifyAppliedTeamReward(context.Background(), appliedTeamReward); err != nil {
+				s.logger.Error(roomsServiceLog("Failed to notify team rewards"), "room_id", roomID, "team_id", appliedTeamReward.TeamID, "err", err)
+			}
+		}
 	}
 
-	s.logger.Info(roomsServiceLog("Room updated"), "room_id", updatedRoom.RoomID, "status", updatedRoom.Status, "admin_user_id", userID)
+	s.logger.Info(roomsServiceLog("Room updated"), "room_id", updatedRoom.RoomID, "status", updatedRoom.Status, "user_id", userID)
 
 	return updatedRoom, nil
 }
 
-func (s *roomsService) applyTerminalRewardsBestEffort(room *roomsmodels.RoomsModel) []gamification.AppliedRoomReward {
+func isFinishOnlyPatch(input UpdateRoomInput) bool {
+	return input.Name == nil &&
+		input.Options == nil &&
+		input.Status != nil &&
+		strings.TrimSpace(*input.Status) == "FINISHED"
+}
+
+func (s *roomsService) FinishRoom(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+	status := "FINISHED"
+
+	return s.UpdateRoom(roomID, userID, UpdateRoomInput{Status: &status})
+}
+
+func (s *roomsService) OnFinish(handler RoomFinishedHandler) {
+	if handler == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.finishHandlers = append(s.finishHandlers, handler)
+	s.mu.Unlock()
+}
+
+func (s *roomsService) notifyFinished(room *roomsmodels.RoomsModel) {
+	s.mu.RLock()
+	handlers := append([]RoomFinishedHandler(nil), s.finishHandlers...)
+	s.mu.RUnlock()
+
+	for _, handler := range handlers {
+		handler(room)
+	}
+}
+
+func (s *roomsService) applyTerminalRewardsBestEffort(
+	room *roomsmodels.RoomsModel,
+) ([]gamification.AppliedRoomReward, *gamification.AppliedTeamReward) {
 	if s.rewardService == nil || room == nil || !isTerminalRoomStatus(room.Status) {
-		return nil
+		return nil, nil
 	}
 
 	appliedRewards := make([]gamification.AppliedRoomReward, 0)
+	var appliedTeamReward *gamification.AppliedTeamReward
 	err := s.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
 		rewards, err := s.rewardService.ApplyRoomTerminalRewards(ctx, tx, room)
 		if err != nil {
 			return err
 		}
 
+		teamReward, err := s.rewardService.ApplyTeamTerminalRewards(ctx, tx, room)
+		if err != nil {
+			return err
+		}
+
 		appliedRewards = rewards
+		appliedTeamReward = teamReward
 		return nil
 	})
 	if err != nil {
 		s.logger.Error(roomsServiceLog("Failed to apply room rewards"), "room_id", room.RoomID, "status", room.Status, "err", err)
-		return nil
+		return nil, nil
 	}
 
-	return appliedRewards
+	return appliedRewards, appliedTeamReward
 }
 
 func roomsServiceLog(message string) string {
@@ -329,6 +747,32 @@ func roomPatchIsNoop(room *roomsmodels.RoomsModel, input UpdateRoomInput) bool {
 	return true
 }
 
+// normalizeRoomDimensions trims the dimensions and their decks and never
+// returns nil, so an empty list is stored rather than null.
+func normalizeRoomDimensions(dimensions roomsmodels.RoomDimensions) roomsmodels.RoomDimensions {
+	normalized := make(roomsmodels.RoomDimensions, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		values := make([]string, 0, len(dimension.Deck.Values))
+		for _, value := range dimension.Deck.Values {
+			if trimmed := strings.TrimSpace(value); trimmed != "" {
+				values = append(values, trimmed)
+			}
+		}
+
+		normalized = append(normalized, roomsmodels.RoomDimension{
+			Key:  strings.ToLower(strings.TrimSpace(dimension.Key)),
+			Name: strings.TrimSpace(dimension.Name),
+			Deck: roomsmodels.RoomDeck{
+				Name:   strings.TrimSpace(dimension.Deck.Name),
+				Kind:   strings.TrimSpace(dimension.Deck.Kind),
+				Values: values,
+			},
+		})
+	}
+
+	return normalized
+}
+
 func isTerminalRoomStatus(status string) bool {
 	return status == "FINISHED" || status == "EXPIRED"
 }
@@ -504,6 +948,30 @@ func valueOrEmpty(value *string) string {
 	return *value
 }
 
+// ensureRoomFacilitator allows the room admin and co-facilitators.
+func (s *roomsService) ensureRoomFacilitator(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+	room, err := s.roomsRepo.FindByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.FindActiveByUserID(roomID, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, apperrors.ErrForbidden
+		}
+		return nil, err
+	}
+	if !participant.Role.CanFacilitate() {
+		return nil, apperrors.ErrForbidden
+	}
+	if participant.Role == roomsmodels.RoomParticipantRoleAdmin && room.AdminUserID != userID {
+		return nil, apperrors.ErrForbidden
+	}
+
+	return room, nil
+}
+
 func (s *roomsService) ensureRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, error) {
 	room, err := s.roomsRepo.FindByID(roomID)
 	if err != nil {
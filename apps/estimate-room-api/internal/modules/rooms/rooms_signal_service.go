@@ -0,0 +1,275 @@
+package rooms
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+	"github.com/redis/go-redis/v9"
+)
+
+// roomFlagsTTL drops the flags of a room nobody has raised a flag in for a
+// day, should the room never reach a terminal status.
+const roomFlagsTTL = 24 * time.Hour
+
+// RoomsSignalService keeps the flags participants raise in a room. Flags are
+// never persisted to Postgres: they live in one Redis hash per room that every
+// instance reads, or in memory when there is no Redis.
+type RoomsSignalService interface {
+	SetFlag(roomID, participantID string, flag roomsmodels.RoomFlag, raised bool) (bool, error)
+	ClearRoomFlags(roomID string) error
+	ClearParticipantFlags(roomID, participantID string)
+	ListFlags(roomID string) []RoomRaisedFlag
+}
+
+type RoomRaisedFlag struct {
+	ParticipantID string               `json:"participantId"`
+	Flag          roomsmodels.RoomFlag `json:"flag"`
+	RaisedAt      time.Time            `json:"raisedAt"`
+}
+
+type roomFlagStore interface {
+	Raise(ctx context.Context, roomID, participantID string, flag roomsmodels.RoomFlag, raisedAt time.Time) (bool, error)
+	Lower(ctx context.Context, roomID, participantID string, flag roomsmodels.RoomFlag) (bool, error)
+	ClearParticipant(ctx context.Context, roomID, participantID string) error
+	ClearRoom(ctx context.Context, roomID string) error
+	List(ctx context.Context, roomID string) ([]RoomRaisedFlag, error)
+}
+
+type roomsSignalService struct {
+	store  roomFlagStore
+	logger *slog.Logger
+}
+
+func NewRoomsSignalService(client *redis.Client) RoomsSignalService {
+	var store roomFlagStore = &memoryRoomFlagStore{
+		flags: make(map[string]map[string]map[roomsmodels.RoomFlag]time.Time),
+	}
+	if client != nil {
+		store = &redisRoomFlagStore{client: client, ttl: roomFlagsTTL}
+	}
+
+	return &roomsSignalService{
+		store:  store,
+		logger: logger.L().With(slog.String("service", "rooms-signals")),
+	}
+}
+
+// SetFlag raises or lowers one of the participant's flags and reports whether
+// that changed anything.
+func (s *roomsSignalService) SetFlag(roomID, participantID string, flag roomsmodels.RoomFlag, raised bool) (bool, error) {
+	if !flag.IsValid() {
+		return false, fmt.Errorf("%w: unknown flag %q", apperrors.ErrBadRequest, flag)
+	}
+
+	if raised {
+		return s.store.Raise(context.Background(), roomID, participantID, flag, time.Now().UTC())
+	}
+
+	return s.store.Lower(context.Background(), roomID, participantID, flag)
+}
+
+// ClearRoomFlags lowers every flag in the room.
+func (s *roomsSignalService) ClearRoomFlags(roomID string) error {
+	return s.store.ClearRoom(context.Background(), roomID)
+}
+
+// ClearParticipantFlags drops the flags of a participant who left the room.
+func (s *roomsSignalService) ClearParticipantFlags(roomID, participantID string) {
+	if err := s.store.ClearParticipant(context.Background(), roomID, participantID); err != nil {
+		s.logger.Error(roomsSignalLog("Failed to clear participant flags"), "room_id", roomID, "participant_id", participantID, "err", err)
+	}
+}
+
+// ListFlags returns the raised flags of the room, oldest first.
+func (s *roomsSignalService) ListFlags(roomID string) []RoomRaisedFlag {
+	raised, err := s.store.List(context.Background(), roomID)
+	if err != nil {
+		s.logger.Error(roomsSignalLog("Failed to list room flags"), "room_id", roomID, "err", err)
+		return []RoomRaisedFlag{}
+	}
+
+	sort.Slice(raised, func(i, j int) bool {
+		if !raised[i].RaisedAt.Equal(raised[j].RaisedAt) {
+			return raised[i].RaisedAt.Before(raised[j].RaisedAt)
+		}
+		if raised[i].ParticipantID != raised[j].ParticipantID {
+			return raised[i].ParticipantID < raised[j].ParticipantID
+		}
+		return raised[i].Flag < raised[j].Flag
+	})
+
+	return raised
+}
+
+// redisRoomFlagStore keeps a hash per room from "participantID|flag" to the
+// time the flag was raised.
+type redisRoomFlagStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func (s *redisRoomFlagStore) Raise(ctx context.Context, roomID, participantID string, flag roomsmodels.RoomFlag, raisedAt time.Time) (bool, error) {
+	key := roomFlagsKey(roomID)
+
+	var raised *redis.BoolCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		raised = pipe.HSetNX(ctx, key, roomFlagField(participantID, flag), raisedAt.Format(time.RFC3339Nano))
+		pipe.Expire(ctx, key, s.ttl)
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return raised.Val(), nil
+}
+
+func (s *redisRoomFlagStore) Lower(ctx context.Context, roomID, participantID string, flag roomsmodels.RoomFlag) (bool, error) {
+	lowered, err := s.client.HDel(ctx, roomFlagsKey(roomID), roomFlagField(participantID, flag)).Result()
+	if err != nil {
+		return false, err
+	}
+
+	return lowered > 0, nil
+}
+
+func (s *redisRoomFlagStore) ClearParticipant(ctx context.Context, roomID, participantID string) error {
+	fields := make([]string, 0, len(roomsmodels.RoomFlags))
+	for _, flag := range roomsmodels.RoomFlags {
+		fields = append(fields, roomFlagField(participantID, flag))
+	}
+
+	return s.client.HDel(ctx, roomFlagsKey(roomID), fields...).Err()
+}
+
+func (s *redisRoomFlagStore) ClearRoom(ctx context.Context, roomID string) error {
+	return s.client.Del(ctx, roomFlagsKey(roomID)).Err()
+}
+
+func (s *redisRoomFlagStore) List(ctx context.Context, roomID string) ([]RoomRaisedFlag, error) {
+	fields, err := s.client.HGetAll(ctx, roomFlagsKey(roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	raised := make([]RoomRaisedFlag, 0, len(fields))
+	for field, value := range fields {
+		separator := strings.LastIndex(field, "|")
+		if separator < 0 {
+			continue
+		}
+		raisedAt, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			continue
+		}
+		raised = append(raised, RoomRaisedFlag{
+			ParticipantID: field[:separator],
+			Flag:          roomsmodels.RoomFlag(field[separator+1:]),
+			RaisedAt:      raisedAt,
+		})
+	}
+
+	return raised, nil
+}
+
+func roomFlagsKey(roomID string) string {
+	return "rooms:" + roomID + ":flags"
+}
+
+func roomFlagField(participantID string, flag roomsmodels.RoomFlag) string {
+	return participantID + "|" + string(flag)
+}
+
+// memoryRoomFlagStore serves a single instance running without Redis.
+type memoryRoomFlagStore struct {
+	mu sync.Mutex
+	// flags maps room ID to participant ID to the time each flag was raised.
+	flags map[string]map[string]map[roomsmodels.RoomFlag]time.Time
+}
+
+func (s *memoryRoomFlagStore) Raise(_ context.Context, roomID, participantID string, flag roomsmodels.RoomFlag, raisedAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[roomID][participantID][flag]; ok {
+		return false, nil
+	}
+	if s.flags[roomID] == nil {
+		s.flags[roomID] = make(map[string]map[roomsmodels.RoomFlag]time.Time)
+	}
+	if s.flags[roomID][participantID] == nil {
+		s.flags[roomID][participantID] = make(map[roomsmodels.RoomFlag]time.Time)
+	}
+	s.flags[roomID][participantID][flag] = raisedAt
+
+	return true, nil
+}
+
+func (s *memoryRoomFlagStore) Lower(_ context.Context, roomID, participantID string, flag roomsmodels.RoomFlag) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.flags[roomID][participantID][flag]; !ok {
+		return false, nil
+	}
+	delete(s.flags[roomID][participantID], flag)
+	if len(s.flags[roomID][participantID]) == 0 {
+		delete(s.flags[roomID], participantID)
+	}
+	if len(s.flags[roomID]) == 0 {
+		delete(s.flags, roomID)
+	}
+
+	return true, nil
+}
+
+func (s *memoryRoomFlagStore) ClearParticipant(_ context.Context, roomID, participantID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.flags[roomID], participantID)
+	if len(s.flags[roomID]) == 0 {
+		delete(s.flags, roomID)
+	}
+
+	return nil
+}
+
+func (s *memoryRoomFlagStore) ClearRoom(_ context.Context, roomID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.flags, roomID)
+
+	return nil
+}
+
+func (s *memoryRoomFlagStore) List(_ context.Context, roomID string) ([]RoomRaisedFlag, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raised := make([]RoomRaisedFlag, 0)
+	for participantID, flags := range s.flags[roomID] {
+		for flag, raisedAt := range flags {
+			raised = append(raised, RoomRaisedFlag{
+				ParticipantID: participantID,
+				Flag:          flag,
+				RaisedAt:      raisedAt,
+			})
+		}
+	}
+
+	return raised, nil
+}
+
+func roomsSignalLog(message string) string {
+	return logger.Prefix("MODULE", "ROOMS", "SIGNALS", message)
+}
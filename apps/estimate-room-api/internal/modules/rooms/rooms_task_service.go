@@ -1,6 +1,8 @@
 package rooms
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -9,13 +11,16 @@ import (
 	"github.com/google/uuid"
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
 )
 
 type RoomsTaskService interface {
 	CreateTask(roomID, userID string, input CreateTaskInput) (*roomsmodels.RoomTaskModel, error)
+	ImportTasks(roomID, userID string, inputs []CreateTaskInput) ([]*roomsmodels.RoomTaskModel, error)
 	ListTasks(roomID, userID string) ([]*roomsmodels.RoomTaskModel, error)
+	ReorderTasks(roomID, userID string, taskIDs []string, position int) ([]*roomsmodels.RoomTaskModel, error)
 	GetTask(roomID, taskID, userID string) (*roomsmodels.RoomTaskModel, error)
 	UpdateTask(roomID, taskID, userID string, input UpdateTaskInput) (*roomsmodels.RoomTaskModel, error)
 	DeleteTask(roomID, taskID, userID string) error
@@ -27,6 +32,7 @@ type roomsTaskService struct {
 	voteService     RoomsVoteService
 	participantRepo roomsrepositories.RoomParticipantRepository
 	expiryService   RoomsExpiryService
+	wsService       *ws.Service
 	logger          *slog.Logger
 }
 
@@ -43,6 +49,9 @@ type UpdateTaskInput struct {
 	Status             *string
 	IsActive           *bool
 	FinalEstimateValue *string
+	// FinalDimensionEstimates keeps the stored per-dimension estimates when
+	// empty.
+	FinalDimensionEstimates map[string]string
 }
 
 func NewRoomsTaskService(
@@ -51,6 +60,7 @@ func NewRoomsTaskService(
 	voteService RoomsVoteService,
 	participantRepo roomsrepositories.RoomParticipantRepository,
 	expiryService RoomsExpiryService,
+	wsService *ws.Service,
 ) RoomsTaskService {
 	return &roomsTaskService{
 		roomsRepo:       roomsRepo,
@@ -58,15 +68,134 @@ func NewRoomsTaskService(
 		voteService:     voteService,
 		participantRepo: participantRepo,
 		expiryService:   expiryService,
+		wsService:       wsService,
 		logger:          logger.L().With(slog.String("service", "rooms-tasks")),
 	}
 }
 
 func (s *roomsTaskService) CreateTask(roomID, userID string, input CreateTaskInput) (*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
+	task, err := newRoomTask(roomID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	createdTask, err := s.taskRepo.Create(task)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return createdTask, nil
+}
+
+// ImportTasks creates a pasted backlog all-or-nothing and announces the new
+// tasks to the room.
+func (s *roomsTaskService) ImportTasks(roomID, userID string, inputs []CreateTaskInput) ([]*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
+		return nil, err
+	}
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: at least one task is required", apperrors.ErrBadRequest)
+	}
+
+	tasks := make([]*roomsmodels.RoomTaskModel, 0, len(inputs))
+	for idx, input := range inputs {
+		task, err := newRoomTask(roomID, input)
+		if err != nil {
+			return nil, fmt.Errorf("%w: task %d: title is required", apperrors.ErrBadRequest, idx+1)
+		}
+		tasks = append(tasks, task)
+	}
+
+	createdTasks, err := s.taskRepo.CreateMany(context.Background(), tasks)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	if err := s.broadcastTasksImported(roomID, createdTasks); err != nil {
+		s.logger.Error(roomsTaskLog("Failed to broadcast imported tasks"), "room_id", roomID, "err", err)
+	}
+
+	s.logger.Info(roomsTaskLog("Tasks imported"), "room_id", roomID, "count", len(createdTasks))
+
+	return createdTasks, nil
+}
+
+// ReorderTasks moves one or more tasks within the backlog and broadcasts the
+// resulting order to the room.
+func (s *roomsTaskService) ReorderTasks(roomID, userID string, taskIDs []string, position int) ([]*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
+		return nil, err
+	}
+	if len(taskIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one task is required", apperrors.ErrBadRequest)
+	}
+
+	tasks, err := s.taskRepo.Reorder(context.Background(), roomID, taskIDs, position)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	payload := roomTasksReorderedPayload{TaskIDs: make([]string, 0, len(tasks))}
+	for _, task := range tasks {
+		payload.TaskIDs = append(payload.TaskIDs, task.TaskID)
+	}
+	if err := s.broadcast(roomID, RoomsTasksReordered, payload); err != nil {
+		s.logger.Error(roomsTaskLog("Failed to broadcast reordered tasks"), "room_id", roomID, "err", err)
+	}
+
+	return tasks, nil
+}
+
+func (s *roomsTaskService) broadcastTasksImported(roomID string, tasks []*roomsmodels.RoomTaskModel) error {
+	if s.wsService == nil {
+		return nil
+	}
+
+	payload := roomTasksImportedPayload{Tasks: make([]roomSnapshotTask, 0, len(tasks))}
+	for _, task := range tasks {
+		payload.Tasks = append(payload.Tasks, roomSnapshotTask{
+			TaskID:                  task.TaskID,
+			Title:                   task.Title,
+			Description:             task.Description,
+			ExternalKey:             task.ExternalKey,
+			Status:                  task.Status,
+			FinalEstimateValue:      task.FinalEstimateValue,
+			FinalDimensionEstimates: task.FinalDimensionEstimates,
+			Position:                task.Position,
+		})
+	}
+
+	return s.broadcast(roomID, RoomsTasksImported, payload)
+}
+
+func (s *roomsTaskService) broadcast(roomID, eventType string, payload any) error {
+	if s.wsService == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.wsService.Broadcast(ws.Event{
+		Type:    eventType,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func newRoomTask(roomID string, input CreateTaskInput) (*roomsmodels.RoomTaskModel, error) {
 	title := strings.TrimSpace(input.Title)
 	if title == "" {
 		return nil, apperrors.ErrBadRequest
@@ -82,7 +211,7 @@ func (s *roomsTaskService) CreateTask(roomID, userID string, input CreateTaskInp
 		externalKey = &input.ExternalKey
 	}
 
-	task := &roomsmodels.RoomTaskModel{
+	return &roomsmodels.RoomTaskModel{
 		TaskID:      uuid.NewString(),
 		RoomID:      roomID,
 		Title:       title,
@@ -90,20 +219,11 @@ func (s *roomsTaskService) CreateTask(roomID, userID string, input CreateTaskInp
 		ExternalKey: externalKey,
 		Status:      "PENDING",
 		IsActive:    false,
-	}
-
-	createdTask, err := s.taskRepo.Create(task)
-	if err != nil {
-		return nil, err
-	}
-
-	s.expiryService.TouchActivity(roomID)
-
-	return createdTask, nil
+	}, nil
 }
 
 func (s *roomsTaskService) ListTasks(roomID, userID string) ([]*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureRoomAdmin(roomID, userID); err != nil {
+	if _, err := s.ensureRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
@@ -111,7 +231,7 @@ func (s *roomsTaskService) ListTasks(roomID, userID string) ([]*roomsmodels.Room
 }
 
 func (s *roomsTaskService) GetTask(roomID, taskID, userID string) (*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureRoomAdmin(roomID, userID); err != nil {
+	if _, err := s.ensureRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
@@ -119,7 +239,7 @@ func (s *roomsTaskService) GetTask(roomID, taskID, userID string) (*roomsmodels.
 }
 
 func (s *roomsTaskService) UpdateTask(roomID, taskID, userID string, input UpdateTaskInput) (*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
@@ -154,7 +274,7 @@ func (s *roomsTaskService) UpdateTask(roomID, taskID, userID string, input Updat
 }
 
 func (s *roomsTaskService) DeleteTask(roomID, taskID, userID string) error {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
 		return err
 	}
 
@@ -180,7 +300,7 @@ func (s *roomsTaskService) applyVoteStateUpdate(
 
 	activateRequested := (input.IsActive != nil && *input.IsActive) || status == "VOTING"
 	deactivateRequested := input.IsActive != nil && !*input.IsActive
-	finalizeRequested := input.FinalEstimateValue != nil || status == "ESTIMATED"
+	finalizeRequested := input.FinalEstimateValue != nil || len(input.FinalDimensionEstimates) > 0 || status == "ESTIMATED"
 
 	if activateRequested && (finalizeRequested || status == "SKIPPED" || status == "PENDING" || deactivateRequested) {
 		return nil, fmt.Errorf("%w: conflicting task state update", apperrors.ErrBadRequest)
@@ -207,17 +327,13 @@ func (s *roomsTaskService) applyVoteStateUpdate(
 			}
 			value = strings.TrimSpace(*task.FinalEstimateValue)
 		}
-		return s.voteService.FinalizeTask(roomID, task.TaskID, userID, value)
-	case status == "SKIPPED":
-		task.Status = "SKIPPED"
-		task.IsActive = false
-		task.FinalEstimateValue = nil
-		updatedTask, err := s.taskRepo.Update(roomID, task)
-		if err != nil {
-			return nil, err
+		dimensionValues := input.FinalDimensionEstimates
+		if len(dimensionValues) == 0 {
+			dimensionValues = task.FinalDimensionEstimates
 		}
-		s.expiryService.TouchActivity(roomID)
-		return updatedTask, nil
+		return s.voteService.FinalizeTask(roomID, task.TaskID, userID, value, dimensionValues)
+	case status == "SKIPPED":
+		return s.voteService.SkipTask(roomID, task.TaskID, userID)
 	case status == "PENDING" || deactivateRequested:
 		task.Status = "PENDING"
 		task.IsActive = false
@@ -233,7 +349,7 @@ func (s *roomsTaskService) applyVoteStateUpdate(
 }
 
 func hasVoteStateInput(input UpdateTaskInput) bool {
-	return input.Status != nil || input.IsActive != nil || input.FinalEstimateValue != nil
+	return input.Status != nil || input.IsActive != nil || input.FinalEstimateValue != nil || len(input.FinalDimensionEstimates) > 0
 }
 
 func applyTaskMetadata(task *roomsmodels.RoomTaskModel, input UpdateTaskInput) (bool, error) {
@@ -279,7 +395,8 @@ func applyTaskMetadata(task *roomsmodels.RoomTaskModel, input UpdateTaskInput) (
 	return changed, nil
 }
 
-func (s *roomsTaskService) ensureRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+// ensureRoomFacilitator allows the room admin and co-facilitators.
+func (s *roomsTaskService) ensureRoomFacilitator(roomID, userID string) (*roomsmodels.RoomsModel, error) {
 	room, err := s.roomsRepo.FindByID(roomID)
 	if err != nil {
 		return nil, err
@@ -293,19 +410,18 @@ func (s *roomsTaskService) ensureRoomAdmin(roomID, userID string) (*roomsmodels.
 		return nil, err
 	}
 
-	if participant.Role != roomsmodels.RoomParticipantRoleAdmin {
+	if !participant.Role.CanFacilitate() {
 		return nil, apperrors.ErrForbidden
 	}
-
-	if room.AdminUserID != userID {
+	if participant.Role == roomsmodels.RoomParticipantRoleAdmin && room.AdminUserID != userID {
 		return nil, apperrors.ErrForbidden
 	}
 
 	return room, nil
 }
 
-func (s *roomsTaskService) ensureActiveRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, error) {
-	room, err := s.ensureRoomAdmin(roomID, userID)
+func (s *roomsTaskService) ensureActiveRoomFacilitator(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+	room, err := s.ensureRoomFacilitator(roomID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -315,3 +431,7 @@ func (s *roomsTaskService) ensureActiveRoomAdmin(roomID, userID string) (*roomsm
 
 	return room, nil
 }
+
+func roomsTaskLog(message string) string {
+	return logger.Prefix("MODULE", "ROOMS", "TASKS", message)
+}
@@ -0,0 +1,265 @@
+package rooms
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+)
+
+const roomTimersChannel = "rooms:timers"
+
+const (
+	roomTimerActionSchedule = "SCHEDULE"
+	roomTimerActionCancel   = "CANCEL"
+)
+
+// RoomsTimerService runs round voting timers. Schedules and cancellations are
+// fanned out over pub/sub so every instance arms the same timer; the round
+// reveal itself is conditional, so only one instance acts on expiry.
+type RoomsTimerService interface {
+	Schedule(timer RoomRoundTimer)
+	Cancel(timer RoomRoundTimer)
+	OnExpire(handler RoomTimerExpiredHandler)
+	Start(ctx context.Context)
+}
+
+type RoomRoundTimer struct {
+	RoomID          string    `json:"roomId"`
+	TaskID          string    `json:"taskId"`
+	RoundNumber     int       `json:"roundNumber"`
+	DurationSeconds int       `json:"durationSeconds"`
+	EndsAt          time.Time `json:"endsAt"`
+}
+
+type RoomTimerExpiredHandler func(timer RoomRoundTimer)
+
+type roomTimerMessage struct {
+	Action string         `json:"action"`
+	Timer  RoomRoundTimer `json:"timer"`
+}
+
+type roomTimerStartedPayload struct {
+	TaskID          string    `json:"taskId"`
+	RoundNumber     int       `json:"roundNumber"`
+	DurationSeconds int       `json:"durationSeconds"`
+	EndsAt          time.Time `json:"endsAt"`
+}
+
+type roomTimerStoppedPayload struct {
+	TaskID      string `json:"taskId"`
+	RoundNumber int    `json:"roundNumber"`
+}
+
+type armedRoomTimer struct {
+	timer RoomRoundTimer
+	stop  *time.Timer
+}
+
+type roomsTimerService struct {
+	pubSub    ws.PubSub
+	wsService *ws.Service
+	roundRepo roomsrepositories.RoomTaskRoundRepository
+	mu        sync.Mutex
+	timers    map[string]*armedRoomTimer
+	handlers  []RoomTimerExpiredHandler
+	logger    *slog.Logger
+}
+
+func NewRoomsTimerService(
+	pubSub ws.PubSub,
+	wsService *ws.Service,
+	roundRepo roomsrepositories.RoomTaskRoundRepository,
+) RoomsTimerService {
+	s := &roomsTimerService{
+		pubSub:    pubSub,
+		wsService: wsService,
+		roundRepo: roundRepo,
+		timers:    make(map[string]*armedRoomTimer),
+		handlers:  make([]RoomTimerExpiredHandler, 0),
+		logger:    logger.L().With(slog.String("service", "rooms-timers")),
+	}
+
+	if pubSub != nil {
+		pubSub.Subscribe(roomTimersChannel, s.handleMessage)
+	}
+
+	return s
+}
+
+func (s *roomsTimerService) Schedule(timer RoomRoundTimer) {
+	if strings.TrimSpace(timer.RoomID) == "" || strings.TrimSpace(timer.TaskID) == "" {
+		return
+	}
+
+	s.publish(roomTimerMessage{Action: roomTimerActionSchedule, Timer: timer})
+
+	if err := s.broadcast(timer.RoomID, RoomsTimerStarted, roomTimerStartedPayload{
+		TaskID:          timer.TaskID,
+		RoundNumber:     timer.RoundNumber,
+		DurationSeconds: timer.DurationSeconds,
+		EndsAt:          timer.EndsAt,
+	}); err != nil {
+		s.logger.Error(roomsTimerLog("Failed to broadcast timer started"), "room_id", timer.RoomID, "task_id", timer.TaskID, "err", err)
+	}
+}
+
+func (s *roomsTimerService) Cancel(timer RoomRoundTimer) {
+	if strings.TrimSpace(timer.RoomID) == "" {
+		return
+	}
+
+	s.publish(roomTimerMessage{Action: roomTimerActionCancel, Timer: timer})
+
+	if err := s.broadcast(timer.RoomID, RoomsTimerCancelled, roomTimerStoppedPayload{
+		TaskID:      timer.TaskID,
+		RoundNumber: timer.RoundNumber,
+	}); err != nil {
+		s.logger.Error(roomsTimerLog("Failed to broadcast timer cancelled"), "room_id", timer.RoomID, "task_id", timer.TaskID, "err", err)
+	}
+}
+
+func (s *roomsTimerService) OnExpire(handler RoomTimerExpiredHandler) {
+	if handler == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.handlers = append(s.handlers, handler)
+	s.mu.Unlock()
+}
+
+// Start re-arms timers persisted by rounds that were running before this
+// instance booted and stops every local timer once ctx is done.
+func (s *roomsTimerService) Start(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	timers, err := s.roundRepo.ListActiveTimers(ctx)
+	if err != nil {
+		s.logger.Error(roomsTimerLog("Failed to load active timers"), "err", err)
+	} else {
+		for _, timer := range timers {
+			s.arm(RoomRoundTimer{
+				RoomID:          timer.RoomID,
+				TaskID:          timer.TaskID,
+				RoundNumber:     timer.RoundNumber,
+				DurationSeconds: timer.TimerDurationSeconds,
+				EndsAt:          timer.TimerEndsAt,
+			})
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for roomID, armed := range s.timers {
+			armed.stop.Stop()
+			delete(s.timers, roomID)
+		}
+	}()
+}
+
+func (s *roomsTimerService) publish(message roomTimerMessage) {
+	if s.pubSub == nil {
+		s.apply(message)
+		return
+	}
+
+	if err := s.pubSub.Publish(roomTimersChannel, message); err != nil {
+		s.logger.Error(roomsTimerLog("Failed to publish timer message"), "room_id", message.Timer.RoomID, "action", message.Action, "err", err)
+		s.apply(message)
+	}
+}
+
+func (s *roomsTimerService) handleMessage(data []byte) {
+	message := roomTimerMessage{}
+	if err := json.Unmarshal(data, &message); err != nil {
+		s.logger.Warn(roomsTimerLog("Timer message ignored: invalid payload"), "err", err)
+		return
+	}
+
+	s.apply(message)
+}
+
+func (s *roomsTimerService) apply(message roomTimerMessage) {
+	switch message.Action {
+	case roomTimerActionSchedule:
+		s.arm(message.Timer)
+	case roomTimerActionCancel:
+		s.disarm(message.Timer)
+	}
+}
+
+func (s *roomsTimerService) arm(timer RoomRoundTimer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.timers[timer.RoomID]; ok {
+		existing.stop.Stop()
+	}
+
+	armed := &armedRoomTimer{timer: timer}
+	armed.stop = time.AfterFunc(time.Until(timer.EndsAt), func() {
+		s.fire(armed)
+	})
+	s.timers[timer.RoomID] = armed
+}
+
+func (s *roomsTimerService) disarm(timer RoomRoundTimer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.timers[timer.RoomID]
+	if !ok || existing.timer.TaskID != timer.TaskID || existing.timer.RoundNumber != timer.RoundNumber {
+		return
+	}
+
+	existing.stop.Stop()
+	delete(s.timers, timer.RoomID)
+}
+
+func (s *roomsTimerService) fire(armed *armedRoomTimer) {
+	s.mu.Lock()
+	if s.timers[armed.timer.RoomID] != armed {
+		s.mu.Unlock()
+		return
+	}
+	delete(s.timers, armed.timer.RoomID)
+	handlers := append([]RoomTimerExpiredHandler(nil), s.handlers...)
+	s.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(armed.timer)
+	}
+}
+
+func (s *roomsTimerService) broadcast(roomID, eventType string, payload any) error {
+	if s.wsService == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.wsService.Broadcast(ws.Event{
+		Type:    eventType,
+		RoomID:  roomID,
+		Payload: data,
+	})
+}
+
+func roomsTimerLog(message string) string {
+	return logger.Prefix("MODULE", "ROOMS", "TIMERS", message)
+}
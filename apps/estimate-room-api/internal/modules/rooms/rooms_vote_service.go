@@ -1,73 +1,148 @@
 package rooms
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+const (
+	minAsyncVotingWindow = 10 * time.Minute
+	maxAsyncVotingWindow = 14 * 24 * time.Hour
 )
 
 type RoomsVoteService interface {
 	SetCurrentTask(roomID, taskID, userID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskRoundModel, error)
-	CastVote(roomID string, participant *roomsmodels.RoomParticipantModel, value string) (*CastVoteResult, error)
+	ReopenTask(roomID, taskID, userID string, eligibleParticipantIDs []string) (*ReopenTaskResult, error)
+	OpenTask(roomID, taskID, userID string, deadline time.Time) (*OpenTaskResult, error)
+	CastVote(roomID string, participant *roomsmodels.RoomParticipantModel, dimension, value string) (*CastVoteResult, error)
+	RetractVote(roomID string, participant *roomsmodels.RoomParticipantModel, dimension string) (*RetractVoteResult, error)
 	RevealCurrentRound(roomID, userID string) (*RevealVotesResult, error)
+	AutoRevealRound(roomID, taskID string, roundNumber int) (*RevealVotesResult, error)
+	RevealExpiredRound(roomID, taskID string, roundNumber int) (*RevealVotesResult, error)
 	StartNextRound(roomID, userID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskRoundModel, error)
-	FinalizeCurrentTask(roomID, userID, value string) (*roomsmodels.RoomTaskModel, error)
-	FinalizeTask(roomID, taskID, userID, value string) (*roomsmodels.RoomTaskModel, error)
+	FinalizeCurrentTask(roomID, userID, value string, dimensionValues map[string]string) (*roomsmodels.RoomTaskModel, error)
+	FinalizeTask(roomID, taskID, userID, value string, dimensionValues map[string]string) (*roomsmodels.RoomTaskModel, error)
+	SkipCurrentTask(roomID, userID string) (*roomsmodels.RoomTaskModel, error)
+	SkipTask(roomID, taskID, userID string) (*roomsmodels.RoomTaskModel, error)
+	SetParticipantObserver(roomID, userID, participantID string, observer bool) (*SetParticipantObserverResult, error)
+	DropFromActiveRoundWithDB(db bun.IDB, roomID, participantID string) (*RoundEligibilityChange, error)
 }
 
+// CastVoteResult reports the round after a vote. In a multi-criteria room a
+// participant only counts as voted once every dimension has their vote;
+// VotedDimensions lists the dimensions they have voted in so far.
 type CastVoteResult struct {
 	Task                   *roomsmodels.RoomTaskModel
 	Round                  *roomsmodels.RoomTaskRoundModel
+	VotedDimensions        []string
 	VotedParticipantIDs    []string
 	EligibleParticipantIDs []string
 	AllVotesCast           bool
+	AutoReveal             bool
+}
+
+// ReopenTaskResult describes an estimated or skipped task that is being voted
+// on again. PreviousTask is the task that was current before, if any.
+type ReopenTaskResult struct {
+	Task                       *roomsmodels.RoomTaskModel
+	PreviousTask               *roomsmodels.RoomTaskModel
+	Round                      *roomsmodels.RoomTaskRoundModel
+	PreviousStatus             string
+	PreviousFinalEstimateValue *string
+}
+
+// OpenTaskResult describes a task opened for asynchronous voting.
+// PreviousTask is the task that was current before, if any.
+type OpenTaskResult struct {
+	Task         *roomsmodels.RoomTaskModel
+	PreviousTask *roomsmodels.RoomTaskModel
+	Round        *roomsmodels.RoomTaskRoundModel
+}
+
+type RetractVoteResult struct {
+	Task            *roomsmodels.RoomTaskModel
+	Round           *roomsmodels.RoomTaskRoundModel
+	VotedDimensions []string
 }
 
 type RevealVotesResult struct {
-	Task     *roomsmodels.RoomTaskModel
-	Round    *roomsmodels.RoomTaskRoundModel
-	Votes    []*roomsmodels.RoomVoteModel
-	AllVoted bool
+	Deck        roomsmodels.RoomDeck
+	Dimensions  roomsmodels.RoomDimensions
+	Options     roomsmodels.RoomOptions
+	AdminUserID string
+	Task        *roomsmodels.RoomTaskModel
+	Round       *roomsmodels.RoomTaskRoundModel
+	Votes       []*roomsmodels.RoomVoteModel
+	AllVoted    bool
+}
+
+// RoundEligibilityChange describes the active round after a participant left
+// its eligible voters. Task and Round stay nil when nothing changed.
+type RoundEligibilityChange struct {
+	Task                *roomsmodels.RoomTaskModel
+	Round               *roomsmodels.RoomTaskRoundModel
+	VotedParticipantIDs []string
+	AllVotesCast        bool
+	AutoReveal          bool
+}
+
+// SetParticipantObserverResult carries the updated participant and, when an
+// observer left the eligible voters of the active round, that round.
+type SetParticipantObserverResult struct {
+	Participant *roomsmodels.RoomParticipantModel
+	RoundEligibilityChange
 }
 
 type roomsVoteService struct {
+	db              *bun.DB
 	roomsRepo       roomsrepositories.RoomsRepository
 	taskRepo        roomsrepositories.RoomTaskRepository
 	voteRepo        roomsrepositories.RoomVoteRepository
 	roundRepo       roomsrepositories.RoomTaskRoundRepository
 	participantRepo roomsrepositories.RoomParticipantRepository
 	expiryService   RoomsExpiryService
+	timerService    RoomsTimerService
 	logger          *slog.Logger
 }
 
 func NewRoomsVoteService(
+	db *bun.DB,
 	roomsRepo roomsrepositories.RoomsRepository,
 	taskRepo roomsrepositories.RoomTaskRepository,
 	voteRepo roomsrepositories.RoomVoteRepository,
 	roundRepo roomsrepositories.RoomTaskRoundRepository,
 	participantRepo roomsrepositories.RoomParticipantRepository,
 	expiryService RoomsExpiryService,
+	timerService RoomsTimerService,
 ) RoomsVoteService {
 	return &roomsVoteService{
+		db:              db,
 		roomsRepo:       roomsRepo,
 		taskRepo:        taskRepo,
 		voteRepo:        voteRepo,
 		roundRepo:       roundRepo,
 		participantRepo: participantRepo,
 		expiryService:   expiryService,
+		timerService:    timerService,
 		logger:          logger.L().With(slog.String("service", "rooms-votes")),
 	}
 }
 
 func (s *roomsVoteService) SetCurrentTask(roomID, taskID, userID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskRoundModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
 		return nil, nil, nil, err
 	}
 
@@ -81,16 +156,170 @@ func (s *roomsVoteService) SetCurrentTask(roomID, taskID, userID string, eligibl
 		return nil, nil, nil, err
 	}
 
+	round, err = s.startRoundTimer(room, round)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	s.expiryService.TouchActivity(roomID)
 
 	return task, previousTask, round, nil
 }
 
-func (s *roomsVoteService) CastVote(roomID string, participant *roomsmodels.RoomParticipantModel, value string) (*CastVoteResult, error) {
+// ReopenTask moves an estimated or skipped task back to voting in a new
+// round. Earlier rounds and votes are kept, and the replaced outcome is
+// recorded so history can show it.
+func (s *roomsVoteService) ReopenTask(roomID, taskID, userID string, eligibleParticipantIDs []string) (*ReopenTaskResult, error) {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The replaced outcome only survives in the reestimation record, so the
+	// task is reset only together with the new round and that record.
+	eligibleParticipantIDs = normalizeParticipantIDs(eligibleParticipantIDs)
+	var reopened *roomsrepositories.ReopenedTask
+	var round *roomsmodels.RoomTaskRoundModel
+	err = s.db.RunInTx(context.Background(), nil, func(ctx context.Context, tx bun.Tx) error {
+		taskRepo := roomsrepositories.NewRoomTaskRepository(tx)
+		roundRepo := roomsrepositories.NewRoomTaskRoundRepository(tx)
+		reestimationRepo := roomsrepositories.NewRoomTaskReestimationRepository(tx)
+
+		reopened, err = taskRepo.ReopenTask(roomID, taskID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := roundRepo.GetCurrent(reopened.Task.TaskID); errors.Is(err, apperrors.ErrNotFound) {
+			// A task skipped before it was ever voted on starts at round one.
+			round, err = roundRepo.GetOrCreateCurrent(reopened.Task.TaskID, eligibleParticipantIDs)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		} else {
+			round, err = roundRepo.Advance(reopened.Task.TaskID, eligibleParticipantIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = reestimationRepo.Create(&roomsmodels.RoomTaskReestimationModel{
+			TaskReestimationID:         uuid.NewString(),
+			TaskID:                     reopened.Task.TaskID,
+			RoundNumber:                round.RoundNumber,
+			PreviousStatus:             reopened.Replaced.Status,
+			PreviousFinalEstimateValue: reopened.Replaced.FinalEstimateValue,
+			ReopenedByUserID:           userID,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	round, err = s.startRoundTimer(room, round)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return &ReopenTaskResult{
+		Task:                       reopened.Task,
+		PreviousTask:               reopened.PreviousTask,
+		Round:                      round,
+		PreviousStatus:             reopened.Replaced.Status,
+		PreviousFinalEstimateValue: reopened.Replaced.FinalEstimateValue,
+	}, nil
+}
+
+// OpenTask makes a task the current one of an async room and gives its round
+// a deadline. Every active voter of the room is eligible, not only those
+// online. Opening the open task again moves its deadline, and a revealed task
+// is opened in a new round; another task waits until the open one is
+// revealed.
+func (s *roomsVoteService) OpenTask(roomID, taskID, userID string, deadline time.Time) (*OpenTaskResult, error) {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.IsAsync() {
+		return nil, fmt.Errorf("%w: only async rooms open tasks with a deadline", apperrors.ErrBadRequest)
+	}
+
+	window := time.Until(deadline)
+	if window < minAsyncVotingWindow || window > maxAsyncVotingWindow {
+		return nil, fmt.Errorf("%w: deadline must be between 10 minutes and 14 days away", apperrors.ErrBadRequest)
+	}
+
+	if current, err := s.taskRepo.FindCurrentVotingTask(roomID); err == nil && current.TaskID != taskID {
+		currentRound, err := s.roundRepo.GetCurrent(current.TaskID)
+		if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		if currentRound != nil && currentRound.Status == roomsmodels.RoomTaskRoundStatusActive {
+			return nil, fmt.Errorf("%w: another task is still open for voting", apperrors.ErrConflict)
+		}
+	} else if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+		return nil, err
+	}
+
+	eligibleParticipantIDs, err := s.roomVoterIDs(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	task, previousTask, err := s.taskRepo.SetCurrentVotingTask(roomID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := s.roundRepo.GetOrCreateCurrent(task.TaskID, eligibleParticipantIDs)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status == roomsmodels.RoomTaskRoundStatusRevealed {
+		round, err = s.roundRepo.Advance(task.TaskID, eligibleParticipantIDs)
+	} else {
+		// A round that was already open keeps its voters and gains anyone
+		// who joined since.
+		round, err = s.roundRepo.SetEligibleParticipants(
+			task.TaskID,
+			round.RoundNumber,
+			normalizeParticipantIDs(append(append([]string(nil), round.EligibleParticipantIDs...), eligibleParticipantIDs...)),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	endsAt := deadline.UTC().Truncate(time.Millisecond)
+	round, err = s.scheduleRoundTimer(room, round, time.Until(endsAt), endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return &OpenTaskResult{
+		Task:         task,
+		PreviousTask: previousTask,
+		Round:        round,
+	}, nil
+}
+
+// CastVote stores the participant's vote in the active round. Multi-criteria
+// rooms take one vote per dimension, each from the dimension's deck; classic
+// rooms take a single vote from the room deck under a blank dimension.
+func (s *roomsVoteService) CastVote(roomID string, participant *roomsmodels.RoomParticipantModel, dimension, value string) (*CastVoteResult, error) {
 	if participant == nil {
 		return nil, apperrors.ErrUnauthorized
 	}
-	if !isVotingParticipantRole(participant.Role) {
+	// Eligibility is fixed when the round starts, so a participant promoted
+	// to admin mid-round keeps their vote; only observers give it up.
+	if participant.Role == roomsmodels.RoomParticipantRoleObserver {
 		return nil, apperrors.ErrForbidden
 	}
 
@@ -111,16 +340,36 @@ func (s *roomsVoteService) CastVote(roomID string, participant *roomsmodels.Room
 	if round.Status != roomsmodels.RoomTaskRoundStatusActive {
 		return nil, fmt.Errorf("%w: round already revealed", apperrors.ErrBadRequest)
 	}
-	if !containsParticipantID(round.EligibleParticipantIDs, participant.RoomParticipantID) {
+	// Async voters may join after the round was opened and become eligible
+	// with their first vote. The room admin never joins, as they are never
+	// counted among the room's voters.
+	joining := !containsParticipantID(round.EligibleParticipantIDs, participant.RoomParticipantID)
+	if joining && (!room.IsAsync() || !isVotingParticipantRole(participant.Role)) {
 		return nil, apperrors.ErrForbidden
 	}
 
+	trimmedDimension := strings.TrimSpace(dimension)
+	deck, err := voteDeck(room, trimmedDimension)
+	if err != nil {
+		return nil, err
+	}
+
 	trimmedValue := strings.TrimSpace(value)
-	if !isDeckValueAllowed(room.Deck.Values, trimmedValue) {
-		return nil, fmt.Errorf("%w: vote value is not in the room deck", apperrors.ErrBadRequest)
+	if !isDeckValueAllowed(deck.Values, trimmedValue) {
+		return nil, fmt.Errorf("%w: vote value is not in the deck", apperrors.ErrBadRequest)
 	}
 
-	if _, err := s.voteRepo.Upsert(task.TaskID, participant.RoomParticipantID, round.RoundNumber, trimmedValue); err != nil {
+	if joining {
+		round, err = s.roundRepo.AddEligibleParticipant(task.TaskID, round.RoundNumber, participant.RoomParticipantID)
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return nil, fmt.Errorf("%w: round already revealed", apperrors.ErrBadRequest)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.voteRepo.Upsert(task.TaskID, participant.RoomParticipantID, round.RoundNumber, trimmedDimension, trimmedValue); err != nil {
 		return nil, err
 	}
 
@@ -129,7 +378,7 @@ func (s *roomsVoteService) CastVote(roomID string, participant *roomsmodels.Room
 		return nil, err
 	}
 
-	votedParticipantIDs := filterParticipantIDs(uniqueSortedParticipantIDs(votes), round.EligibleParticipantIDs)
+	votedParticipantIDs := filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs)
 	allVotesCast := len(round.EligibleParticipantIDs) > 0 && sameParticipantIDs(votedParticipantIDs, round.EligibleParticipantIDs)
 
 	s.expiryService.TouchActivity(roomID)
@@ -137,14 +386,72 @@ func (s *roomsVoteService) CastVote(roomID string, participant *roomsmodels.Room
 	return &CastVoteResult{
 		Task:                   task,
 		Round:                  round,
+		VotedDimensions:        votedDimensions(votes, room.Dimensions, participant.RoomParticipantID),
 		VotedParticipantIDs:    votedParticipantIDs,
 		EligibleParticipantIDs: append([]string(nil), round.EligibleParticipantIDs...),
 		AllVotesCast:           allVotesCast,
+		AutoReveal:             revealsWhenAllVoted(room),
+	}, nil
+}
+
+// RetractVote clears the participant's vote while the round is still active.
+// A blank dimension clears their votes in every dimension.
+func (s *roomsVoteService) RetractVote(roomID string, participant *roomsmodels.RoomParticipantModel, dimension string) (*RetractVoteResult, error) {
+	if participant == nil {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	room, err := s.ensureActiveRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	var dimensionFilter *string
+	if trimmedDimension := strings.TrimSpace(dimension); trimmedDimension != "" {
+		if _, err := voteDeck(room, trimmedDimension); err != nil {
+			return nil, err
+		}
+		dimensionFilter = &trimmedDimension
+	}
+
+	task, err := s.taskRepo.FindCurrentVotingTask(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	round, err := s.roundRepo.GetOrCreateCurrent(task.TaskID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status != roomsmodels.RoomTaskRoundStatusActive {
+		return nil, fmt.Errorf("%w: round already revealed", apperrors.ErrBadRequest)
+	}
+
+	retracted, err := s.voteRepo.Retract(task.TaskID, participant.RoomParticipantID, round.RoundNumber, dimensionFilter)
+	if err != nil {
+		return nil, err
+	}
+	if !retracted {
+		return nil, fmt.Errorf("%w: no vote to retract", apperrors.ErrBadRequest)
+	}
+
+	votes, err := s.voteRepo.ListByTaskAndRound(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return &RetractVoteResult{
+		Task:            task,
+		Round:           round,
+		VotedDimensions: votedDimensions(votes, room.Dimensions, participant.RoomParticipantID),
 	}, nil
 }
 
 func (s *roomsVoteService) RevealCurrentRound(roomID, userID string) (*RevealVotesResult, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -164,27 +471,37 @@ func (s *roomsVoteService) RevealCurrentRound(roomID, userID string) (*RevealVot
 	}
 
 	allVoted := len(round.EligibleParticipantIDs) > 0 &&
-		sameParticipantIDs(filterParticipantIDs(uniqueSortedParticipantIDs(votes), round.EligibleParticipantIDs), round.EligibleParticipantIDs)
+		sameParticipantIDs(filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs), round.EligibleParticipantIDs)
 
-	if round.Status == roomsmodels.RoomTaskRoundStatusActive {
-		round, err = s.roundRepo.MarkRevealed(task.TaskID, round.RoundNumber)
-		if err != nil {
-			return nil, err
-		}
+	// The timer and auto-reveal may get there first; only one reveal is
+	// announced.
+	activeRound := round
+	round, revealed, err := s.roundRepo.MarkRevealedIfActive(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
 	}
+	if !revealed {
+		return nil, fmt.Errorf("%w: round already revealed", apperrors.ErrConflict)
+	}
+	s.cancelRoundTimer(roomID, activeRound)
 
 	s.expiryService.TouchActivity(roomID)
 
 	return &RevealVotesResult{
-		Task:     task,
-		Round:    round,
-		Votes:    votes,
-		AllVoted: allVoted,
+		Deck:        room.Deck,
+		Dimensions:  room.Dimensions,
+		Options:     room.Options,
+		AdminUserID: room.AdminUserID,
+		Task:        task,
+		Round:       round,
+		Votes:       votes,
+		AllVoted:    allVoted,
 	}, nil
 }
 
 func (s *roomsVoteService) StartNextRound(roomID, userID string, eligibleParticipantIDs []string) (*roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskRoundModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
 		return nil, nil, err
 	}
 
@@ -206,26 +523,197 @@ func (s *roomsVoteService) StartNextRound(roomID, userID string, eligiblePartici
 		return nil, nil, err
 	}
 
+	round, err = s.startRoundTimer(room, round)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	s.expiryService.TouchActivity(roomID)
 
 	return task, round, nil
 }
 
-func (s *roomsVoteService) FinalizeCurrentTask(roomID, userID, value string) (*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+// AutoRevealRound reveals the round once every eligible participant has voted
+// in a room with auto-reveal enabled or in an async room. It returns ErrConflict when the round
+// is no longer the active one, e.g. because another instance revealed it.
+func (s *roomsVoteService) AutoRevealRound(roomID, taskID string, roundNumber int) (*RevealVotesResult, error) {
+	room, task, round, err := s.findActiveRound(roomID, taskID, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !revealsWhenAllVoted(room) {
+		return nil, fmt.Errorf("%w: auto-reveal is disabled", apperrors.ErrBadRequest)
+	}
+
+	votes, err := s.voteRepo.ListByTaskAndRound(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	votedParticipantIDs := filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs)
+	if len(round.EligibleParticipantIDs) == 0 || !sameParticipantIDs(votedParticipantIDs, round.EligibleParticipantIDs) {
+		return nil, fmt.Errorf("%w: not every eligible participant has voted", apperrors.ErrBadRequest)
+	}
+
+	revealed, err := s.revealActiveRound(room, task, round, votes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cancelRoundTimer(roomID, round)
+
+	return revealed, nil
+}
+
+// RevealExpiredRound reveals the round whose voting timer ran out. Timers
+// that were restarted or whose round was already revealed report
+// ErrConflict.
+func (s *roomsVoteService) RevealExpiredRound(roomID, taskID string, roundNumber int) (*RevealVotesResult, error) {
+	room, task, round, err := s.findActiveRound(roomID, taskID, roundNumber)
+	if err != nil {
+		return nil, err
+	}
+	if round.TimerEndsAt == nil || round.TimerEndsAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: round timer has not expired", apperrors.ErrConflict)
+	}
+
+	votes, err := s.voteRepo.ListByTaskAndRound(task.TaskID, round.RoundNumber)
+	if err != nil {
 		return nil, err
 	}
 
+	return s.revealActiveRound(room, task, round, votes)
+}
+
+func (s *roomsVoteService) findActiveRound(
+	roomID, taskID string,
+	roundNumber int,
+) (*roomsmodels.RoomsModel, *roomsmodels.RoomTaskModel, *roomsmodels.RoomTaskRoundModel, error) {
+	room, err := s.ensureActiveRoom(roomID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
 	task, err := s.taskRepo.FindCurrentVotingTask(roomID)
 	if err != nil {
+		return nil, nil, nil, err
+	}
+	if task.TaskID != taskID {
+		return nil, nil, nil, fmt.Errorf("%w: task is no longer current", apperrors.ErrConflict)
+	}
+
+	round, err := s.roundRepo.GetCurrent(task.TaskID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if round.RoundNumber != roundNumber || round.Status != roomsmodels.RoomTaskRoundStatusActive {
+		return nil, nil, nil, fmt.Errorf("%w: round is no longer active", apperrors.ErrConflict)
+	}
+
+	return room, task, round, nil
+}
+
+func (s *roomsVoteService) revealActiveRound(
+	room *roomsmodels.RoomsModel,
+	task *roomsmodels.RoomTaskModel,
+	round *roomsmodels.RoomTaskRoundModel,
+	votes []*roomsmodels.RoomVoteModel,
+) (*RevealVotesResult, error) {
+	revealedRound, revealed, err := s.roundRepo.MarkRevealedIfActive(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+	if !revealed {
+		return nil, fmt.Errorf("%w: round is no longer active", apperrors.ErrConflict)
+	}
+
+	s.expiryService.TouchActivity(room.RoomID)
+
+	allVoted := len(round.EligibleParticipantIDs) > 0 &&
+		sameParticipantIDs(filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs), round.EligibleParticipantIDs)
+
+	return &RevealVotesResult{
+		Deck:        room.Deck,
+		Dimensions:  room.Dimensions,
+		Options:     room.Options,
+		AdminUserID: room.AdminUserID,
+		Task:        task,
+		Round:       revealedRound,
+		Votes:       votes,
+		AllVoted:    allVoted,
+	}, nil
+}
+
+// startRoundTimer persists the deadline of a new active round and schedules
+// it when the room has a voting timer.
+func (s *roomsVoteService) startRoundTimer(
+	room *roomsmodels.RoomsModel,
+	round *roomsmodels.RoomTaskRoundModel,
+) (*roomsmodels.RoomTaskRoundModel, error) {
+	if room.Options.VotingTimerSeconds <= 0 || round.Status != roomsmodels.RoomTaskRoundStatusActive {
+		return round, nil
+	}
+
+	duration := room.Options.VotingTimer()
+	return s.scheduleRoundTimer(room, round, duration, time.Now().Add(duration).UTC().Truncate(time.Millisecond))
+}
+
+// scheduleRoundTimer persists the round deadline and arms the timer that
+// reveals the round once it passes.
+func (s *roomsVoteService) scheduleRoundTimer(
+	room *roomsmodels.RoomsModel,
+	round *roomsmodels.RoomTaskRoundModel,
+	duration time.Duration,
+	endsAt time.Time,
+) (*roomsmodels.RoomTaskRoundModel, error) {
+	timedRound, err := s.roundRepo.StartTimer(round.TaskID, round.RoundNumber, duration, endsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.timerService != nil {
+		s.timerService.Schedule(RoomRoundTimer{
+			RoomID:          room.RoomID,
+			TaskID:          timedRound.TaskID,
+			RoundNumber:     timedRound.RoundNumber,
+			DurationSeconds: int(duration / time.Second),
+			EndsAt:          endsAt,
+		})
+	}
+
+	return timedRound, nil
+}
+
+// cancelRoundTimer stops the countdown of a round revealed before its
+// deadline.
+func (s *roomsVoteService) cancelRoundTimer(roomID string, round *roomsmodels.RoomTaskRoundModel) {
+	if s.timerService == nil || round == nil || round.TimerEndsAt == nil || !round.TimerEndsAt.After(time.Now()) {
+		return
+	}
+
+	s.timerService.Cancel(RoomRoundTimer{
+		RoomID:      roomID,
+		TaskID:      round.TaskID,
+		RoundNumber: round.RoundNumber,
+		EndsAt:      *round.TimerEndsAt,
+	})
+}
+
+func (s *roomsVoteService) FinalizeCurrentTask(roomID, userID, value string, dimensionValues map[string]string) (*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
-	return s.finalizeTask(roomID, task, value)
+	task, err := s.taskRepo.FindCurrentVotingTask(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.finalizeTask(roomID, task, value, dimensionValues)
 }
 
-func (s *roomsVoteService) FinalizeTask(roomID, taskID, userID, value string) (*roomsmodels.RoomTaskModel, error) {
-	if _, err := s.ensureActiveRoomAdmin(roomID, userID); err != nil {
+func (s *roomsVoteService) FinalizeTask(roomID, taskID, userID, value string, dimensionValues map[string]string) (*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
 		return nil, err
 	}
 
@@ -234,10 +722,12 @@ func (s *roomsVoteService) FinalizeTask(roomID, taskID, userID, value string) (*
 		return nil, err
 	}
 
-	return s.finalizeTask(roomID, task, value)
+	return s.finalizeTask(roomID, task, value, dimensionValues)
 }
 
-func (s *roomsVoteService) finalizeTask(roomID string, task *roomsmodels.RoomTaskModel, value string) (*roomsmodels.RoomTaskModel, error) {
+// finalizeTask stores the overall estimate from the room deck and, in a
+// multi-criteria room, one estimate per dimension from that dimension's deck.
+func (s *roomsVoteService) finalizeTask(roomID string, task *roomsmodels.RoomTaskModel, value string, dimensionValues map[string]string) (*roomsmodels.RoomTaskModel, error) {
 	room, err := s.roomsRepo.FindByID(roomID)
 	if err != nil {
 		return nil, err
@@ -253,6 +743,10 @@ func (s *roomsVoteService) finalizeTask(roomID string, task *roomsmodels.RoomTas
 	if !isDeckValueAllowed(room.Deck.Values, trimmedValue) {
 		return nil, fmt.Errorf("%w: final estimate must be from the room deck", apperrors.ErrBadRequest)
 	}
+	dimensionEstimates, err := normalizeDimensionEstimates(room.Dimensions, dimensionValues)
+	if err != nil {
+		return nil, err
+	}
 	if task.IsActive {
 		round, err := s.roundRepo.GetOrCreateCurrent(task.TaskID, nil)
 		if err != nil {
@@ -266,6 +760,60 @@ func (s *roomsVoteService) finalizeTask(roomID string, task *roomsmodels.RoomTas
 	task.Status = "ESTIMATED"
 	task.IsActive = false
 	task.FinalEstimateValue = &trimmedValue
+	task.FinalDimensionEstimates = dimensionEstimates
+	updatedTask, err := s.taskRepo.Update(roomID, task)
+	if err != nil {
+		return nil, err
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	return updatedTask, nil
+}
+
+func (s *roomsVoteService) SkipCurrentTask(roomID, userID string) (*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindCurrentVotingTask(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skipTask(roomID, task)
+}
+
+func (s *roomsVoteService) SkipTask(roomID, taskID, userID string) (*roomsmodels.RoomTaskModel, error) {
+	if _, err := s.ensureActiveRoomFacilitator(roomID, userID); err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.FindByID(roomID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.skipTask(roomID, task)
+}
+
+// skipTask closes a task without an estimate. Skipping the current task
+// stops the countdown of its round.
+func (s *roomsVoteService) skipTask(roomID string, task *roomsmodels.RoomTaskModel) (*roomsmodels.RoomTaskModel, error) {
+	if task.IsActive {
+		round, err := s.roundRepo.GetCurrent(task.TaskID)
+		if err != nil && !errors.Is(err, apperrors.ErrNotFound) {
+			return nil, err
+		}
+		if round != nil && round.Status == roomsmodels.RoomTaskRoundStatusActive {
+			s.cancelRoundTimer(roomID, round)
+		}
+	}
+
+	task.Status = "SKIPPED"
+	task.IsActive = false
+	task.FinalEstimateValue = nil
+	task.FinalDimensionEstimates = nil
 	updatedTask, err := s.taskRepo.Update(roomID, task)
 	if err != nil {
 		return nil, err
@@ -276,7 +824,134 @@ func (s *roomsVoteService) finalizeTask(roomID string, task *roomsmodels.RoomTas
 	return updatedTask, nil
 }
 
-func (s *roomsVoteService) ensureRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+// SetParticipantObserver switches a participant between voter and observer.
+// An observer is dropped from the eligible voters of the active round and
+// their vote in it is discarded; a new voter becomes eligible from the next
+// round, like a late joiner.
+func (s *roomsVoteService) SetParticipantObserver(roomID, userID, participantID string, observer bool) (*SetParticipantObserverResult, error) {
+	room, err := s.ensureActiveRoomFacilitator(roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant, err := s.participantRepo.FindActiveByID(roomID, strings.TrimSpace(participantID))
+	if err != nil {
+		return nil, err
+	}
+	if participant.Role.CanFacilitate() {
+		return nil, fmt.Errorf("%w: room facilitators cannot be switched to observer", apperrors.ErrBadRequest)
+	}
+
+	role := roomsmodels.RoomParticipantRoleObserver
+	if !observer {
+		role = roomsmodels.RoomParticipantRoleMember
+		if participant.IsGuest() {
+			role = roomsmodels.RoomParticipantRoleGuest
+		}
+	}
+
+	result := &SetParticipantObserverResult{Participant: participant}
+	if participant.Role != role {
+		result.Participant, err = s.participantRepo.UpdateRole(roomID, participant.RoomParticipantID, role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.expiryService.TouchActivity(roomID)
+
+	if !observer {
+		return result, nil
+	}
+
+	change, err := s.dropFromActiveRound(s.taskRepo, s.roundRepo, s.voteRepo, room, participant.RoomParticipantID)
+	if err != nil {
+		return nil, err
+	}
+	result.RoundEligibilityChange = *change
+
+	return result, nil
+}
+
+// DropFromActiveRoundWithDB removes a participant who is leaving the room
+// from the eligible voters of the active round and discards their vote in it.
+// It writes through db, so the caller can do it in the same transaction that
+// takes the participant out of the room.
+func (s *roomsVoteService) DropFromActiveRoundWithDB(db bun.IDB, roomID, participantID string) (*RoundEligibilityChange, error) {
+	if db == nil {
+		return nil, apperrors.ErrInternal
+	}
+
+	room, err := s.ensureActiveRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.dropFromActiveRound(
+		roomsrepositories.NewRoomTaskRepository(db),
+		roomsrepositories.NewRoomTaskRoundRepository(db),
+		roomsrepositories.NewRoomVoteRepository(db),
+		room,
+		strings.TrimSpace(participantID),
+	)
+}
+
+func (s *roomsVoteService) dropFromActiveRound(
+	taskRepo roomsrepositories.RoomTaskRepository,
+	roundRepo roomsrepositories.RoomTaskRoundRepository,
+	voteRepo roomsrepositories.RoomVoteRepository,
+	room *roomsmodels.RoomsModel,
+	participantID string,
+) (*RoundEligibilityChange, error) {
+	change := &RoundEligibilityChange{}
+
+	task, err := taskRepo.FindCurrentVotingTask(room.RoomID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNotFound) {
+			return change, nil
+		}
+		return nil, err
+	}
+
+	round, err := roundRepo.GetOrCreateCurrent(task.TaskID, nil)
+	if err != nil {
+		return nil, err
+	}
+	if round.Status != roomsmodels.RoomTaskRoundStatusActive || !containsParticipantID(round.EligibleParticipantIDs, participantID) {
+		return change, nil
+	}
+
+	remaining := make([]string, 0, len(round.EligibleParticipantIDs))
+	for _, id := range round.EligibleParticipantIDs {
+		if strings.TrimSpace(id) != participantID {
+			remaining = append(remaining, id)
+		}
+	}
+
+	round, err = roundRepo.SetEligibleParticipants(task.TaskID, round.RoundNumber, normalizeParticipantIDs(remaining))
+	if err != nil {
+		return nil, err
+	}
+	if err := voteRepo.DeleteByParticipant(task.TaskID, participantID, round.RoundNumber); err != nil {
+		return nil, err
+	}
+
+	votes, err := voteRepo.ListByTaskAndRound(task.TaskID, round.RoundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Task = task
+	change.Round = round
+	change.VotedParticipantIDs = filterParticipantIDs(completeVoterIDs(votes, room.Dimensions), round.EligibleParticipantIDs)
+	change.AllVotesCast = len(round.EligibleParticipantIDs) > 0 && sameParticipantIDs(change.VotedParticipantIDs, round.EligibleParticipantIDs)
+	change.AutoReveal = revealsWhenAllVoted(room)
+
+	return change, nil
+}
+
+// ensureRoomFacilitator allows the room admin and co-facilitators.
+func (s *roomsVoteService) ensureRoomFacilitator(roomID, userID string) (*roomsmodels.RoomsModel, error) {
 	room, err := s.roomsRepo.FindByID(roomID)
 	if err != nil {
 		return nil, err
@@ -289,10 +964,10 @@ func (s *roomsVoteService) ensureRoomAdmin(roomID, userID string) (*roomsmodels.
 		}
 		return nil, err
 	}
-	if participant.Role != roomsmodels.RoomParticipantRoleAdmin {
+	if !participant.Role.CanFacilitate() {
 		return nil, apperrors.ErrForbidden
 	}
-	if room.AdminUserID != userID {
+	if participant.Role == roomsmodels.RoomParticipantRoleAdmin && room.AdminUserID != userID {
 		return nil, apperrors.ErrForbidden
 	}
 
@@ -311,8 +986,8 @@ func (s *roomsVoteService) ensureActiveRoom(roomID string) (*roomsmodels.RoomsMo
 	return room, nil
 }
 
-func (s *roomsVoteService) ensureActiveRoomAdmin(roomID, userID string) (*roomsmodels.RoomsModel, error) {
-	room, err := s.ensureRoomAdmin(roomID, userID)
+func (s *roomsVoteService) ensureActiveRoomFacilitator(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+	room, err := s.ensureRoomFacilitator(roomID, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -323,8 +998,34 @@ func (s *roomsVoteService) ensureActiveRoomAdmin(roomID, userID string) (*roomsm
 	return room, nil
 }
 
+// roomVoterIDs lists every active participant who may vote, online or not.
+func (s *roomsVoteService) roomVoterIDs(roomID string) ([]string, error) {
+	participants, err := s.participantRepo.ListActiveByRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	voterIDs := make([]string, 0, len(participants))
+	for _, participant := range participants {
+		if participant != nil && isVotingParticipantRole(participant.Role) {
+			voterIDs = append(voterIDs, participant.RoomParticipantID)
+		}
+	}
+
+	return normalizeParticipantIDs(voterIDs), nil
+}
+
+// revealsWhenAllVoted reports whether the round reveals itself once every
+// eligible participant has voted. Async rooms always do, as nobody is there
+// to reveal it.
+func revealsWhenAllVoted(room *roomsmodels.RoomsModel) bool {
+	return room.Options.AutoReveal || room.IsAsync()
+}
+
 func isVotingParticipantRole(role roomsmodels.RoomParticipantRole) bool {
-	return role == roomsmodels.RoomParticipantRoleMember || role == roomsmodels.RoomParticipantRoleGuest
+	return role == roomsmodels.RoomParticipantRoleMember ||
+		role == roomsmodels.RoomParticipantRoleGuest ||
+		role == roomsmodels.RoomParticipantRoleFacilitator
 }
 
 func normalizeParticipantIDs(ids []string) []string {
@@ -382,3 +1083,76 @@ func sameParticipantIDs(left []string, right []string) bool {
 	}
 	return true
 }
+
+// voteDeck resolves the deck a vote in the given dimension is drawn from.
+// Classic rooms only accept the blank dimension; multi-criteria rooms only
+// accept one of their own.
+func voteDeck(room *roomsmodels.RoomsModel, dimension string) (roomsmodels.RoomDeck, error) {
+	if len(room.Dimensions) == 0 {
+		if dimension != "" {
+			return roomsmodels.RoomDeck{}, fmt.Errorf("%w: room has no dimensions", apperrors.ErrBadRequest)
+		}
+		return room.Deck, nil
+	}
+
+	found, ok := room.Dimensions.Find(dimension)
+	if !ok {
+		return roomsmodels.RoomDeck{}, fmt.Errorf("%w: unknown dimension", apperrors.ErrBadRequest)
+	}
+
+	return found.Deck, nil
+}
+
+// normalizeDimensionEstimates checks that a multi-criteria room receives one
+// final estimate per dimension from its deck. Classic rooms take none.
+func normalizeDimensionEstimates(dimensions roomsmodels.RoomDimensions, values map[string]string) (map[string]string, error) {
+	if len(dimensions) == 0 {
+		if len(values) > 0 {
+			return nil, fmt.Errorf("%w: room has no dimensions", apperrors.ErrBadRequest)
+		}
+		return nil, nil
+	}
+
+	estimates := make(map[string]string, len(dimensions))
+	for key, value := range values {
+		dimension, ok := dimensions.Find(strings.TrimSpace(key))
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown dimension", apperrors.ErrBadRequest)
+		}
+
+		trimmedValue := strings.TrimSpace(value)
+		if !isDeckValueAllowed(dimension.Deck.Values, trimmedValue) {
+			return nil, fmt.Errorf("%w: final estimate of %s must be from its deck", apperrors.ErrBadRequest, dimension.Key)
+		}
+		estimates[dimension.Key] = trimmedValue
+	}
+	if len(estimates) != len(dimensions) {
+		return nil, fmt.Errorf("%w: every dimension needs a final estimate", apperrors.ErrBadRequest)
+	}
+
+	return estimates, nil
+}
+
+// votedDimensions lists, in room order, the dimensions the participant has
+// voted in. It is nil for classic rooms.
+func votedDimensions(votes []*roomsmodels.RoomVoteModel, dimensions roomsmodels.RoomDimensions, participantID string) []string {
+	if len(dimensions) == 0 {
+		return nil
+	}
+
+	voted := make(map[string]struct{}, len(dimensions))
+	for _, vote := range votes {
+		if strings.TrimSpace(vote.ParticipantID) == participantID {
+			voted[vote.Dimension] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(voted))
+	for _, key := range dimensions.Keys() {
+		if _, ok := voted[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,200 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func doRoomsRequest(t *testing.T, router *chi.Mux, method, path, accessToken, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestTransferAdmin_HandsRoomOverAndKeepsPreviousAdminAsFacilitator(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	memberToken, memberUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+
+	rr := doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/admin", memberToken, `{"participantId":"`+memberParticipantID+`"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-admin transfer, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/admin", adminToken, `{"participantId":"`+memberParticipantID+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var room roomsmodels.RoomsModel
+	if err := json.NewDecoder(rr.Body).Decode(&room); err != nil {
+		t.Fatalf("failed to decode room response: %v", err)
+	}
+	if room.AdminUserID != memberUserID {
+		t.Fatalf("expected admin %s, got %s", memberUserID, room.AdminUserID)
+	}
+
+	roles := make(map[string]roomsmodels.RoomParticipantRole, len(room.Participants))
+	for _, participant := range room.Participants {
+		if participant.UserID != nil {
+			roles[*participant.UserID] = participant.Role
+		}
+	}
+	if roles[memberUserID] != roomsmodels.RoomParticipantRoleAdmin || roles[adminUserID] != roomsmodels.RoomParticipantRoleFacilitator {
+		t.Fatalf("expected new admin and facilitator roles, got %v", roles)
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks", adminToken, `{"title":"Facilitated task"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected previous admin to keep managing tasks, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPatch, "/api/v1/rooms/"+roomID, adminToken, `{"name":"Taken over"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected previous admin to lose room settings, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPatch, "/api/v1/rooms/"+roomID, memberToken, `{"name":"Taken over"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected new admin to update the room, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFacilitators_ManageTasksUntilDemoted(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	memberToken, memberUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	facilitatorPath := "/api/v1/rooms/" + roomID + "/facilitators/" + memberParticipantID
+
+	rr := doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks", memberToken, `{"title":"Too early"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected member task creation to be forbidden, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodDelete, facilitatorPath, adminToken, "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 when demoting a member who is not a facilitator, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, facilitatorPath, memberToken, "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected members to be unable to promote themselves, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, facilitatorPath, adminToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on promotion, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks", memberToken, `{"title":"Facilitated task"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected facilitator to create tasks, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodDelete, facilitatorPath, adminToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK on demotion, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks", memberToken, `{"title":"Too late"}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected demoted member task creation to be forbidden, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestFacilitators_DemotingObserverKeepsTheirRole(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	_, observerUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	observerParticipantID := seedMemberParticipant(t, db, roomID, observerUserID)
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE room_participants SET role = 'OBSERVER' WHERE room_participants_id = $1
+	`, observerParticipantID); err != nil {
+		t.Fatalf("failed to make participant an observer: %v", err)
+	}
+
+	rr := doRoomsRequest(t, router, http.MethodDelete, "/api/v1/rooms/"+roomID+"/facilitators/"+observerParticipantID, adminToken, "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 when demoting an observer, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var role roomsmodels.RoomParticipantRole
+	if err := db.NewSelect().
+		TableExpr("room_participants").
+		Column("role").
+		Where("room_participants_id = ?", observerParticipantID).
+		Scan(context.Background(), &role); err != nil {
+		t.Fatalf("failed to load observer: %v", err)
+	}
+	if role != roomsmodels.RoomParticipantRoleObserver {
+		t.Fatalf("expected the observer to keep their role, got %s", role)
+	}
+}
+
+func TestFacilitators_FinishRoomButCannotChangeSettings(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	memberToken, memberUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	roomURL := server.URL + "/api/v1/rooms/" + roomID
+
+	resp := doRealtimeRequest(t, http.MethodPut, roomURL+"/facilitators/"+memberParticipantID, adminToken, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK on promotion, got %d", resp.StatusCode)
+	}
+
+	resp = doRealtimeRequest(t, http.MethodPatch, roomURL, memberToken, `{"name":"Renamed"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected a facilitator rename to be forbidden, got %d", resp.StatusCode)
+	}
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsFinish, RoomID: roomID})
+	readUntilEvent(t, adminConn, rooms.RoomsFinished)
+
+	var status string
+	if err := db.NewRaw("SELECT status FROM rooms WHERE room_id = ?", roomID).Scan(context.Background(), &status); err != nil {
+		t.Fatalf("failed to load room status: %v", err)
+	}
+	if status != "FINISHED" {
+		t.Fatalf("expected the facilitator to finish the room, got %s", status)
+	}
+}
@@ -0,0 +1,171 @@
+package tests
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+type anonymousRevealedVote struct {
+	ParticipantID string `json:"participantId"`
+	Value         string `json:"value"`
+}
+
+func TestAnonymousVoting_HidesVotersFromEveryoneButAdmin(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"anonymousVoting":true,"adminSeesVotes":true}`)
+	taskID := seedTask(t, db, roomID, "Anonymous task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	for _, conn := range []*websocket.Conn{adminConn, memberConn} {
+		writeEvent(t, conn, ws.Event{
+			Type:    rooms.RoomsVoteCast,
+			RoomID:  roomID,
+			Payload: mustMarshalJSON(t, map[string]string{"value": "5"}),
+		})
+		readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+	}
+
+	writeEvent(t, adminConn, ws.Event{Type: rooms.RoomsVoteReveal, RoomID: roomID})
+
+	revealed := decodePayload[struct {
+		Anonymous bool                    `json:"anonymous"`
+		Votes     []anonymousRevealedVote `json:"votes"`
+		Summary   struct {
+			TotalVotes int            `json:"totalVotes"`
+			Counts     map[string]int `json:"counts"`
+		} `json:"summary"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsVotesRevealed).Payload)
+	if !revealed.Anonymous || len(revealed.Votes) != 0 {
+		t.Fatalf("expected an anonymous reveal without votes, got %+v", revealed)
+	}
+	if revealed.Summary.TotalVotes != 2 || revealed.Summary.Counts["5"] != 2 {
+		t.Fatalf("expected the value distribution in the summary, got %+v", revealed.Summary)
+	}
+
+	voters := decodePayload[struct {
+		TaskID string                  `json:"taskId"`
+		Votes  []anonymousRevealedVote `json:"votes"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsVotesRevealedVoters).Payload)
+	if voters.TaskID != taskID || len(voters.Votes) != 2 {
+		t.Fatalf("expected the admin to receive both voters, got %+v", voters)
+	}
+
+	snapshotVotes := func(token string) []anonymousRevealedVote {
+		conn := connectWS(t, server.URL, token)
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		readUntilEvent(t, conn, ws.EventTypeHello)
+		writeEvent(t, conn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+
+		return decodePayload[struct {
+			RevealedVotes []anonymousRevealedVote `json:"revealedVotes"`
+		}](t, readUntilEvent(t, conn, rooms.RoomsSnapshot).Payload).RevealedVotes
+	}
+
+	if votes := snapshotVotes(memberToken); len(votes) != 0 {
+		t.Fatalf("expected the member snapshot to hide revealed votes, got %+v", votes)
+	}
+
+	adminVotes := snapshotVotes(adminToken)
+	participantIDs := make([]string, 0, len(adminVotes))
+	for _, vote := range adminVotes {
+		participantIDs = append(participantIDs, vote.ParticipantID)
+	}
+	if len(participantIDs) != 2 || !slices.Contains(participantIDs, memberParticipantID) {
+		t.Fatalf("expected the admin snapshot to list voters, got %+v", adminVotes)
+	}
+}
+
+func TestUpdateRoom_AnonymousVotingCannotBeTurnedOff(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	roomPath := "/api/v1/rooms/" + roomID
+
+	rr := doRoomsRequest(t, router, http.MethodPatch, roomPath, adminToken, `{"options":{"anonymousVoting":true}}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK turning anonymous voting on, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPatch, roomPath, adminToken, `{"options":{"anonymousVoting":false}}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 turning anonymous voting off, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdateRoom_AdminSeesVotesCannotBeTurnedOnAfterAnonymousReveal(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"anonymousVoting":true}`)
+	taskID := seedTask(t, db, roomID, "Anonymous task")
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "5"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+	writeEvent(t, adminConn, ws.Event{Type: rooms.RoomsVoteReveal, RoomID: roomID})
+	readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed)
+
+	router := server.Config.Handler.(*chi.Mux)
+	rr := doRoomsRequest(t, router, http.MethodPatch, "/api/v1/rooms/"+roomID, adminToken, `{"options":{"adminSeesVotes":true}}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 turning admin vote visibility on after a reveal, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var adminSeesVotes bool
+	if err := db.NewRaw(
+		"SELECT COALESCE((options->>'adminSeesVotes')::boolean, false) FROM rooms WHERE room_id = ?",
+		roomID,
+	).Scan(context.Background(), &adminSeesVotes); err != nil {
+		t.Fatalf("failed to read room options: %v", err)
+	}
+	if adminSeesVotes {
+		t.Fatal("expected the room to keep hiding voters from the admin")
+	}
+}
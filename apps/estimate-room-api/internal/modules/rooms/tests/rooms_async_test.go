@@ -0,0 +1,231 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/infra/email"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsdto "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/dto"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/uptrace/bun"
+)
+
+type recordingEmailClient struct {
+	mu       sync.Mutex
+	messages []email.Message
+}
+
+func (c *recordingEmailClient) Send(_ context.Context, msg email.Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, msg)
+	return nil
+}
+
+func setRoomAsync(t *testing.T, db *bun.DB, roomID string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET mode = 'ASYNC' WHERE room_id = $1
+	`, roomID); err != nil {
+		t.Fatalf("failed to set room mode: %v", err)
+	}
+}
+
+func decodeVotingResponse(t *testing.T, resp *http.Response) roomsdto.RoomTaskVotingResponse {
+	t.Helper()
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+
+	body := roomsdto.RoomTaskVotingResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode voting response: %v", err)
+	}
+
+	return body
+}
+
+func TestRoomsAsync_OpenTaskVoteOverRESTAndRevealWhenAllVoted(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Async task")
+	memberToken, memberUserID := createAccessToken(t, db)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+
+	tasksURL := server.URL + "/api/v1/rooms/" + roomID + "/tasks/" + taskID
+	deadline := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	resp := doRealtimeRequest(t, http.MethodPost, tasksURL+"/open", adminToken, `{"deadline":"`+deadline+`"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 when opening a task of a live room, got %d", resp.StatusCode)
+	}
+
+	setRoomAsync(t, db, roomID)
+
+	resp = doRealtimeRequest(t, http.MethodPost, tasksURL+"/open", adminToken, `{"deadline":"`+time.Now().Add(time.Minute).UTC().Format(time.RFC3339)+`"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a deadline that is too close, got %d", resp.StatusCode)
+	}
+
+	resp = doRealtimeRequest(t, http.MethodPost, tasksURL+"/open", memberToken, `{"deadline":"`+deadline+`"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 when a member opens a task, got %d", resp.StatusCode)
+	}
+
+	// Nobody is connected: the offline member is still eligible.
+	opened := decodeVotingResponse(t, doRealtimeRequest(t, http.MethodPost, tasksURL+"/open", adminToken, `{"deadline":"`+deadline+`"}`))
+	if opened.TaskID != taskID || opened.RoundStatus != "ACTIVE" || opened.Deadline == nil {
+		t.Fatalf("unexpected opened round: %+v", opened)
+	}
+	if !sameStringSet(opened.EligibleParticipantIDs, []string{memberParticipantID}) {
+		t.Fatalf("expected the offline member to be eligible, got %+v", opened.EligibleParticipantIDs)
+	}
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+
+	resp = doRealtimeRequest(t, http.MethodPut, tasksURL+"/vote", adminToken, `{"value":"5"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 when the admin votes their way into the round, got %d", resp.StatusCode)
+	}
+
+	resp = doRealtimeRequest(t, http.MethodPut, tasksURL+"/vote", memberToken, `{"value":"13"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a value outside the deck, got %d", resp.StatusCode)
+	}
+
+	voted := decodeVotingResponse(t, doRealtimeRequest(t, http.MethodPut, tasksURL+"/vote", memberToken, `{"value":"5"}`))
+	if voted.RoundStatus != "REVEALED" || !sameStringSet(voted.VotedParticipantIDs, []string{memberParticipantID}) {
+		t.Fatalf("expected the round to reveal once everyone voted, got %+v", voted)
+	}
+
+	revealed := decodePayload[struct {
+		TaskID  string `json:"taskId"`
+		Trigger string `json:"trigger"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed).Payload)
+	if revealed.TaskID != taskID || revealed.Trigger != "ALL_VOTED" {
+		t.Fatalf("unexpected revealed payload: %+v", revealed)
+	}
+
+	resp = doRealtimeRequest(t, http.MethodDelete, tasksURL+"/vote", memberToken, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400 when retracting from a revealed round, got %d", resp.StatusCode)
+	}
+}
+
+func TestRoomsAsync_SendDueRemindersEmailsMissingVotersOnce(t *testing.T) {
+	_, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	_, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomAsync(t, db, roomID)
+	taskID := seedTask(t, db, roomID, "Backend API")
+
+	_, votedUserID := createAccessTokenForEmail(t, db, "voted-"+uuid.NewString()+"@example.com")
+	votedParticipantID := seedMemberParticipant(t, db, roomID, votedUserID)
+	missingEmail := "missing-" + uuid.NewString() + "@example.com"
+	_, missingUserID := createAccessTokenForEmail(t, db, missingEmail)
+	missingParticipantID := seedMemberParticipant(t, db, roomID, missingUserID)
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE tasks SET status = 'VOTING', is_active = TRUE WHERE task_id = $1
+	`, taskID); err != nil {
+		t.Fatalf("failed to open task: %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO task_rounds (task_id, round_number, eligible_participant_ids, status, timer_duration_seconds, timer_ends_at)
+		VALUES ($1, 1, $2::jsonb, 'ACTIVE', 7200, $3)
+	`, taskID, string(mustMarshalJSON(t, []string{votedParticipantID, missingParticipantID})), time.Now().Add(30*time.Minute).UTC()); err != nil {
+		t.Fatalf("failed to insert round: %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO votes (votes_id, task_id, participant_id, round_number, value)
+		VALUES ($1, $2, $3, 1, '5')
+	`, uuid.NewString(), taskID, votedParticipantID); err != nil {
+		t.Fatalf("failed to insert vote: %v", err)
+	}
+
+	emailClient := &recordingEmailClient{}
+	asyncService := rooms.NewRoomsAsyncService(
+		roomsrepositories.NewRoomsRepository(db),
+		roomsrepositories.NewRoomTaskRepository(db),
+		roomsrepositories.NewRoomVoteRepository(db),
+		roomsrepositories.NewRoomTaskRoundRepository(db),
+		roomsrepositories.NewRoomParticipantRepository(db),
+		nil,
+		nil,
+		emailClient,
+		"http://localhost:4080",
+	)
+
+	sent, err := asyncService.SendDueReminders(context.Background())
+	if err != nil {
+		t.Fatalf("failed to send reminders: %v", err)
+	}
+	if sent != 1 || len(emailClient.messages) != 1 {
+		t.Fatalf("expected one reminder, got %d: %+v", sent, emailClient.messages)
+	}
+	message := emailClient.messages[0]
+	if len(message.To) != 1 || message.To[0] != missingEmail {
+		t.Fatalf("expected the reminder to go to the missing voter, got %v", message.To)
+	}
+	if !strings.Contains(message.TextBody, "http://localhost:4080/rooms/"+roomID) {
+		t.Fatalf("expected a link to the room, got %q", message.TextBody)
+	}
+
+	sent, err = asyncService.SendDueReminders(context.Background())
+	if err != nil {
+		t.Fatalf("failed to send reminders again: %v", err)
+	}
+	if sent != 0 {
+		t.Fatalf("expected the reminder to be sent once, got %d more", sent)
+	}
+}
+
+func TestRoomsExpiry_ExpireInactiveRoomsSkipsAsyncRooms(t *testing.T) {
+	_, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	_, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomAsync(t, db, roomID)
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET last_activity_at = $2 WHERE room_id = $1
+	`, roomID, time.Now().Add(-48*time.Hour).UTC()); err != nil {
+		t.Fatalf("failed to set room activity time: %v", err)
+	}
+
+	expiryService := rooms.NewRoomsExpiryService(db, roomsrepositories.NewRoomsRepository(db), nil, nil)
+	expiredRooms, err := expiryService.ExpireInactiveRooms(time.Now().Add(-30 * time.Minute))
+	if err != nil {
+		t.Fatalf("failed to expire inactive rooms: %v", err)
+	}
+	if len(expiredRooms) != 0 {
+		t.Fatalf("expected the async room to stay active, got %d expired", len(expiredRooms))
+	}
+}
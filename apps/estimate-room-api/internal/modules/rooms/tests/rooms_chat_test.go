@@ -0,0 +1,168 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsdto "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/dto"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func doRealtimeRequest(t *testing.T, method, url, accessToken, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, bytes.NewReader([]byte(body)))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+
+	return resp
+}
+
+func connectGuestWS(t *testing.T, serverURL string, cookie *http.Cookie) *websocket.Conn {
+	t.Helper()
+
+	wsURL := "ws" + strings.TrimPrefix(serverURL, "http") + "/api/v1/ws"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	conn, _, err := websocket.Dial(ctx, wsURL, &websocket.DialOptions{
+		HTTPHeader: http.Header{
+			"Cookie": []string{cookie.Name + "=" + cookie.Value},
+			"Origin": []string{testWSOrigin},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to connect guest websocket: %v", err)
+	}
+
+	return conn
+}
+
+func listChatMessages(t *testing.T, serverURL, roomID, accessToken, query string) roomsdto.RoomChatMessagesResponse {
+	t.Helper()
+
+	resp := doRealtimeRequest(t, http.MethodGet, serverURL+"/api/v1/rooms/"+roomID+"/chat"+query, accessToken, "")
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK listing chat, got %d", resp.StatusCode)
+	}
+
+	var page roomsdto.RoomChatMessagesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode chat page: %v", err)
+	}
+
+	return page
+}
+
+func TestRoomChat_GuestsAndMembersPostToRoomAndTaskThreads(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, _ := createAccessToken(t, db)
+	resp := doRealtimeRequest(t, http.MethodPost, server.URL+"/api/v1/rooms", adminToken, `{"name":"Chat room","createShareLink":true}`)
+	var created roomsdto.CreateRoomResponse
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode created room: %v", err)
+	}
+	resp.Body.Close()
+	roomID := created.Room.RoomID
+	taskID := seedTask(t, db, roomID, "Discussed task")
+
+	resp = doRealtimeRequest(t, http.MethodPost, server.URL+"/api/v1/invites/"+created.InviteToken+"/accept", "", `{"guestName":"Guest Chatter"}`)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(resp.Cookies()) == 0 {
+		t.Fatalf("expected guest join to set a cookie, got %d", resp.StatusCode)
+	}
+	guestCookie := resp.Cookies()[0]
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	guestConn := connectGuestWS(t, server.URL, guestCookie)
+	defer guestConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, guestConn, roomID)
+
+	writeEvent(t, guestConn, ws.Event{
+		Type:    rooms.RoomsChatSend,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"body": "  Hello from the guest  "}),
+	})
+	guestMessage := decodePayload[roomsdto.RoomChatMessageResponse](t, readUntilEvent(t, adminConn, rooms.RoomsChatMessage).Payload)
+	if guestMessage.Body != "Hello from the guest" || guestMessage.TaskID != nil {
+		t.Fatalf("unexpected guest message %+v", guestMessage)
+	}
+	if guestMessage.GuestName == nil || *guestMessage.GuestName != "Guest Chatter" || guestMessage.UserID != nil {
+		t.Fatalf("expected the guest to post under their guest name, got %+v", guestMessage)
+	}
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsChatSend,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID, "body": "Is this a 5?"}),
+	})
+	taskMessage := decodePayload[roomsdto.RoomChatMessageResponse](t, readUntilEvent(t, guestConn, rooms.RoomsChatMessage).Payload)
+	if taskMessage.TaskID == nil || *taskMessage.TaskID != taskID || taskMessage.UserID == nil {
+		t.Fatalf("expected the admin message in the task thread, got %+v", taskMessage)
+	}
+
+	for _, body := range []string{"Second", "Third"} {
+		writeEvent(t, adminConn, ws.Event{
+			Type:    rooms.RoomsChatSend,
+			RoomID:  roomID,
+			Payload: mustMarshalJSON(t, map[string]string{"body": body}),
+		})
+		readUntilEvent(t, adminConn, rooms.RoomsChatMessage)
+	}
+
+	page := listChatMessages(t, server.URL, roomID, adminToken, "?limit=2")
+	if len(page.Items) != 2 || !page.HasMore || page.Items[0].Body != "Second" || page.Items[1].Body != "Third" {
+		t.Fatalf("expected the two latest room messages oldest first, got %+v", page)
+	}
+
+	page = listChatMessages(t, server.URL, roomID, adminToken, "?limit=2&before="+page.Items[0].MessageID)
+	if len(page.Items) != 1 || page.HasMore || page.Items[0].MessageID != guestMessage.MessageID {
+		t.Fatalf("expected the guest message on the last page, got %+v", page)
+	}
+
+	page = listChatMessages(t, server.URL, roomID, adminToken, "?taskId="+taskID)
+	if len(page.Items) != 1 || page.Items[0].MessageID != taskMessage.MessageID {
+		t.Fatalf("expected only the task thread message, got %+v", page)
+	}
+
+	outsiderToken, _ := createAccessToken(t, db)
+	resp = doRealtimeRequest(t, http.MethodGet, server.URL+"/api/v1/rooms/"+roomID+"/chat", outsiderToken, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected 403 for a non-participant, got %d", resp.StatusCode)
+	}
+
+	snapshotConn := connectWS(t, server.URL, adminToken)
+	defer snapshotConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, snapshotConn, ws.EventTypeHello)
+	writeEvent(t, snapshotConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+	snapshot := decodePayload[struct {
+		ChatMessages []roomsdto.RoomChatMessageResponse `json:"chatMessages"`
+	}](t, readUntilEvent(t, snapshotConn, rooms.RoomsSnapshot).Payload)
+	if len(snapshot.ChatMessages) != 4 || snapshot.ChatMessages[3].Body != "Third" {
+		t.Fatalf("expected the snapshot to carry the latest chat messages, got %+v", snapshot.ChatMessages)
+	}
+}
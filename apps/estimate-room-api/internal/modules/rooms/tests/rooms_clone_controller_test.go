@@ -0,0 +1,104 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	roomsdto "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/dto"
+)
+
+func TestCloneRoom_CopiesUnestimatedTasksAndReinvitesParticipants(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	memberEmail := "clone-member-" + uuid.NewString()[:8] + "@example.com"
+	adminToken, adminUserID := createAccessToken(t, db)
+	memberToken, memberUserID := createAccessTokenForEmail(t, db, memberEmail)
+	roomID := seedRoom(t, db, adminUserID)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+	setRoomOptions(t, db, roomID, `{"autoReveal":true,"votingTimerSeconds":60}`)
+
+	pendingTaskID := seedTask(t, db, roomID, "Pending")
+	estimatedTaskID := seedTask(t, db, roomID, "Estimated")
+	skippedTaskID := seedTask(t, db, roomID, "Skipped")
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE tasks SET position = CASE task_id WHEN $1 THEN 2 WHEN $2 THEN 1 WHEN $3 THEN 0 END WHERE room_id = $4
+	`, pendingTaskID, estimatedTaskID, skippedTaskID, roomID); err != nil {
+		t.Fatalf("failed to order tasks: %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE tasks SET status = 'ESTIMATED', final_estimate_value = '5' WHERE task_id = $1
+	`, estimatedTaskID); err != nil {
+		t.Fatalf("failed to estimate task: %v", err)
+	}
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE tasks SET status = 'SKIPPED' WHERE task_id = $1
+	`, skippedTaskID); err != nil {
+		t.Fatalf("failed to skip task: %v", err)
+	}
+
+	clonePath := "/api/v1/rooms/" + roomID + "/clone"
+	rr := doRoomsRequest(t, router, http.MethodPost, clonePath, adminToken, "")
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 when cloning an active room, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET status = 'FINISHED', finished_at = NOW() WHERE room_id = $1
+	`, roomID); err != nil {
+		t.Fatalf("failed to finish room: %v", err)
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, clonePath, memberToken, "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for non-admin clone, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPost, clonePath, adminToken, `{"name":"Follow-up","inviteParticipants":true}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var response roomsdto.CreateRoomResponse
+	if err := json.NewDecoder(rr.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode clone response: %v", err)
+	}
+
+	room := response.Room
+	if room == nil || room.RoomID == roomID {
+		t.Fatalf("expected a new room, got %+v", room)
+	}
+	if room.Status != "ACTIVE" || room.Name != "Follow-up" || room.AdminUserID != adminUserID {
+		t.Fatalf("unexpected cloned room %+v", room)
+	}
+	if !room.Options.AutoReveal || room.Options.VotingTimerSeconds != 60 || len(room.Deck.Values) != 6 {
+		t.Fatalf("expected deck and options to be copied, got %+v %+v", room.Deck, room.Options)
+	}
+
+	titles := make([]string, 0, len(room.Tasks))
+	for _, task := range room.Tasks {
+		if task.Status != "PENDING" || task.IsActive || task.FinalEstimateValue != nil {
+			t.Fatalf("expected cloned task to be reset, got %+v", task)
+		}
+		titles = append(titles, task.Title)
+	}
+	if strings.Join(titles, ",") != "Skipped,Pending" {
+		t.Fatalf("expected unestimated tasks in backlog order, got %v", titles)
+	}
+
+	if len(response.EmailInvites) != 1 || response.EmailInvites[0].InvitedEmail == nil || *response.EmailInvites[0].InvitedEmail != memberEmail {
+		t.Fatalf("expected previous member to be re-invited, got %+v", response.EmailInvites)
+	}
+
+	var sourceStatus string
+	if err := db.NewSelect().TableExpr("rooms").Column("status").Where("room_id = ?", roomID).Scan(context.Background(), &sourceStatus); err != nil {
+		t.Fatalf("failed to load source room: %v", err)
+	}
+	if sourceStatus != "FINISHED" {
+		t.Fatalf("expected source room to stay FINISHED, got %s", sourceStatus)
+	}
+}
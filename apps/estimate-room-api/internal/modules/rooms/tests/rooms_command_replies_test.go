@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func TestRoomsCommands_ReplyWithAckOrTypedError(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	_, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, memberConn, ws.EventTypeHello)
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID, CorrelationID: "join-1"})
+	joined := readUntilEvent(t, memberConn, ws.EventTypeAck)
+	if joined.CorrelationID != "join-1" || joined.RoomID != roomID {
+		t.Fatalf("unexpected join acknowledgement: %+v", joined)
+	}
+	if ack := decodePayload[ws.AckPayload](t, joined.Payload); ack.Command != rooms.RoomsJoin {
+		t.Fatalf("expected the acknowledgement to name the command, got %+v", ack)
+	}
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsVoteReveal, RoomID: roomID, CorrelationID: "reveal-1"})
+	denied := readUntilEvent(t, memberConn, ws.EventTypeError)
+	if denied.CorrelationID != "reveal-1" {
+		t.Fatalf("expected the error to echo the correlation ID, got %q", denied.CorrelationID)
+	}
+	deniedPayload := decodePayload[ws.ErrorPayload](t, denied.Payload)
+	if deniedPayload.Command != rooms.RoomsVoteReveal || deniedPayload.Code != "FORBIDDEN" || deniedPayload.Status != 403 {
+		t.Fatalf("unexpected reveal error: %+v", deniedPayload)
+	}
+	if deniedPayload.Detail != "only a facilitator can reveal votes" {
+		t.Fatalf("expected the reason to be reported, got %q", deniedPayload.Detail)
+	}
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:          rooms.RoomsReactionSend,
+		RoomID:        roomID,
+		CorrelationID: "reaction-1",
+		Payload:       mustMarshalJSON(t, map[string]string{"emoji": "not-an-emoji"}),
+	})
+	invalid := decodePayload[ws.ErrorPayload](t, readUntilEvent(t, memberConn, ws.EventTypeError).Payload)
+	if invalid.Code != "BAD_REQUEST" || invalid.Detail != "unknown reaction" {
+		t.Fatalf("unexpected reaction error: %+v", invalid)
+	}
+}
@@ -0,0 +1,180 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	"github.com/uptrace/bun"
+)
+
+const testRoomDimensions = `[
+	{"key":"complexity","name":"Complexity","deck":{"name":"Fibonacci","kind":"FIBONACCI","values":["1","2","3","5","8"]}},
+	{"key":"risk","name":"Risk","deck":{"name":"Risk","kind":"CUSTOM","values":["low","medium","high"]}}
+]`
+
+type dimensionVoteStatusPayload struct {
+	ParticipantID   string   `json:"participantId"`
+	Voted           bool     `json:"voted"`
+	VotedDimensions []string `json:"votedDimensions"`
+}
+
+type dimensionVoteSummaryPayload struct {
+	Key     string `json:"key"`
+	Summary struct {
+		TotalVotes int            `json:"totalVotes"`
+		Counts     map[string]int `json:"counts"`
+	} `json:"summary"`
+}
+
+func setRoomDimensions(t *testing.T, db *bun.DB, roomID, dimensions string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET dimensions = $2::jsonb WHERE room_id = $1
+	`, roomID, dimensions); err != nil {
+		t.Fatalf("failed to set room dimensions: %v", err)
+	}
+}
+
+func TestRoomsDimensions_VoteRevealAndFinalizePerDimension(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomDimensions(t, db, roomID, testRoomDimensions)
+	taskID := seedTask(t, db, roomID, "Multi-criteria task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	// Neither a vote without a dimension nor a value from another deck counts.
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"dimension": "risk", "value": "3"}),
+	})
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"dimension": "complexity", "value": "3"}),
+	})
+	status := decodePayload[dimensionVoteStatusPayload](t, readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged).Payload)
+	if status.Voted || !sameStringSet(status.VotedDimensions, []string{"complexity"}) {
+		t.Fatalf("expected a partial vote after one dimension, got %+v", status)
+	}
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"dimension": "risk", "value": "high"}),
+	})
+	status = decodePayload[dimensionVoteStatusPayload](t, readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged).Payload)
+	if !status.Voted || status.ParticipantID != memberParticipantID || len(status.VotedDimensions) != 2 {
+		t.Fatalf("expected a complete vote after every dimension, got %+v", status)
+	}
+
+	writeEvent(t, adminConn, ws.Event{Type: rooms.RoomsVoteReveal, RoomID: roomID})
+	revealed := decodePayload[struct {
+		Votes []struct {
+			Dimension string `json:"dimension"`
+			Value     string `json:"value"`
+		} `json:"votes"`
+		DimensionSummaries []dimensionVoteSummaryPayload `json:"dimensionSummaries"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsVotesRevealed).Payload)
+	if len(revealed.Votes) != 2 || len(revealed.DimensionSummaries) != 2 {
+		t.Fatalf("expected two dimension votes and summaries, got %+v", revealed)
+	}
+	if revealed.DimensionSummaries[0].Key != "complexity" || revealed.DimensionSummaries[0].Summary.Counts["3"] != 1 {
+		t.Fatalf("unexpected complexity summary: %+v", revealed.DimensionSummaries[0])
+	}
+	if revealed.DimensionSummaries[1].Key != "risk" || revealed.DimensionSummaries[1].Summary.Counts["high"] != 1 {
+		t.Fatalf("unexpected risk summary: %+v", revealed.DimensionSummaries[1])
+	}
+
+	// A finalize missing a dimension is rejected before the complete one.
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskFinalize,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]any{"value": "5", "dimensions": map[string]string{"complexity": "3"}}),
+	})
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsTaskFinalize,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]any{
+			"value":      "5",
+			"dimensions": map[string]string{"complexity": "3", "risk": "high"},
+		}),
+	})
+	finalized := decodePayload[struct {
+		TaskID                  string            `json:"taskId"`
+		FinalEstimateValue      string            `json:"finalEstimateValue"`
+		FinalDimensionEstimates map[string]string `json:"finalDimensionEstimates"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsTaskFinalized).Payload)
+	if finalized.TaskID != taskID || finalized.FinalEstimateValue != "5" || finalized.FinalDimensionEstimates["risk"] != "high" {
+		t.Fatalf("unexpected finalized payload: %+v", finalized)
+	}
+
+	var stored struct {
+		FinalDimensionEstimates map[string]string `bun:"final_dimension_estimates,type:jsonb"`
+	}
+	if err := db.NewSelect().
+		TableExpr("tasks").
+		Column("final_dimension_estimates").
+		Where("task_id = ?", taskID).
+		Scan(context.Background(), &stored); err != nil {
+		t.Fatalf("failed to load task: %v", err)
+	}
+	if stored.FinalDimensionEstimates["complexity"] != "3" || stored.FinalDimensionEstimates["risk"] != "high" {
+		t.Fatalf("expected stored dimension estimates, got %+v", stored.FinalDimensionEstimates)
+	}
+}
+
+func TestRoomDimensions_IsValidRequiresUniqueKeysAndDecks(t *testing.T) {
+	deck := roomsmodels.DefaultRoomDeck()
+
+	valid := roomsmodels.RoomDimensions{
+		{Key: "complexity", Name: "Complexity", Deck: deck},
+		{Key: "risk", Name: "Risk", Deck: deck},
+	}
+	if !valid.IsValid() {
+		t.Fatal("expected dimensions with unique keys to be valid")
+	}
+
+	invalid := map[string]roomsmodels.RoomDimensions{
+		"duplicate key": {{Key: "risk", Name: "Risk", Deck: deck}, {Key: "risk", Name: "Risk again", Deck: deck}},
+		"bad key":       {{Key: "Risk level", Name: "Risk", Deck: deck}},
+		"blank name":    {{Key: "risk", Name: " ", Deck: deck}},
+		"empty deck":    {{Key: "risk", Name: "Risk"}},
+	}
+	for name, dimensions := range invalid {
+		if dimensions.IsValid() {
+			t.Fatalf("expected %s to be invalid", name)
+		}
+	}
+}
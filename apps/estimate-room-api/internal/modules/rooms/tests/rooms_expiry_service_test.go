@@ -43,7 +43,7 @@ func TestRoomsExpiry_ExpireInactiveRoomsMarksStaleRoomsExpiredAndBroadcastsEvent
 	expiryService := rooms.NewRoomsExpiryService(db, roomsrepositories.NewRoomsRepository(db), wsService, nil)
 
 	events := make(chan ws.Event, 1)
-	pubSub.Subscribe("test-room-events", func(data []byte) {
+	pubSub.Subscribe(ws.RoomChannel("test-room-events", staleRoomID), func(data []byte) {
 		event := ws.Event{}
 		if err := json.Unmarshal(data, &event); err != nil {
 			return
@@ -0,0 +1,281 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func TestLockRoom_AdminTogglesLock(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	memberToken, memberUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+	lockPath := "/api/v1/rooms/" + roomID + "/lock"
+
+	rr := doRoomsRequest(t, router, http.MethodPut, lockPath, memberToken, "")
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for member lock, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, lockPath, adminToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK locking, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var room roomsmodels.RoomsModel
+	if err := json.NewDecoder(rr.Body).Decode(&room); err != nil {
+		t.Fatalf("failed to decode room response: %v", err)
+	}
+	if room.LockedAt == nil {
+		t.Fatal("expected room to be locked")
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodDelete, lockPath, adminToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK unlocking, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	room = roomsmodels.RoomsModel{}
+	if err := json.NewDecoder(rr.Body).Decode(&room); err != nil {
+		t.Fatalf("failed to decode room response: %v", err)
+	}
+	if room.LockedAt != nil {
+		t.Fatalf("expected room to be unlocked, got %v", room.LockedAt)
+	}
+}
+
+func TestLockRoom_RejectsJoinOfParticipantsAdmittedAfterLock(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsLockSet,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]bool{"locked": true}),
+	})
+	lockChanged := decodePayload[struct {
+		Locked bool `json:"locked"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsLockChanged).Payload)
+	if !lockChanged.Locked {
+		t.Fatal("expected lock changed event to report a locked room")
+	}
+
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, memberConn, roomID)
+
+	lateToken, lateUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, lateUserID)
+
+	lateConn := connectWS(t, server.URL, lateToken)
+	defer lateConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, lateConn, ws.EventTypeHello)
+	writeEvent(t, lateConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+
+	denied := decodePayload[ws.ErrorPayload](t, readUntilEvent(t, lateConn, ws.EventTypeError).Payload)
+	if denied.Command != rooms.RoomsJoin || denied.Code != "FORBIDDEN" || denied.Detail != "room is locked" {
+		t.Fatalf("expected join of a participant admitted after the lock to be refused, got %+v", denied)
+	}
+}
+
+func TestKickParticipant_ClosesConnectionsAndCompletesRound(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Kick task")
+
+	voterToken, voterUserID := createAccessToken(t, db)
+	kickedToken, kickedUserID := createAccessToken(t, db)
+	voterParticipantID := seedMemberParticipant(t, db, roomID, voterUserID)
+	kickedParticipantID := seedMemberParticipant(t, db, roomID, kickedUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	voterConn := connectWS(t, server.URL, voterToken)
+	defer voterConn.Close(websocket.StatusNormalClosure, "")
+	kickedConn := connectWS(t, server.URL, kickedToken)
+	defer kickedConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, voterConn, roomID)
+	joinRoom(t, kickedConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, voterConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+
+	writeEvent(t, voterConn, ws.Event{
+		Type:    rooms.RoomsParticipantKick,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"participantId": kickedParticipantID}),
+	})
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsParticipantKick,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"participantId": kickedParticipantID}),
+	})
+
+	left := decodePayload[struct {
+		ParticipantID          string   `json:"participantId"`
+		Reason                 string   `json:"reason"`
+		TaskID                 string   `json:"taskId"`
+		EligibleParticipantIDs []string `json:"eligibleParticipantIds"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsParticipantLeft).Payload)
+	if left.ParticipantID != kickedParticipantID || left.Reason != "KICKED" {
+		t.Fatalf("expected %s to leave as KICKED, got %+v", kickedParticipantID, left)
+	}
+	if left.TaskID != taskID || !sameStringSet(left.EligibleParticipantIDs, []string{voterParticipantID}) {
+		t.Fatalf("expected only the voter to stay eligible, got %+v", left)
+	}
+
+	allCast := decodePayload[struct {
+		VotedParticipantIDs []string `json:"votedParticipantIds"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsVotesAllCast).Payload)
+	if !sameStringSet(allCast.VotedParticipantIDs, []string{voterParticipantID}) {
+		t.Fatalf("expected the remaining voter to complete the round, got %v", allCast.VotedParticipantIDs)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		_, _, err := kickedConn.Read(ctx)
+		if err == nil {
+			continue
+		}
+
+		var closeErr websocket.CloseError
+		if !errors.As(err, &closeErr) || closeErr.Code != websocket.StatusPolicyViolation {
+			t.Fatalf("expected kicked connection to be closed with policy violation, got %v", err)
+		}
+		break
+	}
+
+	var leftAt *time.Time
+	if err := db.NewSelect().
+		TableExpr("room_participants").
+		Column("left_at").
+		Where("room_participants_id = ?", kickedParticipantID).
+		Scan(context.Background(), &leftAt); err != nil {
+		t.Fatalf("failed to load kicked participant: %v", err)
+	}
+	if leftAt == nil {
+		t.Fatal("expected kicked participant to be marked as left")
+	}
+
+	rejoinConn := connectWS(t, server.URL, kickedToken)
+	defer rejoinConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, rejoinConn, ws.EventTypeHello)
+	writeEvent(t, rejoinConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+
+	rejoinCtx, rejoinCancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer rejoinCancel()
+	if _, data, err := rejoinConn.Read(rejoinCtx); err == nil {
+		t.Fatalf("expected kicked participant to be unable to rejoin, got %s", data)
+	}
+}
+
+func TestKickParticipant_KeepsRoundWhenLeavingFails(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomAsync(t, db, roomID)
+	taskID := seedTask(t, db, roomID, "Kick rollback")
+
+	_, voterUserID := createAccessToken(t, db)
+	kickedToken, kickedUserID := createAccessToken(t, db)
+	voterParticipantID := seedMemberParticipant(t, db, roomID, voterUserID)
+	kickedParticipantID := seedMemberParticipant(t, db, roomID, kickedUserID)
+
+	tasksURL := server.URL + "/api/v1/rooms/" + roomID + "/tasks/" + taskID
+	deadline := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	opened := decodeVotingResponse(t, doRealtimeRequest(t, http.MethodPost, tasksURL+"/open", adminToken, `{"deadline":"`+deadline+`"}`))
+	if !sameStringSet(opened.EligibleParticipantIDs, []string{voterParticipantID, kickedParticipantID}) {
+		t.Fatalf("expected both members to be eligible, got %+v", opened.EligibleParticipantIDs)
+	}
+	decodeVotingResponse(t, doRealtimeRequest(t, http.MethodPut, tasksURL+"/vote", kickedToken, `{"value":"5"}`))
+
+	if _, err := db.ExecContext(context.Background(), `
+		CREATE OR REPLACE FUNCTION fail_participant_leave() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'participant leave failed';
+		END;
+		$$ LANGUAGE plpgsql;
+		CREATE TRIGGER fail_participant_leave BEFORE UPDATE OF left_at ON room_participants
+			FOR EACH ROW EXECUTE FUNCTION fail_participant_leave();
+	`); err != nil {
+		t.Fatalf("failed to install failing trigger: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(context.Background(), `
+			DROP TRIGGER IF EXISTS fail_participant_leave ON room_participants;
+			DROP FUNCTION IF EXISTS fail_participant_leave();
+		`)
+	})
+
+	resp := doRealtimeRequest(t, http.MethodDelete, server.URL+"/api/v1/rooms/"+roomID+"/participants/"+kickedParticipantID, adminToken, "")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("expected 500 when the participant cannot leave, got %d", resp.StatusCode)
+	}
+
+	round, err := roomsrepositories.NewRoomTaskRoundRepository(db).GetCurrent(taskID)
+	if err != nil {
+		t.Fatalf("failed to load round: %v", err)
+	}
+	if round.Status != roomsmodels.RoomTaskRoundStatusActive || !sameStringSet(round.EligibleParticipantIDs, []string{voterParticipantID, kickedParticipantID}) {
+		t.Fatalf("expected the round to keep both voters, got %+v", round)
+	}
+
+	var votes int
+	if err := db.NewSelect().
+		TableExpr("votes").
+		ColumnExpr("COUNT(*)").
+		Where("task_id = ?", taskID).
+		Where("participant_id = ?", kickedParticipantID).
+		Scan(context.Background(), &votes); err != nil {
+		t.Fatalf("failed to count votes: %v", err)
+	}
+	if votes != 1 {
+		t.Fatalf("expected the participant's vote to be kept, got %d votes", votes)
+	}
+}
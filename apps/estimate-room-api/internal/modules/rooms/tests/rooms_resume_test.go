@@ -0,0 +1,132 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+type testEventLog struct {
+	mu     sync.Mutex
+	pubSub ws.PubSub
+	events map[string][]ws.Event
+}
+
+func newTestEventLog(pubSub ws.PubSub) *testEventLog {
+	return &testEventLog{
+		pubSub: pubSub,
+		events: make(map[string][]ws.Event),
+	}
+}
+
+func (l *testEventLog) Publish(_ context.Context, channel string, event ws.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	event.Seq = int64(len(l.events[event.RoomID]) + 1)
+	l.events[event.RoomID] = append(l.events[event.RoomID], event)
+	return l.pubSub.Publish(channel, event)
+}
+
+func (l *testEventLog) LastSeq(_ context.Context, roomID string) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return int64(len(l.events[roomID])), nil
+}
+
+func (l *testEventLog) Since(_ context.Context, roomID string, lastSeq int64) ([]ws.Event, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	events := l.events[roomID]
+	if lastSeq > int64(len(events)) {
+		return nil, false, nil
+	}
+
+	return append([]ws.Event{}, events[lastSeq:]...), true, nil
+}
+
+func TestRoomsResume_ReplaysMissedEventsAndFallsBackToSnapshot(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+
+	memberConn := connectWS(t, server.URL, memberToken)
+	readUntilEvent(t, memberConn, ws.EventTypeHello)
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+	snapshot := readUntilEvent(t, memberConn, rooms.RoomsSnapshot)
+	if snapshot.Seq == 0 {
+		t.Fatal("expected the snapshot to carry the room sequence number")
+	}
+	joined := readUntilEvent(t, adminConn, rooms.RoomsParticipantJoined)
+	if joined.Seq <= 0 {
+		t.Fatalf("expected room broadcasts to be numbered, got %d", joined.Seq)
+	}
+
+	memberConn.Close(websocket.StatusNormalClosure, "")
+	left := readUntilEvent(t, adminConn, rooms.RoomsParticipantLeft)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsChatSend,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"body": "Sent while you were away"}),
+	})
+	missedMessage := readUntilEvent(t, adminConn, rooms.RoomsChatMessage)
+	if missedMessage.Seq != left.Seq+1 {
+		t.Fatalf("expected consecutive sequence numbers, got %d after %d", missedMessage.Seq, left.Seq)
+	}
+
+	resumedConn := connectWS(t, server.URL, memberToken)
+	defer resumedConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, resumedConn, ws.EventTypeHello)
+	writeEvent(t, resumedConn, ws.Event{
+		Type:    rooms.RoomsResume,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]int64{"lastSeq": snapshot.Seq}),
+	})
+
+	replayed := make(map[int64]string)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for len(replayed) < 2 {
+		_, data, err := resumedConn.Read(ctx)
+		if err != nil {
+			t.Fatalf("failed to read replayed events: %v", err)
+		}
+		event := decodePayload[ws.Event](t, data)
+		if event.Type == rooms.RoomsSnapshot {
+			t.Fatal("expected missed events to be replayed instead of a snapshot")
+		}
+		if event.Seq == left.Seq || event.Seq == missedMessage.Seq {
+			replayed[event.Seq] = event.Type
+		}
+	}
+	if replayed[left.Seq] != rooms.RoomsParticipantLeft || replayed[missedMessage.Seq] != rooms.RoomsChatMessage {
+		t.Fatalf("unexpected replayed events: %+v", replayed)
+	}
+
+	writeEvent(t, resumedConn, ws.Event{
+		Type:    rooms.RoomsResume,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]int64{"lastSeq": missedMessage.Seq + 1000}),
+	})
+	fallback := readUntilEvent(t, resumedConn, rooms.RoomsSnapshot)
+	if fallback.Seq < missedMessage.Seq {
+		t.Fatalf("expected the fallback snapshot to carry the latest sequence number, got %d", fallback.Seq)
+	}
+}
@@ -107,8 +107,10 @@ func TestCreateRoom_RollsBackRoomAndParticipantWhenInviteCreationFails(t *testin
 		teamsrepositories.NewTeamRepository(db),
 		teamsrepositories.NewTeamMemberRepository(db),
 		usersrepositories.NewUserRepository(db),
+		usersrepositories.NewUserSettingsRepository(db),
 		&failingInvitesService{err: errors.New("invite failure")},
 		nil,
+		nil,
 	)
 
 	_, err = service.CreateRoom(context.Background(), rooms.CreateRoomInput{
@@ -0,0 +1,159 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+)
+
+type roomFlagPayload struct {
+	ParticipantID string `json:"participantId"`
+	Flag          string `json:"flag"`
+	Raised        bool   `json:"raised"`
+}
+
+func TestRoomSignals_ReactionsAndFlagsStayInMemory(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	viewerToken, viewerUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, viewerUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsReactionSend,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"emoji": "🎉"}),
+	})
+	reaction := decodePayload[struct {
+		ParticipantID string `json:"participantId"`
+		Emoji         string `json:"emoji"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsReaction).Payload)
+	if reaction.ParticipantID != memberParticipantID || reaction.Emoji != "🎉" {
+		t.Fatalf("unexpected reaction %+v", reaction)
+	}
+
+	for _, flag := range []string{"SNOOZE", "COFFEE_BREAK", "NEED_INFO"} {
+		writeEvent(t, memberConn, ws.Event{
+			Type:    rooms.RoomsFlagSet,
+			RoomID:  roomID,
+			Payload: mustMarshalJSON(t, map[string]any{"flag": flag, "raised": true}),
+		})
+	}
+	for _, want := range []string{"COFFEE_BREAK", "NEED_INFO"} {
+		changed := decodePayload[roomFlagPayload](t, readUntilEvent(t, adminConn, rooms.RoomsFlagChanged).Payload)
+		if changed.ParticipantID != memberParticipantID || changed.Flag != want || !changed.Raised {
+			t.Fatalf("expected %s to be raised, got %+v", want, changed)
+		}
+	}
+
+	snapshotFlags := func() []roomFlagPayload {
+		conn := connectWS(t, server.URL, viewerToken)
+		defer conn.Close(websocket.StatusNormalClosure, "")
+		readUntilEvent(t, conn, ws.EventTypeHello)
+		writeEvent(t, conn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+
+		return decodePayload[struct {
+			Flags []roomFlagPayload `json:"flags"`
+		}](t, readUntilEvent(t, conn, rooms.RoomsSnapshot).Payload).Flags
+	}
+
+	if flags := snapshotFlags(); len(flags) != 2 || flags[0].Flag != "COFFEE_BREAK" || flags[1].Flag != "NEED_INFO" {
+		t.Fatalf("expected both raised flags in the snapshot, got %+v", flags)
+	}
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsFlagsClear, RoomID: roomID})
+	writeEvent(t, adminConn, ws.Event{Type: rooms.RoomsFlagsClear, RoomID: roomID})
+	cleared := decodePayload[struct {
+		ClearedBy string `json:"clearedBy"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsFlagsCleared).Payload)
+	if cleared.ClearedBy == "" || cleared.ClearedBy == memberParticipantID {
+		t.Fatalf("expected only the admin to clear flags, got %+v", cleared)
+	}
+
+	if flags := snapshotFlags(); len(flags) != 0 {
+		t.Fatalf("expected the admin to clear every flag, got %+v", flags)
+	}
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsFlagSet,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]any{"flag": "NEED_INFO", "raised": true}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsFlagChanged)
+
+	memberConn.Close(websocket.StatusNormalClosure, "")
+	for {
+		left := decodePayload[roomFlagPayload](t, readUntilEvent(t, adminConn, rooms.RoomsParticipantLeft).Payload)
+		if left.ParticipantID == memberParticipantID {
+			break
+		}
+	}
+
+	if flags := snapshotFlags(); len(flags) != 0 {
+		t.Fatalf("expected a leaving participant's flags to be dropped, got %+v", flags)
+	}
+}
+
+func TestRoomsSignalService_SharesFlagsBetweenInstancesThroughRedis(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+
+	roomID := uuid.NewString()
+	participantID := uuid.NewString()
+	first := rooms.NewRoomsSignalService(client)
+	second := rooms.NewRoomsSignalService(client)
+	defer first.ClearRoomFlags(roomID)
+
+	raised, err := first.SetFlag(roomID, participantID, roomsmodels.RoomFlagNeedInfo, true)
+	if err != nil || !raised {
+		t.Fatalf("expected the flag to be raised, got %v (%v)", raised, err)
+	}
+	if raised, err := second.SetFlag(roomID, participantID, roomsmodels.RoomFlagNeedInfo, true); err != nil || raised {
+		t.Fatalf("expected raising again on another instance to change nothing, got %v (%v)", raised, err)
+	}
+	if _, err := second.SetFlag(roomID, participantID, roomsmodels.RoomFlagCoffeeBreak, true); err != nil {
+		t.Fatalf("failed to raise flag: %v", err)
+	}
+
+	flags := second.ListFlags(roomID)
+	if len(flags) != 2 || flags[0].Flag != roomsmodels.RoomFlagNeedInfo || flags[1].Flag != roomsmodels.RoomFlagCoffeeBreak {
+		t.Fatalf("expected both flags oldest first on the other instance, got %+v", flags)
+	}
+	if ttl := client.TTL(context.Background(), "rooms:"+roomID+":flags").Val(); ttl <= 0 {
+		t.Fatalf("expected the room's flags to expire, got TTL %v", ttl)
+	}
+
+	second.ClearParticipantFlags(roomID, participantID)
+	if flags := first.ListFlags(roomID); len(flags) != 0 {
+		t.Fatalf("expected a participant's flags to be dropped everywhere, got %+v", flags)
+	}
+
+	if _, err := second.SetFlag(roomID, participantID, roomsmodels.RoomFlagNeedInfo, true); err != nil {
+		t.Fatalf("failed to raise flag: %v", err)
+	}
+	if err := first.ClearRoomFlags(roomID); err != nil {
+		t.Fatalf("failed to clear room flags: %v", err)
+	}
+	if flags := second.ListFlags(roomID); len(flags) != 0 {
+		t.Fatalf("expected the room's flags to be cleared everywhere, got %+v", flags)
+	}
+}
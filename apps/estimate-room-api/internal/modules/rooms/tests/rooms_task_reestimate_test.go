@@ -0,0 +1,120 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func TestTaskReestimate_ReopensEstimatedTaskIntoNewRound(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Reestimated task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskFinalize,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	readUntilEvent(t, memberConn, rooms.RoomsTaskFinalized)
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsTaskReestimate,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskReestimate,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+
+	reopened := decodePayload[struct {
+		TaskID                     string  `json:"taskId"`
+		PreviousStatus             string  `json:"previousStatus"`
+		PreviousFinalEstimateValue *string `json:"previousFinalEstimateValue"`
+		RoundNumber                int     `json:"roundNumber"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsTaskReopened).Payload)
+	if reopened.TaskID != taskID || reopened.PreviousStatus != "ESTIMATED" || reopened.RoundNumber != 2 {
+		t.Fatalf("expected the estimated task to reopen in round 2, got %+v", reopened)
+	}
+	if reopened.PreviousFinalEstimateValue == nil || *reopened.PreviousFinalEstimateValue != "3" {
+		t.Fatalf("expected the replaced estimate 3, got %+v", reopened.PreviousFinalEstimateValue)
+	}
+
+	current := decodePayload[struct {
+		CurrentTaskID string `json:"currentTaskId"`
+		RoundNumber   int    `json:"roundNumber"`
+	}](t, readUntilEvent(t, memberConn, rooms.RoomsTaskCurrentChanged).Payload)
+	if current.CurrentTaskID != taskID || current.RoundNumber != 2 {
+		t.Fatalf("expected the reopened task to be current in round 2, got %+v", current)
+	}
+
+	var task struct {
+		Status             string  `bun:"status"`
+		FinalEstimateValue *string `bun:"final_estimate_value"`
+	}
+	if err := db.NewSelect().
+		TableExpr("tasks").
+		Column("status", "final_estimate_value").
+		Where("task_id = ?", taskID).
+		Scan(context.Background(), &task); err != nil {
+		t.Fatalf("failed to load task: %v", err)
+	}
+	if task.Status != "VOTING" || task.FinalEstimateValue != nil {
+		t.Fatalf("expected the task to be voting without an estimate, got %+v", task)
+	}
+
+	var firstRoundVotes, reestimations int
+	if err := db.NewSelect().
+		TableExpr("votes").
+		ColumnExpr("COUNT(*)").
+		Where("task_id = ?", taskID).
+		Where("round_number = 1").
+		Scan(context.Background(), &firstRoundVotes); err != nil {
+		t.Fatalf("failed to count votes: %v", err)
+	}
+	if err := db.NewSelect().
+		TableExpr("task_reestimations").
+		ColumnExpr("COUNT(*)").
+		Where("task_id = ?", taskID).
+		Where("reopened_by_user_id = ?", adminUserID).
+		Scan(context.Background(), &reestimations); err != nil {
+		t.Fatalf("failed to count reestimations: %v", err)
+	}
+	if firstRoundVotes != 1 || reestimations != 1 {
+		t.Fatalf("expected round 1 votes to stay and one reestimation by the admin, got votes=%d reestimations=%d", firstRoundVotes, reestimations)
+	}
+}
@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+type roomTaskSkippedPayload struct {
+	TaskID string `json:"taskId"`
+	Status string `json:"status"`
+}
+
+func TestTaskSkip_SkipsCurrentOrNamedTask(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	currentTaskID := seedTask(t, db, roomID, "Current task")
+	backlogTaskID := seedTask(t, db, roomID, "Backlog task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": currentTaskID}),
+	})
+	readUntilEvent(t, memberConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsTaskSkip, RoomID: roomID})
+	writeEvent(t, adminConn, ws.Event{Type: rooms.RoomsTaskSkip, RoomID: roomID})
+	skipped := decodePayload[roomTaskSkippedPayload](t, readUntilEvent(t, memberConn, rooms.RoomsTaskSkipped).Payload)
+	if skipped.TaskID != currentTaskID || skipped.Status != "SKIPPED" {
+		t.Fatalf("expected the current task to be skipped, got %+v", skipped)
+	}
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSkip,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": backlogTaskID}),
+	})
+	skipped = decodePayload[roomTaskSkippedPayload](t, readUntilEvent(t, memberConn, rooms.RoomsTaskSkipped).Payload)
+	if skipped.TaskID != backlogTaskID {
+		t.Fatalf("expected the named task to be skipped, got %+v", skipped)
+	}
+
+	var tasks []struct {
+		Status   string `bun:"status"`
+		IsActive bool   `bun:"is_active"`
+	}
+	if err := db.NewSelect().
+		TableExpr("tasks").
+		Column("status", "is_active").
+		Where("room_id = ?", roomID).
+		Scan(context.Background(), &tasks); err != nil {
+		t.Fatalf("failed to load tasks: %v", err)
+	}
+	for _, task := range tasks {
+		if task.Status != "SKIPPED" || task.IsActive {
+			t.Fatalf("expected every task to be skipped and inactive, got %+v", tasks)
+		}
+	}
+}
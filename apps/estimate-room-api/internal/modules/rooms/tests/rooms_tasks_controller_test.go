@@ -104,6 +104,16 @@ func seedRoom(t *testing.T, db *bun.DB, adminUserID string) string {
 	return roomID
 }
 
+func setRoomOptions(t *testing.T, db *bun.DB, roomID, options string) {
+	t.Helper()
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE rooms SET options = $2::jsonb WHERE room_id = $1
+	`, roomID, options); err != nil {
+		t.Fatalf("failed to set room options: %v", err)
+	}
+}
+
 func createAccessToken(t *testing.T, db *bun.DB) (string, string) {
 	t.Helper()
 
@@ -233,6 +243,20 @@ func (s *stubRoomsService) UpdateRoom(
 	return nil, nil
 }
 
+func (s *stubRoomsService) FinishRoom(roomID, userID string) (*roomsmodels.RoomsModel, error) {
+	return nil, nil
+}
+
+func (s *stubRoomsService) OnFinish(handler rooms.RoomFinishedHandler) {}
+
+func (s *stubRoomsService) CloneRoom(
+	ctx context.Context,
+	roomID, userID string,
+	input rooms.CloneRoomInput,
+) (*rooms.CreateRoomResult, error) {
+	return nil, nil
+}
+
 type stubAuthService struct {
 	userID string
 	err    error
@@ -296,6 +320,9 @@ func TestCreateRoom_ReturnsInternalForUnexpectedServiceErrors(t *testing.T) {
 		},
 		nil,
 		nil,
+		nil,
+		nil,
+		nil,
 		&stubAuthService{userID: uuid.NewString()},
 	)
 	router.Post("/rooms", controller.CreateRoom)
@@ -488,6 +515,85 @@ func TestCreateRoom_RejectsInviteTeamIdForNonOwner(t *testing.T) {
 	}
 }
 
+func TestCreateRoom_UsesSavedDeckByID(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessTokenForEmail(t, db, "owner@example.com")
+	otherToken, _ := createAccessTokenForEmail(t, db, "other@example.com")
+
+	deckID := uuid.NewString()
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO decks (deck_id, name, kind, values, owner_user_id)
+		VALUES ($1, 'Hours', 'CUSTOM', '["1","2","4","8","?"]'::jsonb, $2)
+	`, deckID, userID)
+	if err != nil {
+		t.Fatalf("failed to insert deck: %v", err)
+	}
+
+	created := createRoomViaAPI(t, router, accessToken, `{"name":"Saved Deck","deckId":"`+deckID+`"}`)
+	if created.Room.Deck.Name != "Hours" || created.Room.Deck.Kind != "CUSTOM" {
+		t.Fatalf("expected saved deck on room, got %+v", created.Room.Deck)
+	}
+	if len(created.Room.Deck.Values) != 5 || created.Room.Deck.Values[4] != "?" {
+		t.Fatalf("expected saved deck values on room, got %v", created.Room.Deck.Values)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/rooms/", bytes.NewReader([]byte(`{"name":"Stolen Deck","deckId":"`+deckID+`"}`)))
+	req.Header.Set("Authorization", "Bearer "+otherToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 Forbidden for another user's deck, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCreateRoom_AppliesCreatorDefaultsWhenOmitted(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessTokenForEmail(t, db, "owner@example.com")
+
+	deckID := uuid.NewString()
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO decks (deck_id, name, kind, values, owner_user_id)
+		VALUES ($1, 'T-Shirt', 'TSHIRT', '["S","M","L"]'::jsonb, $2)
+	`, deckID, userID)
+	if err != nil {
+		t.Fatalf("failed to insert deck: %v", err)
+	}
+
+	_, err = db.ExecContext(context.Background(), `
+		INSERT INTO user_settings (user_id, default_deck_id, default_room_options)
+		VALUES ($1, $2, '{"createShareLink":true}'::jsonb)
+	`, userID, deckID)
+	if err != nil {
+		t.Fatalf("failed to insert user settings: %v", err)
+	}
+
+	created := createRoomViaAPI(t, router, accessToken, `{"name":"Defaults"}`)
+	if created.Room.Deck.Kind != "TSHIRT" {
+		t.Fatalf("expected default deck to be applied, got %+v", created.Room.Deck)
+	}
+	if created.ShareLink == nil {
+		t.Fatal("expected default room options to create a share link")
+	}
+
+	explicit := createRoomViaAPI(t, router, accessToken, `{
+		"name":"Explicit",
+		"deck":{"name":"Fibonacci","kind":"FIBONACCI","values":["1","2","3"]},
+		"options":{"createShareLink":false}
+	}`)
+	if explicit.Room.Deck.Kind != "FIBONACCI" {
+		t.Fatalf("expected explicit deck to win over default, got %+v", explicit.Room.Deck)
+	}
+	if explicit.ShareLink != nil {
+		t.Fatal("expected explicit options to win over defaults")
+	}
+}
+
 func TestTasksCRUD(t *testing.T) {
 	router, db := setupRoomsTasksTest(t)
 	defer db.Close()
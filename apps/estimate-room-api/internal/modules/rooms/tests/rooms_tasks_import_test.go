@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	roomsdto "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/dto"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+func TestParseImportRoomTasksCSV_MapsHeaderColumnsAndSkipsBlankLines(t *testing.T) {
+	input := "External_Key,Title,Description\nAPI-1,Login form,\"Email, password\"\n\n,Logout,\n"
+
+	dto, err := roomsdto.ParseImportRoomTasksCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("failed to parse csv: %v", err)
+	}
+	if err := dto.Validate(); err != nil {
+		t.Fatalf("expected valid import, got %v", err)
+	}
+
+	if len(dto.Tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(dto.Tasks))
+	}
+	if dto.Tasks[0].Title != "Login form" || dto.Tasks[0].Description != "Email, password" || dto.Tasks[0].ExternalKey != "API-1" {
+		t.Fatalf("unexpected first task: %+v", dto.Tasks[0])
+	}
+	if dto.Tasks[1].Title != "Logout" || dto.Tasks[1].ExternalKey != "" {
+		t.Fatalf("unexpected second task: %+v", dto.Tasks[1])
+	}
+}
+
+func TestParseImportRoomTasksCSV_RequiresTitleColumn(t *testing.T) {
+	if _, err := roomsdto.ParseImportRoomTasksCSV(strings.NewReader("description\nSomething\n")); err == nil {
+		t.Fatal("expected missing title column to fail")
+	}
+}
+
+func TestImportRoomTasksDTO_ReportsInvalidTaskPosition(t *testing.T) {
+	dto := roomsdto.ImportRoomTasksDTO{Tasks: []roomsdto.CreateRoomTaskDTO{{Title: "First"}, {Title: "  "}}}
+
+	err := dto.Validate()
+	if err == nil || !strings.Contains(err.Error(), "task 2") {
+		t.Fatalf("expected error for task 2, got %v", err)
+	}
+}
+
+func TestImportTasks_CreatesJSONAndCSVBacklogsInOrder(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, userID)
+
+	rr := doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks/import", accessToken,
+		`[{"title":"First","externalKey":"K-1"},{"title":"Second","description":"Details"}]`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for json import, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks/import", bytes.NewReader([]byte("title,external_key\nThird,K-3\n")))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK for csv import, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodGet, "/api/v1/rooms/"+roomID+"/tasks", accessToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK listing tasks, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var tasks []roomsmodels.RoomTaskModel
+	if err := json.NewDecoder(rr.Body).Decode(&tasks); err != nil {
+		t.Fatalf("failed to decode tasks: %v", err)
+	}
+
+	titles := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		titles = append(titles, task.Title)
+	}
+	if strings.Join(titles, ",") != "First,Second,Third" {
+		t.Fatalf("expected imported order First,Second,Third, got %v", titles)
+	}
+}
+
+func TestImportTasks_RejectsWholeBatchWhenOneTaskIsInvalid(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, userID)
+
+	rr := doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks/import", accessToken,
+		`[{"title":"Valid"},{"title":""}]`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 Bad Request, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var count int
+	if err := db.NewSelect().TableExpr("tasks").ColumnExpr("COUNT(*)").Where("room_id = ?", roomID).Scan(t.Context(), &count); err != nil {
+		t.Fatalf("failed to count tasks: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected no tasks to be created, got %d", count)
+	}
+}
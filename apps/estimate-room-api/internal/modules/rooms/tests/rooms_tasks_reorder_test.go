@@ -0,0 +1,218 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+func createTaskViaAPI(t *testing.T, router *chi.Mux, roomID, accessToken, title string) string {
+	t.Helper()
+
+	rr := doRoomsRequest(t, router, http.MethodPost, "/api/v1/rooms/"+roomID+"/tasks", accessToken, `{"title":"`+title+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("failed to create task %s: %d %s", title, rr.Code, rr.Body.String())
+	}
+
+	var task roomsmodels.RoomTaskModel
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode task: %v", err)
+	}
+
+	return task.TaskID
+}
+
+func listTaskTitles(t *testing.T, router *chi.Mux, roomID, accessToken string) string {
+	t.Helper()
+
+	rr := doRoomsRequest(t, router, http.MethodGet, "/api/v1/rooms/"+roomID+"/tasks", accessToken, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK listing tasks, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var tasks []roomsmodels.RoomTaskModel
+	if err := json.NewDecoder(rr.Body).Decode(&tasks); err != nil {
+		t.Fatalf("failed to decode tasks: %v", err)
+	}
+
+	titles := make([]string, 0, len(tasks))
+	for idx, task := range tasks {
+		if task.Position != idx {
+			t.Fatalf("expected task %s at position %d, got %d", task.Title, idx, task.Position)
+		}
+		titles = append(titles, task.Title)
+	}
+
+	return strings.Join(titles, ",")
+}
+
+func TestReorderTasks_MovesSingleAndMultipleTasks(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, userID)
+	orderPath := "/api/v1/rooms/" + roomID + "/tasks/order"
+
+	taskA := createTaskViaAPI(t, router, roomID, accessToken, "A")
+	taskB := createTaskViaAPI(t, router, roomID, accessToken, "B")
+	taskC := createTaskViaAPI(t, router, roomID, accessToken, "C")
+	taskD := createTaskViaAPI(t, router, roomID, accessToken, "D")
+	if titles := listTaskTitles(t, router, roomID, accessToken); titles != "A,B,C,D" {
+		t.Fatalf("expected creation order A,B,C,D, got %s", titles)
+	}
+
+	rr := doRoomsRequest(t, router, http.MethodPut, orderPath, accessToken, `{"taskIds":["`+taskD+`"],"position":0}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK moving one task, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if titles := listTaskTitles(t, router, roomID, accessToken); titles != "D,A,B,C" {
+		t.Fatalf("expected D,A,B,C, got %s", titles)
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, orderPath, accessToken, `{"taskIds":["`+taskC+`","`+taskD+`"],"position":99}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK moving several tasks, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if titles := listTaskTitles(t, router, roomID, accessToken); titles != "A,B,C,D" {
+		t.Fatalf("expected A,B,C,D, got %s", titles)
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, orderPath, accessToken, `{"taskIds":["`+taskB+`","missing-task"],"position":0}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown task, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if titles := listTaskTitles(t, router, roomID, accessToken); titles != "A,B,C,D" {
+		t.Fatalf("expected a failed reorder to keep A,B,C,D, got %s", titles)
+	}
+
+	rr = doRoomsRequest(t, router, http.MethodPut, orderPath, accessToken, `{"taskIds":["`+taskA+`","`+taskA+`"],"position":0}`)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for duplicate task ids, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+	rr = doRoomsRequest(t, router, http.MethodPut, orderPath, memberToken, `{"taskIds":["`+taskA+`"],"position":3}`)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for member, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestReorderTasks_BroadcastsOrderAndUpdatesSnapshot(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	firstTaskID := seedTask(t, db, roomID, "First")
+	secondTaskID := seedTask(t, db, roomID, "Second")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, memberConn, roomID)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL+"/api/v1/rooms/"+roomID+"/tasks/order", bytes.NewReader(mustMarshalJSON(t, map[string]any{
+		"taskIds":  []string{secondTaskID},
+		"position": 0,
+	})))
+	if err != nil {
+		t.Fatalf("failed to build reorder request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("reorder request failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d", resp.StatusCode)
+	}
+
+	event := readUntilEvent(t, memberConn, rooms.RoomsTasksReordered)
+	payload := decodePayload[struct {
+		TaskIDs []string `json:"taskIds"`
+	}](t, event.Payload)
+	if strings.Join(payload.TaskIDs, ",") != secondTaskID+","+firstTaskID {
+		t.Fatalf("unexpected reordered task ids %v", payload.TaskIDs)
+	}
+
+	otherConn := connectWS(t, server.URL, adminToken)
+	defer otherConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, otherConn, ws.EventTypeHello)
+	writeEvent(t, otherConn, ws.Event{Type: rooms.RoomsJoin, RoomID: roomID})
+	snapshotEvent := readUntilEvent(t, otherConn, rooms.RoomsSnapshot)
+	snapshot := decodePayload[struct {
+		Tasks []struct {
+			TaskID   string `json:"taskId"`
+			Position int    `json:"position"`
+		} `json:"tasks"`
+	}](t, snapshotEvent.Payload)
+	if len(snapshot.Tasks) != 2 || snapshot.Tasks[0].TaskID != secondTaskID || snapshot.Tasks[1].Position != 1 {
+		t.Fatalf("unexpected snapshot task order %+v", snapshot.Tasks)
+	}
+}
+
+func TestCreateTasks_ConcurrentCreatesGetDistinctPositions(t *testing.T) {
+	router, db := setupRoomsTasksTest(t)
+	defer db.Close()
+
+	accessToken, userID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, userID)
+	taskRepo := roomsrepositories.NewRoomTaskRepository(db)
+
+	newTask := func(title string) *roomsmodels.RoomTaskModel {
+		return &roomsmodels.RoomTaskModel{
+			TaskID: uuid.NewString(),
+			RoomID: roomID,
+			Title:  title,
+			Status: "PENDING",
+		}
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for idx := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := taskRepo.Create(newTask("task-" + strconv.Itoa(idx)))
+			errs <- err
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := taskRepo.CreateMany(context.Background(), []*roomsmodels.RoomTaskModel{newTask("import-0"), newTask("import-1")})
+		errs <- err
+	}()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+	}
+
+	// listTaskTitles fails unless the tasks are numbered 0..n-1 in order.
+	if titles := strings.Split(listTaskTitles(t, router, roomID, accessToken), ","); len(titles) != 10 {
+		t.Fatalf("expected 10 tasks, got %v", titles)
+	}
+}
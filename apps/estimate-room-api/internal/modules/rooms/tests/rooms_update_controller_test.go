@@ -33,6 +33,21 @@ func (s *failingRewardService) NotifyAppliedRewards(
 	return nil
 }
 
+func (s *failingRewardService) ApplyTeamTerminalRewards(
+	ctx context.Context,
+	db bun.IDB,
+	room *roomsmodels.RoomsModel,
+) (*gamification.AppliedTeamReward, error) {
+	return nil, s.applyErr
+}
+
+func (s *failingRewardService) NotifyAppliedTeamReward(
+	ctx context.Context,
+	reward *gamification.AppliedTeamReward,
+) error {
+	return nil
+}
+
 func TestUpdateRoom_ChangesFieldsForCreator(t *testing.T) {
 	router, db := setupRoomsTasksTest(t)
 	defer db.Close()
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/coder/websocket"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+)
+
+type voteStatusChangedPayload struct {
+	TaskID        string `json:"taskId"`
+	ParticipantID string `json:"participantId"`
+	Voted         bool   `json:"voted"`
+}
+
+func TestVoteRetract_ClearsVoteAndRecordsHistory(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Retract task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	for _, value := range []string{"3", "3", "5"} {
+		writeEvent(t, memberConn, ws.Event{
+			Type:    rooms.RoomsVoteCast,
+			RoomID:  roomID,
+			Payload: mustMarshalJSON(t, map[string]string{"value": value}),
+		})
+		status := decodePayload[voteStatusChangedPayload](t, readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged).Payload)
+		if !status.Voted {
+			t.Fatalf("expected voted status after casting %s, got %+v", value, status)
+		}
+	}
+
+	writeEvent(t, memberConn, ws.Event{Type: rooms.RoomsVoteRetract, RoomID: roomID})
+	status := decodePayload[voteStatusChangedPayload](t, readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged).Payload)
+	if status.Voted || status.ParticipantID != memberParticipantID || status.TaskID != taskID {
+		t.Fatalf("expected retracted vote status for %s, got %+v", memberParticipantID, status)
+	}
+
+	var voteCount int
+	if err := db.NewSelect().
+		TableExpr("votes").
+		ColumnExpr("COUNT(*)").
+		Where("task_id = ?", taskID).
+		Where("participant_id = ?", memberParticipantID).
+		Scan(context.Background(), &voteCount); err != nil {
+		t.Fatalf("failed to count votes: %v", err)
+	}
+	if voteCount != 0 {
+		t.Fatalf("expected retracted vote to be removed, got %d votes", voteCount)
+	}
+
+	var actions []string
+	if err := db.NewSelect().
+		TableExpr("vote_changes").
+		Column("action").
+		Where("task_id = ?", taskID).
+		Where("participant_id = ?", memberParticipantID).
+		OrderExpr("created_at ASC").
+		Scan(context.Background(), &actions); err != nil {
+		t.Fatalf("failed to load vote changes: %v", err)
+	}
+	if strings.Join(actions, ",") != "CAST,CHANGED,RETRACTED" {
+		t.Fatalf("expected CAST,CHANGED,RETRACTED history, got %v", actions)
+	}
+}
@@ -3,9 +3,14 @@ package tests
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/master-bogdan/estimate-room-api/internal/modules/rooms"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
 	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
@@ -43,12 +48,14 @@ func setupRoomsVoteServiceTest(t *testing.T) (*bun.DB, rooms.RoomsVoteService, r
 	participantRepo := roomsrepositories.NewRoomParticipantRepository(db)
 	expiryService := rooms.NewRoomsExpiryService(db, roomsRepo, nil, nil)
 	voteService := rooms.NewRoomsVoteService(
+		db,
 		roomsRepo,
 		roomsrepositories.NewRoomTaskRepository(db),
 		roomsrepositories.NewRoomVoteRepository(db),
 		roomsrepositories.NewRoomTaskRoundRepository(db),
 		participantRepo,
 		expiryService,
+		nil,
 	)
 
 	return db, voteService, participantRepo
@@ -74,7 +81,7 @@ func TestRoomsVoteService_CastVoteRejectsValueOutsideDeck(t *testing.T) {
 		t.Fatalf("failed to load member participant: %v", err)
 	}
 
-	_, err = voteService.CastVote(roomID, participant, "13")
+	_, err = voteService.CastVote(roomID, participant, "", "13")
 	if !errors.Is(err, apperrors.ErrBadRequest) {
 		t.Fatalf("expected ErrBadRequest for invalid deck vote, got %v", err)
 	}
@@ -116,8 +123,299 @@ func TestRoomsVoteService_FinalizeCurrentTaskRequiresRevealedRound(t *testing.T)
 		t.Fatalf("failed to set current task: %v", err)
 	}
 
-	_, err := voteService.FinalizeCurrentTask(roomID, adminUserID, "5")
+	_, err := voteService.FinalizeCurrentTask(roomID, adminUserID, "5", nil)
 	if !errors.Is(err, apperrors.ErrBadRequest) {
 		t.Fatalf("expected ErrBadRequest when finalizing before reveal, got %v", err)
 	}
 }
+
+func TestRoomsVoteService_ReopenTaskRequiresClosedTask(t *testing.T) {
+	db, voteService, _ := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-reopen@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "member-reopen@example.com", "password123")
+
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	taskID := seedTask(t, db, roomID, "Reopen guard")
+
+	_, err := voteService.ReopenTask(roomID, taskID, adminUserID, []string{memberParticipantID})
+	if !errors.Is(err, apperrors.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest when reopening a pending task, got %v", err)
+	}
+
+	_, err = voteService.ReopenTask(roomID, taskID, memberUserID, []string{memberParticipantID})
+	if !errors.Is(err, apperrors.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden when a member reopens a task, got %v", err)
+	}
+}
+
+func TestRoomsVoteService_ReopenTaskKeepsEstimateWhenReestimationFails(t *testing.T) {
+	db, voteService, participantRepo := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-reopen-fail@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "member-reopen-fail@example.com", "password123")
+
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	taskID := seedTask(t, db, roomID, "Reopen rollback")
+
+	if _, _, _, err := voteService.SetCurrentTask(roomID, taskID, adminUserID, []string{memberParticipantID}); err != nil {
+		t.Fatalf("failed to set current task: %v", err)
+	}
+	participant, err := participantRepo.FindActiveByUserID(roomID, memberUserID)
+	if err != nil {
+		t.Fatalf("failed to load member participant: %v", err)
+	}
+	if _, err := voteService.CastVote(roomID, participant, "", "3"); err != nil {
+		t.Fatalf("failed to cast vote: %v", err)
+	}
+	if _, err := voteService.RevealCurrentRound(roomID, adminUserID); err != nil {
+		t.Fatalf("failed to reveal round: %v", err)
+	}
+	if _, err := voteService.FinalizeCurrentTask(roomID, adminUserID, "3", nil); err != nil {
+		t.Fatalf("failed to finalize task: %v", err)
+	}
+
+	if _, err := db.ExecContext(context.Background(), `
+		CREATE OR REPLACE FUNCTION fail_task_reestimation() RETURNS trigger AS $$
+		BEGIN
+			RAISE EXCEPTION 'reestimation insert failed';
+		END;
+		$$ LANGUAGE plpgsql;
+		CREATE TRIGGER fail_task_reestimation BEFORE INSERT ON task_reestimations
+			FOR EACH ROW EXECUTE FUNCTION fail_task_reestimation();
+	`); err != nil {
+		t.Fatalf("failed to install failing trigger: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = db.ExecContext(context.Background(), `
+			DROP TRIGGER IF EXISTS fail_task_reestimation ON task_reestimations;
+			DROP FUNCTION IF EXISTS fail_task_reestimation();
+		`)
+	})
+
+	if _, err := voteService.ReopenTask(roomID, taskID, adminUserID, []string{memberParticipantID}); err == nil {
+		t.Fatal("expected reopening to fail when the reestimation cannot be recorded")
+	}
+
+	var task struct {
+		Status             string  `bun:"status"`
+		FinalEstimateValue *string `bun:"final_estimate_value"`
+	}
+	if err := db.NewSelect().
+		TableExpr("tasks").
+		Column("status", "final_estimate_value").
+		Where("task_id = ?", taskID).
+		Scan(context.Background(), &task); err != nil {
+		t.Fatalf("failed to load task: %v", err)
+	}
+	if task.Status != "ESTIMATED" || task.FinalEstimateValue == nil || *task.FinalEstimateValue != "3" {
+		t.Fatalf("expected the task to keep its estimate, got %+v", task)
+	}
+
+	var rounds int
+	if err := db.NewSelect().
+		TableExpr("task_rounds").
+		ColumnExpr("COUNT(*)").
+		Where("task_id = ?", taskID).
+		Scan(context.Background(), &rounds); err != nil {
+		t.Fatalf("failed to count rounds: %v", err)
+	}
+	if rounds != 1 {
+		t.Fatalf("expected no new round, got %d rounds", rounds)
+	}
+}
+
+func TestRoomsVoteService_CastVoteKeepsEveryConcurrentAsyncJoiner(t *testing.T) {
+	db, voteService, participantRepo := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-async-join@example.com", "password123")
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomAsync(t, db, roomID)
+	taskID := seedTask(t, db, roomID, "Async joiners")
+
+	if _, err := voteService.OpenTask(roomID, taskID, adminUserID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to open task: %v", err)
+	}
+
+	participants := make([]*roomsmodels.RoomParticipantModel, 0, 5)
+	for i := range 5 {
+		userID := testutils.SeedUser(t, db, fmt.Sprintf("member-async-join-%d@example.com", i), "password123")
+		seedMemberParticipant(t, db, roomID, userID)
+		participant, err := participantRepo.FindActiveByUserID(roomID, userID)
+		if err != nil {
+			t.Fatalf("failed to load member participant: %v", err)
+		}
+		participants = append(participants, participant)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(participants))
+	for _, participant := range participants {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := voteService.CastVote(roomID, participant, "", "5"); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("failed to cast vote: %v", err)
+	}
+
+	round, err := roomsrepositories.NewRoomTaskRoundRepository(db).GetCurrent(taskID)
+	if err != nil {
+		t.Fatalf("failed to load round: %v", err)
+	}
+	for _, participant := range participants {
+		if !slices.Contains(round.EligibleParticipantIDs, participant.RoomParticipantID) {
+			t.Fatalf("expected every joiner to stay eligible, got %v", round.EligibleParticipantIDs)
+		}
+	}
+	if len(round.EligibleParticipantIDs) != len(participants) {
+		t.Fatalf("expected %d eligible voters, got %v", len(participants), round.EligibleParticipantIDs)
+	}
+}
+
+func TestRoomsVoteService_AutoRevealRoundRevealsOnce(t *testing.T) {
+	db, voteService, participantRepo := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-auto@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "member-auto@example.com", "password123")
+
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"autoReveal":true}`)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	taskID := seedTask(t, db, roomID, "Auto reveal")
+
+	if _, _, _, err := voteService.SetCurrentTask(roomID, taskID, adminUserID, []string{memberParticipantID}); err != nil {
+		t.Fatalf("failed to set current task: %v", err)
+	}
+
+	participant, err := participantRepo.FindActiveByUserID(roomID, memberUserID)
+	if err != nil {
+		t.Fatalf("failed to load member participant: %v", err)
+	}
+
+	castResult, err := voteService.CastVote(roomID, participant, "", "5")
+	if err != nil {
+		t.Fatalf("failed to cast vote: %v", err)
+	}
+	if !castResult.AllVotesCast || !castResult.AutoReveal {
+		t.Fatalf("expected all votes cast with auto reveal, got allVotesCast=%t autoReveal=%t", castResult.AllVotesCast, castResult.AutoReveal)
+	}
+
+	revealed, err := voteService.AutoRevealRound(roomID, taskID, castResult.Round.RoundNumber)
+	if err != nil {
+		t.Fatalf("expected auto reveal to succeed, got %v", err)
+	}
+	if revealed.Round.Status != roomsmodels.RoomTaskRoundStatusRevealed || len(revealed.Votes) != 1 {
+		t.Fatalf("expected revealed round with one vote, got status=%s votes=%d", revealed.Round.Status, len(revealed.Votes))
+	}
+
+	if _, err := voteService.AutoRevealRound(roomID, taskID, castResult.Round.RoundNumber); !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("expected ErrConflict for a second auto reveal, got %v", err)
+	}
+	if _, err := voteService.RevealCurrentRound(roomID, adminUserID); !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("expected ErrConflict for a manual reveal after the auto reveal, got %v", err)
+	}
+}
+
+func TestRoomsVoteService_RevealExpiredRoundWaitsForDeadline(t *testing.T) {
+	db, voteService, _ := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-timer@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "member-timer@example.com", "password123")
+
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"votingTimerSeconds":60}`)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	taskID := seedTask(t, db, roomID, "Timed round")
+
+	_, _, round, err := voteService.SetCurrentTask(roomID, taskID, adminUserID, []string{memberParticipantID})
+	if err != nil {
+		t.Fatalf("failed to set current task: %v", err)
+	}
+	if round.TimerEndsAt == nil || round.TimerDurationSeconds == nil || *round.TimerDurationSeconds != 60 {
+		t.Fatalf("expected a 60 second timer on the new round, got endsAt=%v duration=%v", round.TimerEndsAt, round.TimerDurationSeconds)
+	}
+
+	if _, err := voteService.RevealExpiredRound(roomID, taskID, round.RoundNumber); !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("expected ErrConflict before the deadline, got %v", err)
+	}
+
+	if _, err := db.ExecContext(context.Background(), `
+		UPDATE task_rounds
+		SET timer_ends_at = NOW() - INTERVAL '1 second'
+		WHERE task_id = $1 AND round_number = $2
+	`, taskID, round.RoundNumber); err != nil {
+		t.Fatalf("failed to expire round timer: %v", err)
+	}
+
+	revealed, err := voteService.RevealExpiredRound(roomID, taskID, round.RoundNumber)
+	if err != nil {
+		t.Fatalf("expected expired round to be revealed, got %v", err)
+	}
+	if revealed.Round.Status != roomsmodels.RoomTaskRoundStatusRevealed {
+		t.Fatalf("expected revealed round, got %s", revealed.Round.Status)
+	}
+
+	if _, err := voteService.RevealExpiredRound(roomID, taskID, round.RoundNumber); !errors.Is(err, apperrors.ErrConflict) {
+		t.Fatalf("expected ErrConflict once the round is revealed, got %v", err)
+	}
+}
+
+func TestRoomsVoteService_ObserverCannotVoteAndAdminCannotObserve(t *testing.T) {
+	db, voteService, participantRepo := setupRoomsVoteServiceTest(t)
+	defer db.Close()
+
+	adminUserID := testutils.SeedUser(t, db, "admin-observer@example.com", "password123")
+	memberUserID := testutils.SeedUser(t, db, "member-observer@example.com", "password123")
+
+	roomID := seedRoom(t, db, adminUserID)
+	memberParticipantID := seedMemberParticipant(t, db, roomID, memberUserID)
+	taskID := seedTask(t, db, roomID, "Observer guard")
+
+	if _, _, _, err := voteService.SetCurrentTask(roomID, taskID, adminUserID, []string{memberParticipantID}); err != nil {
+		t.Fatalf("failed to set current task: %v", err)
+	}
+
+	result, err := voteService.SetParticipantObserver(roomID, adminUserID, memberParticipantID, true)
+	if err != nil {
+		t.Fatalf("failed to switch member to observer: %v", err)
+	}
+	if result.Participant.Role != roomsmodels.RoomParticipantRoleObserver {
+		t.Fatalf("expected OBSERVER role, got %s", result.Participant.Role)
+	}
+	if result.Round == nil || len(result.Round.EligibleParticipantIDs) != 0 {
+		t.Fatalf("expected observer to leave the round eligibility, got %+v", result.Round)
+	}
+
+	participant, err := participantRepo.FindActiveByID(roomID, memberParticipantID)
+	if err != nil {
+		t.Fatalf("failed to load observer participant: %v", err)
+	}
+	if _, err := voteService.CastVote(roomID, participant, "", "3"); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for observer vote, got %v", err)
+	}
+
+	admin, err := participantRepo.FindActiveByUserID(roomID, adminUserID)
+	if err != nil {
+		t.Fatalf("failed to load admin participant: %v", err)
+	}
+	if _, err := voteService.SetParticipantObserver(roomID, adminUserID, admin.RoomParticipantID, true); !errors.Is(err, apperrors.ErrBadRequest) {
+		t.Fatalf("expected ErrBadRequest when switching the admin, got %v", err)
+	}
+	if _, err := voteService.SetParticipantObserver(roomID, memberUserID, memberParticipantID, false); !errors.Is(err, apperrors.ErrForbidden) {
+		t.Fatalf("expected ErrForbidden for non-admin toggle, got %v", err)
+	}
+}
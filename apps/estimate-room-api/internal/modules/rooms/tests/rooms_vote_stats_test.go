@@ -0,0 +1,64 @@
+package tests
+
+import (
+	"testing"
+
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+func TestComputeVoteStats_IgnoresNonNumericCardsAndDetectsAdjacentConsensus(t *testing.T) {
+	deck := roomsmodels.DefaultRoomDeck()
+
+	stats := roomsmodels.ComputeVoteStats(deck, []string{"3", "5", "5", "?"})
+
+	if stats.NumericVotes != 3 {
+		t.Fatalf("expected 3 numeric votes, got %d", stats.NumericVotes)
+	}
+	if stats.Median == nil || *stats.Median != 5 {
+		t.Fatalf("expected median 5, got %v", stats.Median)
+	}
+	if stats.Min == nil || *stats.Min != 3 || stats.Max == nil || *stats.Max != 5 {
+		t.Fatalf("expected min 3 and max 5, got %v and %v", stats.Min, stats.Max)
+	}
+	if stats.NearestDeckValue == nil || *stats.NearestDeckValue != "5" {
+		t.Fatalf("expected nearest deck value 5, got %v", stats.NearestDeckValue)
+	}
+	if stats.Spread != 1 || !stats.Consensus {
+		t.Fatalf("expected adjacent cards to reach consensus, got spread=%d consensus=%t", stats.Spread, stats.Consensus)
+	}
+	if stats.ConsensusPercent != 66.7 {
+		t.Fatalf("expected consensus percent 66.7, got %v", stats.ConsensusPercent)
+	}
+}
+
+func TestComputeVoteStats_PrefersHigherCardOnNearestTie(t *testing.T) {
+	deck := roomsmodels.RoomDeck{Name: "Custom", Kind: "CUSTOM", Values: []string{"1", "2", "3", "5", "8"}}
+
+	stats := roomsmodels.ComputeVoteStats(deck, []string{"5", "8"})
+
+	if stats.Mean == nil || *stats.Mean != 6.5 {
+		t.Fatalf("expected mean 6.5, got %v", stats.Mean)
+	}
+	if stats.StdDev == nil || *stats.StdDev != 1.5 {
+		t.Fatalf("expected stdDev 1.5, got %v", stats.StdDev)
+	}
+	if stats.NearestDeckValue == nil || *stats.NearestDeckValue != "8" {
+		t.Fatalf("expected tie to round up to 8, got %v", stats.NearestDeckValue)
+	}
+}
+
+func TestComputeVoteStats_NoNumericVotes(t *testing.T) {
+	deck := roomsmodels.RoomDeck{Name: "T-Shirt", Kind: "TSHIRT", Values: []string{"S", "M", "L", "?"}}
+
+	stats := roomsmodels.ComputeVoteStats(deck, []string{"M", "?"})
+
+	if stats.NumericVotes != 0 {
+		t.Fatalf("expected no numeric votes, got %d", stats.NumericVotes)
+	}
+	if stats.Mean != nil || stats.NearestDeckValue != nil {
+		t.Fatalf("expected empty stats, got mean=%v nearest=%v", stats.Mean, stats.NearestDeckValue)
+	}
+	if stats.Consensus || stats.ConsensusPercent != 0 {
+		t.Fatalf("expected no consensus without numeric votes, got consensus=%t percent=%v", stats.Consensus, stats.ConsensusPercent)
+	}
+}
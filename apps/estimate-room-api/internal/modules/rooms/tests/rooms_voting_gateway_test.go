@@ -42,6 +42,13 @@ func (p *testPubSub) Subscribe(channel string, onMessage func([]byte)) {
 	p.subscriptions[channel] = append(p.subscriptions[channel], onMessage)
 }
 
+func (p *testPubSub) Unsubscribe(channel string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.subscriptions, channel)
+}
+
 func (p *testPubSub) Publish(channel string, message any) error {
 	data, err := json.Marshal(message)
 	if err != nil {
@@ -94,6 +101,7 @@ func setupRoomsRealtimeTest(t *testing.T) (*httptest.Server, *bun.DB) {
 			AuthService:    authService,
 			TokenKey:       testutils.TestTokenKey,
 			Server:         pubSub,
+			EventLog:       newTestEventLog(pubSub),
 			OriginPatterns: []string{testWSOrigin},
 		})
 		invitesModule := invites.NewInvitesModule(invites.InvitesModuleDeps{
@@ -107,6 +115,7 @@ func setupRoomsRealtimeTest(t *testing.T) (*httptest.Server, *bun.DB) {
 			Router:         r,
 			DB:             db,
 			WsService:      wsModule.Service,
+			PubSub:         pubSub,
 			AuthService:    authService,
 			InvitesService: invitesModule.Service,
 			RewardService:  nil,
@@ -400,6 +409,334 @@ func TestRoomsVoting_LateJoinerDoesNotEnterCurrentRoundEligibility(t *testing.T)
 	}
 }
 
+func TestRoomsVoting_RevealAndSnapshotIncludeVoteStats(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Stats task")
+
+	memberTokenOne, memberUserIDOne := createAccessToken(t, db)
+	memberTokenTwo, memberUserIDTwo := createAccessToken(t, db)
+	lateTokenThree, lateUserIDThree := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserIDOne)
+	seedMemberParticipant(t, db, roomID, memberUserIDTwo)
+	seedMemberParticipant(t, db, roomID, lateUserIDThree)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConnOne := connectWS(t, server.URL, memberTokenOne)
+	defer memberConnOne.Close(websocket.StatusNormalClosure, "")
+	memberConnTwo := connectWS(t, server.URL, memberTokenTwo)
+	defer memberConnTwo.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConnOne, roomID)
+	joinRoom(t, memberConnTwo, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsTaskSetCurrent,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]string{
+			"taskId": taskID,
+		}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, memberConnOne, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "1"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+	writeEvent(t, memberConnTwo, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "8"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVotesAllCast)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsVoteReveal,
+		RoomID: roomID,
+	})
+
+	type voteSummary struct {
+		TotalVotes       int      `json:"totalVotes"`
+		NumericVotes     int      `json:"numericVotes"`
+		Mean             *float64 `json:"mean"`
+		Median           *float64 `json:"median"`
+		Min              *float64 `json:"min"`
+		Max              *float64 `json:"max"`
+		StdDev           *float64 `json:"stdDev"`
+		NearestDeckValue *string  `json:"nearestDeckValue"`
+		Spread           int      `json:"spread"`
+		Consensus        bool     `json:"consensus"`
+		ConsensusPercent float64  `json:"consensusPercent"`
+	}
+	assertSummary := func(label string, summary voteSummary) {
+		t.Helper()
+
+		if summary.TotalVotes != 2 || summary.NumericVotes != 2 {
+			t.Fatalf("%s: expected 2 numeric votes, got total=%d numeric=%d", label, summary.TotalVotes, summary.NumericVotes)
+		}
+		if summary.Mean == nil || *summary.Mean != 4.5 || summary.Median == nil || *summary.Median != 4.5 {
+			t.Fatalf("%s: expected mean and median 4.5, got %v and %v", label, summary.Mean, summary.Median)
+		}
+		if summary.Min == nil || *summary.Min != 1 || summary.Max == nil || *summary.Max != 8 {
+			t.Fatalf("%s: expected min 1 and max 8, got %v and %v", label, summary.Min, summary.Max)
+		}
+		if summary.StdDev == nil || *summary.StdDev != 3.5 {
+			t.Fatalf("%s: expected stdDev 3.5, got %v", label, summary.StdDev)
+		}
+		if summary.NearestDeckValue == nil || *summary.NearestDeckValue != "5" {
+			t.Fatalf("%s: expected nearest deck value 5, got %v", label, summary.NearestDeckValue)
+		}
+		if summary.Spread != 4 || summary.Consensus || summary.ConsensusPercent != 50 {
+			t.Fatalf("%s: expected spread 4 without consensus at 50%%, got spread=%d consensus=%t percent=%v", label, summary.Spread, summary.Consensus, summary.ConsensusPercent)
+		}
+	}
+
+	revealedEvent := readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed)
+	revealedPayload := decodePayload[struct {
+		Summary voteSummary `json:"summary"`
+	}](t, revealedEvent.Payload)
+	assertSummary("reveal", revealedPayload.Summary)
+
+	lateConn := connectWS(t, server.URL, lateTokenThree)
+	defer lateConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, lateConn, ws.EventTypeHello)
+	writeEvent(t, lateConn, ws.Event{
+		Type:   rooms.RoomsJoin,
+		RoomID: roomID,
+	})
+
+	snapshotEvent := readUntilEvent(t, lateConn, rooms.RoomsSnapshot)
+	snapshotPayload := decodePayload[struct {
+		Summary *voteSummary `json:"summary"`
+	}](t, snapshotEvent.Payload)
+	if snapshotPayload.Summary == nil {
+		t.Fatal("expected snapshot to include the revealed summary")
+	}
+	assertSummary("snapshot", *snapshotPayload.Summary)
+}
+
+func TestRoomsVoting_AutoRevealWhenAllEligibleVoted(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"autoReveal":true}`)
+	taskID := seedTask(t, db, roomID, "Auto reveal task")
+
+	memberTokenOne, memberUserIDOne := createAccessToken(t, db)
+	memberTokenTwo, memberUserIDTwo := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserIDOne)
+	seedMemberParticipant(t, db, roomID, memberUserIDTwo)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConnOne := connectWS(t, server.URL, memberTokenOne)
+	defer memberConnOne.Close(websocket.StatusNormalClosure, "")
+	memberConnTwo := connectWS(t, server.URL, memberTokenTwo)
+	defer memberConnTwo.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConnOne, roomID)
+	joinRoom(t, memberConnTwo, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsTaskSetCurrent,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]string{
+			"taskId": taskID,
+		}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, memberConnOne, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+	writeEvent(t, memberConnTwo, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "5"}),
+	})
+
+	revealedEvent := readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed)
+	revealedPayload := decodePayload[struct {
+		TaskID      string `json:"taskId"`
+		RoundStatus string `json:"roundStatus"`
+		AllVoted    bool   `json:"allVoted"`
+		Trigger     string `json:"trigger"`
+		Votes       []struct {
+			Value string `json:"value"`
+		} `json:"votes"`
+	}](t, revealedEvent.Payload)
+
+	if revealedPayload.TaskID != taskID || revealedPayload.RoundStatus != "REVEALED" {
+		t.Fatalf("expected task %s to be revealed, got task=%s status=%s", taskID, revealedPayload.TaskID, revealedPayload.RoundStatus)
+	}
+	if revealedPayload.Trigger != "ALL_VOTED" || !revealedPayload.AllVoted {
+		t.Fatalf("expected ALL_VOTED reveal, got trigger=%s allVoted=%t", revealedPayload.Trigger, revealedPayload.AllVoted)
+	}
+	if len(revealedPayload.Votes) != 2 {
+		t.Fatalf("expected 2 revealed votes, got %d", len(revealedPayload.Votes))
+	}
+}
+
+func TestRoomsVoting_TimerRevealsRoundOnExpiry(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"votingTimerSeconds":1}`)
+	taskID := seedTask(t, db, roomID, "Timed task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, memberConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsTaskSetCurrent,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]string{
+			"taskId": taskID,
+		}),
+	})
+
+	startedEvent := readUntilEvent(t, adminConn, rooms.RoomsTimerStarted)
+	startedPayload := decodePayload[struct {
+		TaskID          string    `json:"taskId"`
+		RoundNumber     int       `json:"roundNumber"`
+		DurationSeconds int       `json:"durationSeconds"`
+		EndsAt          time.Time `json:"endsAt"`
+	}](t, startedEvent.Payload)
+	if startedPayload.TaskID != taskID || startedPayload.RoundNumber != 1 || startedPayload.DurationSeconds != 1 {
+		t.Fatalf("unexpected timer started payload: %+v", startedPayload)
+	}
+	if startedPayload.EndsAt.IsZero() {
+		t.Fatal("expected timer started payload to include endsAt")
+	}
+
+	writeEvent(t, memberConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "8"}),
+	})
+
+	readUntilEvent(t, adminConn, rooms.RoomsTimerExpired)
+	revealedEvent := readUntilEvent(t, adminConn, rooms.RoomsVotesRevealed)
+	revealedPayload := decodePayload[struct {
+		Trigger string `json:"trigger"`
+		Votes   []struct {
+			Value string `json:"value"`
+		} `json:"votes"`
+	}](t, revealedEvent.Payload)
+	if revealedPayload.Trigger != "TIMER" {
+		t.Fatalf("expected TIMER reveal, got %s", revealedPayload.Trigger)
+	}
+	if len(revealedPayload.Votes) != 1 || revealedPayload.Votes[0].Value != "8" {
+		t.Fatalf("expected the cast vote to be revealed, got %+v", revealedPayload.Votes)
+	}
+}
+
+func TestRoomsVoting_SnapshotKeepsRunningTimerAndAdminRevealCancelsIt(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	setRoomOptions(t, db, roomID, `{"votingTimerSeconds":60}`)
+	taskID := seedTask(t, db, roomID, "Long timed task")
+
+	memberToken, memberUserID := createAccessToken(t, db)
+	seedMemberParticipant(t, db, roomID, memberUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	joinRoom(t, adminConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsTaskSetCurrent,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]string{
+			"taskId": taskID,
+		}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTimerStarted)
+
+	memberConn := connectWS(t, server.URL, memberToken)
+	defer memberConn.Close(websocket.StatusNormalClosure, "")
+	readUntilEvent(t, memberConn, ws.EventTypeHello)
+	writeEvent(t, memberConn, ws.Event{
+		Type:   rooms.RoomsJoin,
+		RoomID: roomID,
+	})
+
+	snapshotEvent := readUntilEvent(t, memberConn, rooms.RoomsSnapshot)
+	snapshotPayload := decodePayload[struct {
+		Room struct {
+			Options struct {
+				VotingTimerSeconds int `json:"votingTimerSeconds"`
+			} `json:"options"`
+		} `json:"room"`
+		Timer *struct {
+			TaskID          string    `json:"taskId"`
+			DurationSeconds int       `json:"durationSeconds"`
+			EndsAt          time.Time `json:"endsAt"`
+		} `json:"timer"`
+	}](t, snapshotEvent.Payload)
+	if snapshotPayload.Room.Options.VotingTimerSeconds != 60 {
+		t.Fatalf("expected snapshot room options to include the timer, got %d", snapshotPayload.Room.Options.VotingTimerSeconds)
+	}
+	if snapshotPayload.Timer == nil || snapshotPayload.Timer.TaskID != taskID || snapshotPayload.Timer.DurationSeconds != 60 {
+		t.Fatalf("expected snapshot to include the running timer, got %+v", snapshotPayload.Timer)
+	}
+	if !snapshotPayload.Timer.EndsAt.After(time.Now()) {
+		t.Fatalf("expected running timer to end in the future, got %s", snapshotPayload.Timer.EndsAt)
+	}
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsVoteReveal,
+		RoomID: roomID,
+	})
+
+	cancelledEvent := readUntilEvent(t, memberConn, rooms.RoomsTimerCancelled)
+	cancelledPayload := decodePayload[struct {
+		TaskID      string `json:"taskId"`
+		RoundNumber int    `json:"roundNumber"`
+	}](t, cancelledEvent.Payload)
+	if cancelledPayload.TaskID != taskID || cancelledPayload.RoundNumber != 1 {
+		t.Fatalf("unexpected timer cancelled payload: %+v", cancelledPayload)
+	}
+
+	revealedEvent := readUntilEvent(t, memberConn, rooms.RoomsVotesRevealed)
+	revealedPayload := decodePayload[struct {
+		Trigger string `json:"trigger"`
+	}](t, revealedEvent.Payload)
+	if revealedPayload.Trigger != "ADMIN" {
+		t.Fatalf("expected ADMIN reveal, got %s", revealedPayload.Trigger)
+	}
+}
+
 func TestRoomsRealtime_JoinTouchesRoomActivity(t *testing.T) {
 	server, db := setupRoomsRealtimeTest(t)
 	defer server.Close()
@@ -532,3 +869,109 @@ func sameStringSet(left []string, right []string) bool {
 
 	return true
 }
+
+func TestRoomsVoting_ObserverLeavesEligibilityAndAppearsInSnapshot(t *testing.T) {
+	server, db := setupRoomsRealtimeTest(t)
+	defer server.Close()
+	defer db.Close()
+
+	adminToken, adminUserID := createAccessToken(t, db)
+	roomID := seedRoom(t, db, adminUserID)
+	taskID := seedTask(t, db, roomID, "Observer task")
+
+	voterToken, voterUserID := createAccessToken(t, db)
+	observerToken, observerUserID := createAccessToken(t, db)
+	voterParticipantID := seedMemberParticipant(t, db, roomID, voterUserID)
+	observerParticipantID := seedMemberParticipant(t, db, roomID, observerUserID)
+
+	adminConn := connectWS(t, server.URL, adminToken)
+	defer adminConn.Close(websocket.StatusNormalClosure, "")
+	voterConn := connectWS(t, server.URL, voterToken)
+	defer voterConn.Close(websocket.StatusNormalClosure, "")
+	observerConn := connectWS(t, server.URL, observerToken)
+	defer observerConn.Close(websocket.StatusNormalClosure, "")
+
+	joinRoom(t, adminConn, roomID)
+	joinRoom(t, voterConn, roomID)
+	joinRoom(t, observerConn, roomID)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:    rooms.RoomsTaskSetCurrent,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"taskId": taskID}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsTaskCurrentChanged)
+
+	writeEvent(t, voterConn, ws.Event{
+		Type:    rooms.RoomsVoteCast,
+		RoomID:  roomID,
+		Payload: mustMarshalJSON(t, map[string]string{"value": "3"}),
+	})
+	readUntilEvent(t, adminConn, rooms.RoomsVoteStatusChanged)
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsObserverSet,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]any{
+			"participantId": observerParticipantID,
+			"observer":      true,
+		}),
+	})
+
+	roleChanged := decodePayload[struct {
+		ParticipantID          string   `json:"participantId"`
+		Role                   string   `json:"role"`
+		TaskID                 string   `json:"taskId"`
+		EligibleParticipantIDs []string `json:"eligibleParticipantIds"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsParticipantRole).Payload)
+	if roleChanged.ParticipantID != observerParticipantID || roleChanged.Role != "OBSERVER" {
+		t.Fatalf("expected %s to become OBSERVER, got %+v", observerParticipantID, roleChanged)
+	}
+	if roleChanged.TaskID != taskID || !sameStringSet(roleChanged.EligibleParticipantIDs, []string{voterParticipantID}) {
+		t.Fatalf("expected only the voter to stay eligible, got %+v", roleChanged)
+	}
+
+	allCast := decodePayload[struct {
+		VotedParticipantIDs []string `json:"votedParticipantIds"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsVotesAllCast).Payload)
+	if !sameStringSet(allCast.VotedParticipantIDs, []string{voterParticipantID}) {
+		t.Fatalf("expected the remaining voter to complete the round, got %v", allCast.VotedParticipantIDs)
+	}
+
+	joinRoom(t, observerConn, roomID)
+	snapshot := decodePayload[struct {
+		Participants []struct {
+			ParticipantID string `json:"participantId"`
+			Role          string `json:"role"`
+		} `json:"participants"`
+		EligibleParticipantIDs []string `json:"eligibleParticipantIds"`
+	}](t, readUntilEvent(t, observerConn, rooms.RoomsSnapshot).Payload)
+	if !sameStringSet(snapshot.EligibleParticipantIDs, []string{voterParticipantID}) {
+		t.Fatalf("expected snapshot eligibility %v, got %v", []string{voterParticipantID}, snapshot.EligibleParticipantIDs)
+	}
+	observerRole := ""
+	for _, participant := range snapshot.Participants {
+		if participant.ParticipantID == observerParticipantID {
+			observerRole = participant.Role
+		}
+	}
+	if observerRole != "OBSERVER" {
+		t.Fatalf("expected snapshot to list the observer role, got %q", observerRole)
+	}
+
+	writeEvent(t, adminConn, ws.Event{
+		Type:   rooms.RoomsObserverSet,
+		RoomID: roomID,
+		Payload: mustMarshalJSON(t, map[string]any{
+			"participantId": observerParticipantID,
+			"observer":      false,
+		}),
+	})
+
+	restored := decodePayload[struct {
+		Role string `json:"role"`
+	}](t, readUntilEvent(t, adminConn, rooms.RoomsParticipantRole).Payload)
+	if restored.Role != "MEMBER" {
+		t.Fatalf("expected participant to be restored as MEMBER, got %s", restored.Role)
+	}
+}
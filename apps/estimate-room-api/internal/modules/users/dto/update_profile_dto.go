@@ -0,0 +1,17 @@
+package usersdto
+
+import "github.com/go-playground/validator/v10"
+
+// UpdateProfileDTO patches the current user's profile. Omitted fields are
+// left unchanged; optional fields sent as an empty string are cleared.
+type UpdateProfileDTO struct {
+	DisplayName  *string `json:"displayName" validate:"omitempty,min=1,max=100"`
+	Organization *string `json:"organization" validate:"omitempty,max=120"`
+	Occupation   *string `json:"occupation" validate:"omitempty,max=120"`
+	AvatarURL    *string `json:"avatarUrl" validate:"omitempty,max=2000,http_url"`
+}
+
+func (s *UpdateProfileDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
@@ -3,13 +3,19 @@ package usersdto
 import "time"
 
 type UserResponse struct {
-	ID          string     `json:"id"`
-	Email       *string    `json:"email,omitempty"`
-	GithubID    *string    `json:"githubId,omitempty"`
-	DisplayName string     `json:"displayName"`
-	AvatarURL   *string    `json:"avatarUrl,omitempty"`
-	CreatedAt   time.Time  `json:"createdAt"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
-	LastLoginAt *time.Time `json:"lastLoginAt,omitempty"`
-	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	ID           string     `json:"id"`
+	Email        *string    `json:"email,omitempty"`
+	GithubID     *string    `json:"githubId,omitempty"`
+	DisplayName  string     `json:"displayName"`
+	Organization *string    `json:"organization,omitempty"`
+	Occupation   *string    `json:"occupation,omitempty"`
+	AvatarURL    *string    `json:"avatarUrl,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	LastLoginAt  *time.Time `json:"lastLoginAt,omitempty"`
+	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+}
+
+type DeleteAccountResponse struct {
+	Deleted bool `json:"deleted"`
 }
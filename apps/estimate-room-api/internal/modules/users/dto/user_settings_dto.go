@@ -0,0 +1,27 @@
+package usersdto
+
+import (
+	"github.com/go-playground/validator/v10"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+)
+
+type UpdateUserSettingsDTO struct {
+	Theme              *string                  `json:"theme" validate:"omitempty,oneof=LIGHT DARK SYSTEM"`
+	Timezone           *string                  `json:"timezone" validate:"omitempty,max=64"`
+	Locale             *string                  `json:"locale" validate:"omitempty,max=35,bcp47_language_tag"`
+	DefaultDeckID      *string                  `json:"defaultDeckId" validate:"omitempty,max=100"`
+	DefaultRoomOptions *roomsmodels.RoomOptions `json:"defaultRoomOptions"`
+}
+
+func (s *UpdateUserSettingsDTO) Validate() error {
+	validate := validator.New()
+	return validate.Struct(s)
+}
+
+type UserSettingsResponse struct {
+	Theme              *string                  `json:"theme"`
+	Timezone           *string                  `json:"timezone"`
+	Locale             *string                  `json:"locale"`
+	DefaultDeckID      *string                  `json:"defaultDeckId"`
+	DefaultRoomOptions *roomsmodels.RoomOptions `json:"defaultRoomOptions"`
+}
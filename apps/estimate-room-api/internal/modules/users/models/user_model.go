@@ -6,6 +6,9 @@ import (
 	"github.com/uptrace/bun"
 )
 
+// DeletedUserDisplayName replaces the display name of soft-deleted users.
+const DeletedUserDisplayName = "Deleted user"
+
 type UserModel struct {
 	bun.BaseModel `bun:"table:users,alias:u"`
 
@@ -0,0 +1,18 @@
+package usersmodels
+
+import (
+	"encoding/json"
+
+	"github.com/uptrace/bun"
+)
+
+type UserSettingsModel struct {
+	bun.BaseModel `bun:"table:user_settings,alias:ust"`
+
+	UserID             string          `bun:"user_id,pk"`
+	Theme              *string         `bun:"theme"`
+	Timezone           *string         `bun:"timezone"`
+	Locale             *string         `bun:"locale"`
+	DefaultDeckID      *string         `bun:"default_deck_id"`
+	DefaultRoomOptions json.RawMessage `bun:"default_room_options,type:jsonb,nullzero"`
+}
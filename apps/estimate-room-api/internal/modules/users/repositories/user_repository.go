@@ -23,13 +23,24 @@ type UserRepository interface {
 	UpdateDisplayName(userID, displayName string) error
 	UpdatePasswordHash(userID, passwordHash string) error
 	UpdateLastLoginAt(userID string) error
+	UpdateProfile(ctx context.Context, userID string, fields UpdateProfileFields) error
+	SoftDelete(ctx context.Context, userID string) error
+}
+
+// UpdateProfileFields lists the profile columns to change. Nil fields are left
+// untouched; optional fields set to an empty string are cleared.
+type UpdateProfileFields struct {
+	DisplayName  *string
+	Organization *string
+	Occupation   *string
+	AvatarURL    *string
 }
 
 type userRepository struct {
-	db *bun.DB
+	db bun.IDB
 }
 
-func NewUserRepository(db *bun.DB) *userRepository {
+func NewUserRepository(db bun.IDB) *userRepository {
 	return &userRepository{db: db}
 }
 
@@ -192,3 +203,72 @@ func (r *userRepository) UpdateLastLoginAt(userID string) error {
 
 	return err
 }
+
+func (r *userRepository) UpdateProfile(ctx context.Context, userID string, fields UpdateProfileFields) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	query := r.db.NewUpdate().
+		Model((*usersmodels.UserModel)(nil)).
+		Set("updated_at = NOW()").
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL")
+	if fields.DisplayName != nil {
+		query = query.Set("display_name = ?", *fields.DisplayName)
+	}
+	if fields.Organization != nil {
+		query = query.Set("organization = NULLIF(?, '')", *fields.Organization)
+	}
+	if fields.Occupation != nil {
+		query = query.Set("occupation = NULLIF(?, '')", *fields.Occupation)
+	}
+	if fields.AvatarURL != nil {
+		query = query.Set("avatar_url = NULLIF(?, '')", *fields.AvatarURL)
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return requireAffectedUser(result)
+}
+
+// SoftDelete marks the user as deleted and strips personal profile data. The
+// email and GitHub ID are kept so they stay blocked for re-registration.
+func (r *userRepository) SoftDelete(ctx context.Context, userID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	result, err := r.db.NewUpdate().
+		Model((*usersmodels.UserModel)(nil)).
+		Set("display_name = ?", usersmodels.DeletedUserDisplayName).
+		Set("password_hash = NULL").
+		Set("organization = NULL").
+		Set("occupation = NULL").
+		Set("avatar_url = NULL").
+		Set("deleted_at = NOW()").
+		Set("updated_at = NOW()").
+		Where("user_id = ?", userID).
+		Where("deleted_at IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	return requireAffectedUser(result)
+}
+
+func requireAffectedUser(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return apperrors.ErrUserNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package usersrepositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	usersmodels "github.com/master-bogdan/estimate-room-api/internal/modules/users/models"
+	"github.com/uptrace/bun"
+)
+
+type UserSettingsRepository interface {
+	FindByUserID(ctx context.Context, userID string) (*usersmodels.UserSettingsModel, error)
+	Upsert(ctx context.Context, model *usersmodels.UserSettingsModel) (*usersmodels.UserSettingsModel, error)
+	DeleteByUserID(ctx context.Context, userID string) error
+}
+
+type userSettingsRepository struct {
+	db bun.IDB
+}
+
+func NewUserSettingsRepository(db bun.IDB) UserSettingsRepository {
+	return &userSettingsRepository{db: db}
+}
+
+// FindByUserID returns an empty settings row when the user has never saved
+// settings.
+func (r *userSettingsRepository) FindByUserID(ctx context.Context, userID string) (*usersmodels.UserSettingsModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	settings := new(usersmodels.UserSettingsModel)
+	err := r.db.NewSelect().
+		Model(settings).
+		Where("ust.user_id = ?", userID).
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &usersmodels.UserSettingsModel{UserID: userID}, nil
+		}
+
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (r *userSettingsRepository) Upsert(
+	ctx context.Context,
+	model *usersmodels.UserSettingsModel,
+) (*usersmodels.UserSettingsModel, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, err := r.db.NewInsert().
+		Model(model).
+		Column("user_id", "theme", "timezone", "locale", "default_deck_id", "default_room_options").
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("theme = EXCLUDED.theme").
+		Set("timezone = EXCLUDED.timezone").
+		Set("locale = EXCLUDED.locale").
+		Set("default_deck_id = EXCLUDED.default_deck_id").
+		Set("default_room_options = EXCLUDED.default_room_options").
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (r *userSettingsRepository) DeleteByUserID(ctx context.Context, userID string) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, err := r.db.NewDelete().
+		Model((*usersmodels.UserSettingsModel)(nil)).
+		Where("user_id = ?", userID).
+		Exec(ctx)
+
+	return err
+}
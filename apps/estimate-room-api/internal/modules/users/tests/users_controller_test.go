@@ -5,12 +5,18 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/auth"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	oauth2repositories "github.com/master-bogdan/estimate-room-api/internal/modules/oauth2/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/users"
 	usersdto "github.com/master-bogdan/estimate-room-api/internal/modules/users/dto"
+	usersmodels "github.com/master-bogdan/estimate-room-api/internal/modules/users/models"
+	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
 	apperrors "github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
 	"github.com/uptrace/bun"
@@ -25,12 +31,21 @@ func setupUsersTest(t *testing.T) (*chi.Mux, *bun.DB) {
 	router := chi.NewRouter()
 
 	authService := oauth2.NewOauth2SessionAuthServiceFromDB(testutils.TestTokenKey, db)
+	sessionRevoker := auth.NewAuthService(auth.AuthServiceDeps{
+		UserService:      users.NewUsersService(usersrepositories.NewUserRepository(db)),
+		SessionService:   authService,
+		AuthCodeRepo:     oauth2repositories.NewOauth2AuthCodeRepository(db),
+		AccessTokenRepo:  oauth2repositories.NewOauth2AccessTokenRepository(db),
+		RefreshTokenRepo: oauth2repositories.NewOauth2RefreshTokenRepository(db),
+		OidcSessionRepo:  oauth2repositories.NewOauth2OidcSessionRepository(db),
+	})
 
 	router.Route("/api/v1", func(r chi.Router) {
 		users.NewUsersModule(users.UsersModuleDeps{
-			Router:      r,
-			DB:          db,
-			AuthService: authService,
+			Router:         r,
+			DB:             db,
+			AuthService:    authService,
+			SessionRevoker: sessionRevoker,
 		})
 	})
 
@@ -105,3 +120,276 @@ func TestGetMe_MissingToken_ReturnsUnauthorized(t *testing.T) {
 		t.Fatal("expected errors array")
 	}
 }
+
+func createUsersAccessToken(t *testing.T, db *bun.DB, email string) (string, string) {
+	t.Helper()
+
+	redirectURI := "http://localhost:4081"
+	clientID := testutils.SeedClient(t, db, redirectURI, []string{"user"})
+	userID := testutils.SeedUser(t, db, email, "password123")
+	sessionID := testutils.SeedSession(t, db, userID, clientID, "nonce-users")
+
+	svc := testutils.NewOauth2Service(db)
+	tokens, err := svc.GenerateTokenPair(context.Background(), userID, clientID, sessionID, []string{"user"})
+	if err != nil {
+		t.Fatalf("failed to generate token pair: %v", err)
+	}
+
+	return tokens.AccessToken, userID
+}
+
+func TestUserSettings_ReturnsEmptySettingsByDefault(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, _ := createUsersAccessToken(t, db, "settings@example.com")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/me/settings", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp usersdto.UserSettingsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Theme != nil || resp.DefaultDeckID != nil || resp.DefaultRoomOptions != nil {
+		t.Fatalf("expected empty settings, got %+v", resp)
+	}
+}
+
+func TestUserSettings_PutReplacesSettings(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, userID := createUsersAccessToken(t, db, "settings@example.com")
+
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO decks (deck_id, name, kind, values, owner_user_id)
+		VALUES ('my-deck', 'Hours', 'CUSTOM', '["1","2","4"]'::jsonb, $1)
+	`, userID)
+	if err != nil {
+		t.Fatalf("failed to insert deck: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/settings", strings.NewReader(`{
+		"theme":"DARK",
+		"timezone":"Europe/Kyiv",
+		"locale":"uk-UA",
+		"defaultDeckId":"my-deck",
+		"defaultRoomOptions":{"createShareLink":true}
+	}`))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/me/settings", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var resp usersdto.UserSettingsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Theme == nil || *resp.Theme != "DARK" {
+		t.Fatalf("expected DARK theme, got %v", resp.Theme)
+	}
+	if resp.Timezone == nil || *resp.Timezone != "Europe/Kyiv" {
+		t.Fatalf("expected timezone, got %v", resp.Timezone)
+	}
+	if resp.DefaultDeckID == nil || *resp.DefaultDeckID != "my-deck" {
+		t.Fatalf("expected default deck, got %v", resp.DefaultDeckID)
+	}
+	if resp.DefaultRoomOptions == nil || !resp.DefaultRoomOptions.CreateShareLink {
+		t.Fatalf("expected default room options, got %+v", resp.DefaultRoomOptions)
+	}
+
+	req = httptest.NewRequest(http.MethodPut, "/api/v1/users/me/settings", strings.NewReader(`{"theme":"LIGHT"}`))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	resp = usersdto.UserSettingsResponse{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.DefaultDeckID != nil || resp.DefaultRoomOptions != nil {
+		t.Fatalf("expected PUT to clear omitted fields, got %+v", resp)
+	}
+}
+
+func TestUserSettings_RejectsInvalidValues(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, _ := createUsersAccessToken(t, db, "settings@example.com")
+
+	for _, body := range []string{
+		`{"theme":"NEON"}`,
+		`{"timezone":"Mars/Olympus"}`,
+		`{"defaultDeckId":"missing-deck"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/api/v1/users/me/settings", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 Bad Request for %s, got %d: %s", body, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestUpdateMe_PatchesProfileFields(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, _ := createUsersAccessToken(t, db, "profile@example.com")
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(`{
+		"displayName":"  Ada Lovelace ",
+		"organization":"Analytical Engines",
+		"occupation":"Engineer",
+		"avatarUrl":"https://example.com/ada.png"
+	}`))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var resp usersdto.UserResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.DisplayName != "Ada Lovelace" {
+		t.Fatalf("expected trimmed display name, got %q", resp.DisplayName)
+	}
+	if resp.Organization == nil || *resp.Organization != "Analytical Engines" {
+		t.Fatalf("expected organization, got %v", resp.Organization)
+	}
+	if resp.AvatarURL == nil || *resp.AvatarURL != "https://example.com/ada.png" {
+		t.Fatalf("expected avatar url, got %v", resp.AvatarURL)
+	}
+
+	req = httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(`{"organization":""}`))
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	resp = usersdto.UserResponse{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Organization != nil {
+		t.Fatalf("expected organization to be cleared, got %v", *resp.Organization)
+	}
+	if resp.DisplayName != "Ada Lovelace" || resp.Occupation == nil || *resp.Occupation != "Engineer" {
+		t.Fatalf("expected omitted fields to stay unchanged, got %+v", resp)
+	}
+}
+
+func TestUpdateMe_RejectsInvalidValues(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, _ := createUsersAccessToken(t, db, "profile@example.com")
+
+	for _, body := range []string{
+		`{"displayName":"   "}`,
+		`{"avatarUrl":"not-a-url"}`,
+	} {
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/me", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("expected 400 Bad Request for %s, got %d: %s", body, rr.Code, rr.Body.String())
+		}
+	}
+}
+
+func TestDeleteMe_AnonymizesUserAndRevokesTokens(t *testing.T) {
+	router, db := setupUsersTest(t)
+	defer db.Close()
+
+	accessToken, userID := createUsersAccessToken(t, db, "leaving@example.com")
+
+	roomID := uuid.NewString()
+	_, err := db.ExecContext(context.Background(), `
+		INSERT INTO rooms (room_id, code, name, admin_user_id, deck)
+		VALUES ($1, $2, 'Planning', $3, '{"name":"Fibonacci","kind":"FIBONACCI","values":["1","2","3"]}'::jsonb)
+	`, roomID, "room-"+roomID[:8], userID)
+	if err != nil {
+		t.Fatalf("failed to insert room: %v", err)
+	}
+	_, err = db.ExecContext(context.Background(), `
+		INSERT INTO room_participants (room_participants_id, room_id, user_id, role)
+		VALUES ($1, $2, $3, 'ADMIN')
+	`, uuid.NewString(), roomID, userID)
+	if err != nil {
+		t.Fatalf("failed to insert participant: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	user := new(usersmodels.UserModel)
+	if err := db.NewSelect().Model(user).Where("u.user_id = ?", userID).Scan(context.Background()); err != nil {
+		t.Fatalf("failed to load user: %v", err)
+	}
+	if user.DeletedAt == nil {
+		t.Fatal("expected user to be soft-deleted")
+	}
+	if user.DisplayName != usersmodels.DeletedUserDisplayName || user.PasswordHash != nil {
+		t.Fatalf("expected anonymized profile, got %+v", user)
+	}
+
+	var openParticipations int
+	if err := db.NewRaw(
+		"SELECT COUNT(*) FROM room_participants WHERE user_id = ? AND left_at IS NULL",
+		userID,
+	).Scan(context.Background(), &openParticipations); err != nil {
+		t.Fatalf("failed to count participations: %v", err)
+	}
+	if openParticipations != 0 {
+		t.Fatalf("expected participations to be closed, got %d open", openParticipations)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/users/me", nil)
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected revoked token to return 401, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,123 @@
+package users
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	roomsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/repositories"
+	usersmodels "github.com/master-bogdan/estimate-room-api/internal/modules/users/models"
+	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+	"github.com/uptrace/bun"
+)
+
+// UserSessionRevoker revokes every OAuth2 session and token issued to a user.
+type UserSessionRevoker interface {
+	RevokeAllUserSessions(ctx context.Context, userID string) error
+}
+
+type UserAccountService interface {
+	UpdateProfile(ctx context.Context, userID string, input UpdateProfileInput) (*usersmodels.UserModel, error)
+	DeleteAccount(ctx context.Context, userID string) error
+}
+
+// UpdateProfileInput patches the profile; nil fields are left unchanged and
+// optional fields set to an empty string are cleared.
+type UpdateProfileInput struct {
+	DisplayName  *string
+	Organization *string
+	Occupation   *string
+	AvatarURL    *string
+}
+
+type userAccountService struct {
+	db             *bun.DB
+	userRepo       usersrepositories.UserRepository
+	sessionRevoker UserSessionRevoker
+	logger         *slog.Logger
+}
+
+func NewUserAccountService(
+	db *bun.DB,
+	userRepo usersrepositories.UserRepository,
+	sessionRevoker UserSessionRevoker,
+) UserAccountService {
+	return &userAccountService{
+		db:             db,
+		userRepo:       userRepo,
+		sessionRevoker: sessionRevoker,
+		logger:         logger.L().With(slog.String("service", "user_account")),
+	}
+}
+
+func (s *userAccountService) UpdateProfile(
+	ctx context.Context,
+	userID string,
+	input UpdateProfileInput,
+) (*usersmodels.UserModel, error) {
+	fields := usersrepositories.UpdateProfileFields{
+		Organization: trimOptionalString(input.Organization),
+		Occupation:   trimOptionalString(input.Occupation),
+		AvatarURL:    trimOptionalString(input.AvatarURL),
+	}
+	if input.DisplayName != nil {
+		displayName := strings.TrimSpace(*input.DisplayName)
+		if displayName == "" {
+			return nil, fmt.Errorf("%w: display name cannot be blank", apperrors.ErrBadRequest)
+		}
+		fields.DisplayName = &displayName
+	}
+
+	if err := s.userRepo.UpdateProfile(ctx, userID, fields); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(usersServiceLog("User profile updated"), "user_id", userID)
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// DeleteAccount soft-deletes the user, closes their room participations and
+// drops their settings in one transaction, then revokes all of their tokens.
+// Rooms and history keep the participation rows but only expose the
+// anonymized profile.
+func (s *userAccountService) DeleteAccount(ctx context.Context, userID string) error {
+	err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := usersrepositories.NewUserRepository(tx).SoftDelete(ctx, userID); err != nil {
+			return err
+		}
+		if err := roomsrepositories.NewRoomParticipantRepository(tx).MarkLeftByUserID(ctx, userID); err != nil {
+			return err
+		}
+
+		return usersrepositories.NewUserSettingsRepository(tx).DeleteByUserID(ctx, userID)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionRevoker.RevokeAllUserSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(usersServiceLog("User account deleted"), "user_id", userID)
+
+	return nil
+}
+
+func trimOptionalString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	return &trimmed
+}
@@ -1,12 +1,14 @@
 package users
 
 import (
+	"encoding/json"
 	stdErrors "errors"
 	"log/slog"
 	"net/http"
 
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
 	usersdto "github.com/master-bogdan/estimate-room-api/internal/modules/users/dto"
+	usersmodels "github.com/master-bogdan/estimate-room-api/internal/modules/users/models"
 	apperrors "github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/httputils"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
@@ -14,19 +16,32 @@ import (
 
 type UsersController interface {
 	GetMe(w http.ResponseWriter, r *http.Request)
+	UpdateMe(w http.ResponseWriter, r *http.Request)
+	DeleteMe(w http.ResponseWriter, r *http.Request)
+	GetMySettings(w http.ResponseWriter, r *http.Request)
+	UpdateMySettings(w http.ResponseWriter, r *http.Request)
 }
 
 type usersController struct {
-	service     UsersService
-	authService oauth2.Oauth2SessionAuthService
-	logger      *slog.Logger
+	service         UsersService
+	settingsService UserSettingsService
+	accountService  UserAccountService
+	authService     oauth2.Oauth2SessionAuthService
+	logger          *slog.Logger
 }
 
-func NewUsersController(service UsersService, authService oauth2.Oauth2SessionAuthService) UsersController {
+func NewUsersController(
+	service UsersService,
+	settingsService UserSettingsService,
+	accountService UserAccountService,
+	authService oauth2.Oauth2SessionAuthService,
+) UsersController {
 	return &usersController{
-		service:     service,
-		authService: authService,
-		logger:      logger.L().With(slog.String("controller", "users")),
+		service:         service,
+		settingsService: settingsService,
+		accountService:  accountService,
+		authService:     authService,
+		logger:          logger.L().With(slog.String("controller", "users")),
 	}
 }
 
@@ -86,17 +101,219 @@ func (c *usersController) GetMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := usersdto.UserResponse{
-		ID:          user.UserID,
-		Email:       user.Email,
-		GithubID:    user.GithubID,
-		DisplayName: user.DisplayName,
-		AvatarURL:   user.AvatarURL,
-		CreatedAt:   user.CreatedAt,
-		UpdatedAt:   user.UpdatedAt,
-		LastLoginAt: user.LastLoginAt,
-		DeletedAt:   user.DeletedAt,
+	httputils.WriteResponse(w, newUserResponse(user))
+}
+
+// UpdateMe godoc
+// @Summary Update current user profile
+// @Description Updates the current user's display name, organization, occupation, and avatar URL. Omitted fields are left unchanged; optional fields sent as an empty string are cleared.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body usersdto.UpdateProfileDTO true "Profile fields"
+// @Success 200 {object} usersdto.UserResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/users/me [patch]
+func (c *usersController) UpdateMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	dto := usersdto.UpdateProfileDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	user, err := c.accountService.UpdateProfile(r.Context(), userID, UpdateProfileInput{
+		DisplayName:  dto.DisplayName,
+		Organization: dto.Organization,
+		Occupation:   dto.Occupation,
+		AvatarURL:    dto.AvatarURL,
+	})
+	if err != nil {
+		c.writeUserError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newUserResponse(user))
+}
+
+// DeleteMe godoc
+// @Summary Delete current user account
+// @Description Soft-deletes the current user, anonymizes their profile in rooms and history, and revokes all of their sessions and tokens.
+// @Tags users
+// @Produce json
+// @Success 200 {object} usersdto.DeleteAccountResponse
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 404 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/users/me [delete]
+func (c *usersController) DeleteMe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.accountService.DeleteAccount(r.Context(), userID); err != nil {
+		c.writeUserError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, usersdto.DeleteAccountResponse{Deleted: true})
+}
+
+// GetMySettings godoc
+// @Summary Current user settings
+// @Description Returns the current user's preferences and room defaults.
+// @Tags users
+// @Produce json
+// @Success 200 {object} usersdto.UserSettingsResponse
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/users/me/settings [get]
+func (c *usersController) GetMySettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := c.settingsService.GetSettings(r.Context(), userID)
+	if err != nil {
+		c.writeUserError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newUserSettingsResponse(settings))
+}
+
+// UpdateMySettings godoc
+// @Summary Replace current user settings
+// @Description Replaces the current user's preferences. Omitted fields are cleared. The default deck and room options are applied when creating rooms.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body usersdto.UpdateUserSettingsDTO true "Settings"
+// @Success 200 {object} usersdto.UserSettingsResponse
+// @Failure 400 {object} apperrors.HttpError
+// @Failure 401 {object} apperrors.HttpError
+// @Failure 500 {object} apperrors.HttpError
+// @Router /api/v1/users/me/settings [put]
+func (c *usersController) UpdateMySettings(w http.ResponseWriter, r *http.Request) {
+	userID, ok := c.requireUserID(w, r)
+	if !ok {
+		return
+	}
+
+	dto := usersdto.UpdateUserSettingsDTO{}
+	if err := json.NewDecoder(r.Body).Decode(&dto); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	if err := dto.Validate(); err != nil {
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+		return
+	}
+
+	settings, err := c.settingsService.UpdateSettings(r.Context(), userID, UpdateUserSettingsInput{
+		Theme:              dto.Theme,
+		Timezone:           dto.Timezone,
+		Locale:             dto.Locale,
+		DefaultDeckID:      dto.DefaultDeckID,
+		DefaultRoomOptions: dto.DefaultRoomOptions,
+	})
+	if err != nil {
+		c.writeUserError(w, r, err)
+		return
+	}
+
+	httputils.WriteResponse(w, newUserSettingsResponse(settings))
+}
+
+func newUserResponse(user *usersmodels.UserModel) usersdto.UserResponse {
+	return usersdto.UserResponse{
+		ID:           user.UserID,
+		Email:        user.Email,
+		GithubID:     user.GithubID,
+		DisplayName:  user.DisplayName,
+		Organization: user.Organization,
+		Occupation:   user.Occupation,
+		AvatarURL:    user.AvatarURL,
+		CreatedAt:    user.CreatedAt,
+		UpdatedAt:    user.UpdatedAt,
+		LastLoginAt:  user.LastLoginAt,
+		DeletedAt:    user.DeletedAt,
+	}
+}
+
+func newUserSettingsResponse(settings *UserSettings) usersdto.UserSettingsResponse {
+	return usersdto.UserSettingsResponse{
+		Theme:              settings.Theme,
+		Timezone:           settings.Timezone,
+		Locale:             settings.Locale,
+		DefaultDeckID:      settings.DefaultDeckID,
+		DefaultRoomOptions: settings.DefaultRoomOptions,
+	}
+}
+
+func (c *usersController) writeUserError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case stdErrors.Is(err, apperrors.ErrBadRequest):
+		c.writeError(w, r, apperrors.ErrBadRequest, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrUnauthorized):
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrForbidden):
+		c.writeError(w, r, apperrors.ErrForbidden, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrUserNotFound):
+		c.writeError(w, r, apperrors.ErrUserNotFound, "", err)
+	case stdErrors.Is(err, apperrors.ErrNotFound):
+		c.writeError(w, r, apperrors.ErrNotFound, err.Error(), err)
+	case stdErrors.Is(err, apperrors.ErrConflict):
+		c.writeError(w, r, apperrors.ErrConflict, err.Error(), err)
+	default:
+		c.writeError(w, r, apperrors.ErrInternal, "", err)
+	}
+}
+
+func (c *usersController) writeError(w http.ResponseWriter, r *http.Request, errType error, detail string, cause error) {
+	logArgs := []any{
+		"path", r.URL.Path,
+		"type", errType.Error(),
+	}
+	if detail != "" {
+		logArgs = append(logArgs, "detail", detail)
+	}
+	if cause != nil {
+		logArgs = append(logArgs, "err", cause)
+	}
+
+	logger.FromRequest(r, c.logger).Error("request failed", logArgs...)
+
+	httputils.WriteResponseError(w, apperrors.CreateHttpError(
+		errType,
+		apperrors.HttpError{
+			Detail:   detail,
+			Instance: r.URL.Path,
+		},
+	))
+}
+
+func (c *usersController) requireUserID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	userID, err := c.authService.CheckAuth(r)
+	if err != nil {
+		c.writeError(w, r, apperrors.ErrUnauthorized, err.Error(), err)
+		return "", false
 	}
 
-	httputils.WriteResponse(w, response)
+	return userID, true
 }
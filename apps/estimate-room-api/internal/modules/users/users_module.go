@@ -3,33 +3,53 @@ package users
 
 import (
 	"github.com/go-chi/chi/v5"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
+	decksrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/decks/repositories"
 	"github.com/master-bogdan/estimate-room-api/internal/modules/oauth2"
+	teamsrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/teams/repositories"
 	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
 	"github.com/uptrace/bun"
 )
 
 type UsersModule struct {
-	Controller UsersController
-	Service    UsersService
+	Controller      UsersController
+	Service         UsersService
+	SettingsService UserSettingsService
+	AccountService  UserAccountService
 }
 
 type UsersModuleDeps struct {
-	Router      chi.Router
-	DB          *bun.DB
-	AuthService oauth2.Oauth2SessionAuthService
+	Router         chi.Router
+	DB             *bun.DB
+	AuthService    oauth2.Oauth2SessionAuthService
+	SessionRevoker UserSessionRevoker
 }
 
 func NewUsersModule(deps UsersModuleDeps) *UsersModule {
 	userRepo := usersrepositories.NewUserRepository(deps.DB)
+	settingsRepo := usersrepositories.NewUserSettingsRepository(deps.DB)
+	decksSvc := decks.NewDecksService(
+		decksrepositories.NewDeckRepository(deps.DB),
+		teamsrepositories.NewTeamRepository(deps.DB),
+		teamsrepositories.NewTeamMemberRepository(deps.DB),
+	)
 	svc := NewUsersService(userRepo)
-	ctrl := NewUsersController(svc, deps.AuthService)
+	settingsSvc := NewUserSettingsService(settingsRepo, decksSvc)
+	accountSvc := NewUserAccountService(deps.DB, userRepo, deps.SessionRevoker)
+	ctrl := NewUsersController(svc, settingsSvc, accountSvc, deps.AuthService)
 
 	deps.Router.Route("/users", func(r chi.Router) {
 		r.Get("/me", ctrl.GetMe)
+		r.Patch("/me", ctrl.UpdateMe)
+		r.Delete("/me", ctrl.DeleteMe)
+		r.Get("/me/settings", ctrl.GetMySettings)
+		r.Put("/me/settings", ctrl.UpdateMySettings)
 	})
 
 	return &UsersModule{
-		Controller: ctrl,
-		Service:    svc,
+		Controller:      ctrl,
+		Service:         svc,
+		SettingsService: settingsSvc,
+		AccountService:  accountSvc,
 	}
 }
@@ -0,0 +1,155 @@
+package users
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/master-bogdan/estimate-room-api/internal/modules/decks"
+	roomsmodels "github.com/master-bogdan/estimate-room-api/internal/modules/rooms/models"
+	usersmodels "github.com/master-bogdan/estimate-room-api/internal/modules/users/models"
+	usersrepositories "github.com/master-bogdan/estimate-room-api/internal/modules/users/repositories"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
+	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
+)
+
+type UserSettingsService interface {
+	GetSettings(ctx context.Context, userID string) (*UserSettings, error)
+	UpdateSettings(ctx context.Context, userID string, input UpdateUserSettingsInput) (*UserSettings, error)
+}
+
+type UserSettings struct {
+	Theme              *string
+	Timezone           *string
+	Locale             *string
+	DefaultDeckID      *string
+	DefaultRoomOptions *roomsmodels.RoomOptions
+}
+
+// UpdateUserSettingsInput replaces the stored settings; nil fields are
+// cleared.
+type UpdateUserSettingsInput struct {
+	Theme              *string
+	Timezone           *string
+	Locale             *string
+	DefaultDeckID      *string
+	DefaultRoomOptions *roomsmodels.RoomOptions
+}
+
+type userSettingsService struct {
+	settingsRepo usersrepositories.UserSettingsRepository
+	decksService decks.DecksService
+	logger       *slog.Logger
+}
+
+func NewUserSettingsService(
+	settingsRepo usersrepositories.UserSettingsRepository,
+	decksService decks.DecksService,
+) UserSettingsService {
+	return &userSettingsService{
+		settingsRepo: settingsRepo,
+		decksService: decksService,
+		logger:       logger.L().With(slog.String("service", "user_settings")),
+	}
+}
+
+func (s *userSettingsService) GetSettings(ctx context.Context, userID string) (*UserSettings, error) {
+	model, err := s.settingsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newUserSettings(model)
+}
+
+func (s *userSettingsService) UpdateSettings(
+	ctx context.Context,
+	userID string,
+	input UpdateUserSettingsInput,
+) (*UserSettings, error) {
+	model := &usersmodels.UserSettingsModel{
+		UserID:        userID,
+		Theme:         normalizeOptionalString(input.Theme),
+		Timezone:      normalizeOptionalString(input.Timezone),
+		Locale:        normalizeOptionalString(input.Locale),
+		DefaultDeckID: normalizeOptionalString(input.DefaultDeckID),
+	}
+
+	if model.Timezone != nil {
+		if _, err := time.LoadLocation(*model.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: unknown timezone", apperrors.ErrBadRequest)
+		}
+	}
+
+	if model.DefaultDeckID != nil {
+		if _, err := s.decksService.GetDeck(ctx, *model.DefaultDeckID, userID); err != nil {
+			if errors.Is(err, apperrors.ErrNotFound) || errors.Is(err, apperrors.ErrForbidden) {
+				return nil, fmt.Errorf("%w: default deck is not available", apperrors.ErrBadRequest)
+			}
+
+			return nil, err
+		}
+	}
+
+	if input.DefaultRoomOptions != nil {
+		if !input.DefaultRoomOptions.IsValid() {
+			return nil, fmt.Errorf("%w: invalid default room options", apperrors.ErrBadRequest)
+		}
+
+		options, err := json.Marshal(input.DefaultRoomOptions)
+		if err != nil {
+			return nil, err
+		}
+		model.DefaultRoomOptions = options
+	}
+
+	updated, err := s.settingsRepo.Upsert(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx, s.logger).Info(usersServiceLog("User settings updated"), "user_id", userID, "default_deck_id", updated.DefaultDeckID)
+
+	return newUserSettings(updated)
+}
+
+func newUserSettings(model *usersmodels.UserSettingsModel) (*UserSettings, error) {
+	settings := &UserSettings{
+		Theme:         model.Theme,
+		Timezone:      model.Timezone,
+		Locale:        model.Locale,
+		DefaultDeckID: model.DefaultDeckID,
+	}
+
+	if len(model.DefaultRoomOptions) > 0 && string(model.DefaultRoomOptions) != "null" {
+		options := &roomsmodels.RoomOptions{}
+		if err := json.Unmarshal(model.DefaultRoomOptions, options); err != nil {
+			return nil, err
+		}
+		settings.DefaultRoomOptions = options
+	}
+
+	return settings, nil
+}
+
+func normalizeOptionalString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+
+	return &trimmed
+}
+
+func usersServiceLog(message string) string {
+	return logger.Prefix("MODULE", "USERS", message)
+}
@@ -0,0 +1,116 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+	"github.com/redis/go-redis/v9"
+)
+
+func cleanupEventLogKeys(t *testing.T, client *redis.Client, roomID string) {
+	t.Helper()
+	t.Cleanup(func() {
+		client.Del(context.Background(), "ws:room:"+roomID+":seq", "ws:room:"+roomID+":events")
+	})
+}
+
+func TestRedisEventLog_PublishesNumberedEventsInOrder(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+	ctx := context.Background()
+
+	roomID := uuid.NewString()
+	cleanupEventLogKeys(t, client, roomID)
+	eventLog, err := ws.NewRedisEventLog(ws.RedisEventLogDeps{Client: client})
+	if err != nil {
+		t.Fatalf("failed to create event log: %v", err)
+	}
+
+	channel := ws.RoomChannel("test", roomID)
+	sub := client.Subscribe(ctx, channel)
+	defer sub.Close()
+	if _, err := sub.Receive(ctx); err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	for _, eventType := range []string{"FIRST", "SECOND", "THIRD"} {
+		event := ws.Event{Type: eventType, RoomID: roomID, Payload: json.RawMessage(`{"ok":true}`)}
+		if err := eventLog.Publish(ctx, channel, event); err != nil {
+			t.Fatalf("failed to publish event: %v", err)
+		}
+	}
+
+	messages := sub.Channel()
+	for i, eventType := range []string{"FIRST", "SECOND", "THIRD"} {
+		select {
+		case msg := <-messages:
+			event := ws.Event{}
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				t.Fatalf("failed to decode published event: %v", err)
+			}
+			if event.Seq != int64(i+1) || event.Type != eventType || event.RoomID != roomID {
+				t.Fatalf("expected %s numbered %d, got %+v", eventType, i+1, event)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("expected %s to be published", eventType)
+		}
+	}
+
+	if seq, err := eventLog.LastSeq(ctx, roomID); err != nil || seq != 3 {
+		t.Fatalf("expected last seq 3, got %d (%v)", seq, err)
+	}
+	events, ok, err := eventLog.Since(ctx, roomID, 1)
+	if err != nil || !ok || len(events) != 2 || events[0].Seq != 2 || events[1].Type != "THIRD" {
+		t.Fatalf("expected the last two events to be replayed, got %+v (ok=%v, err=%v)", events, ok, err)
+	}
+	if string(events[0].Payload) != `{"ok":true}` {
+		t.Fatalf("expected the payload to be kept, got %s", events[0].Payload)
+	}
+	if ttl := client.TTL(ctx, "ws:room:"+roomID+":events").Val(); ttl <= 0 {
+		t.Fatalf("expected the room's events to expire, got TTL %v", ttl)
+	}
+}
+
+func TestRedisEventLog_TrimsOldEventsAndReportsHoles(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+	ctx := context.Background()
+
+	roomID := uuid.NewString()
+	cleanupEventLogKeys(t, client, roomID)
+	eventLog, err := ws.NewRedisEventLog(ws.RedisEventLogDeps{Client: client, Size: 3})
+	if err != nil {
+		t.Fatalf("failed to create event log: %v", err)
+	}
+
+	channel := ws.RoomChannel("test", roomID)
+	for i := 0; i < 5; i++ {
+		if err := eventLog.Publish(ctx, channel, ws.Event{Type: "TICK", RoomID: roomID}); err != nil {
+			t.Fatalf("failed to publish event: %v", err)
+		}
+	}
+
+	if count := client.ZCard(ctx, "ws:room:"+roomID+":events").Val(); count != 3 {
+		t.Fatalf("expected the log to keep 3 events, got %d", count)
+	}
+	if _, ok, err := eventLog.Since(ctx, roomID, 1); err != nil || ok {
+		t.Fatalf("expected a trimmed run not to be replayable, got ok=%v (%v)", ok, err)
+	}
+	if events, ok, err := eventLog.Since(ctx, roomID, 2); err != nil || !ok || len(events) != 3 || events[0].Seq != 3 {
+		t.Fatalf("expected the kept events to be replayed, got %+v (ok=%v, err=%v)", events, ok, err)
+	}
+	if events, ok, err := eventLog.Since(ctx, roomID, 5); err != nil || !ok || len(events) != 0 {
+		t.Fatalf("expected nothing to replay at the head, got %+v (ok=%v, err=%v)", events, ok, err)
+	}
+	if _, ok, err := eventLog.Since(ctx, roomID, 6); err != nil || ok {
+		t.Fatalf("expected an unknown seq not to be replayable, got ok=%v (%v)", ok, err)
+	}
+
+	client.ZRemRangeByScore(ctx, "ws:room:"+roomID+":events", "4", "4")
+	if _, ok, err := eventLog.Since(ctx, roomID, 2); err != nil || ok {
+		t.Fatalf("expected a run with a hole not to be replayable, got ok=%v (%v)", ok, err)
+	}
+}
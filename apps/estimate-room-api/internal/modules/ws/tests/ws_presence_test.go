@@ -0,0 +1,125 @@
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/master-bogdan/estimate-room-api/internal/modules/ws"
+	testutils "github.com/master-bogdan/estimate-room-api/internal/pkg/test"
+	"github.com/redis/go-redis/v9"
+)
+
+func newTestRedisPresence(t *testing.T, client *redis.Client, roomID string, ttl time.Duration) *ws.RedisPresence {
+	t.Helper()
+
+	presence, err := ws.NewRedisPresence(ws.RedisPresenceDeps{Client: client, TTL: ttl})
+	if err != nil {
+		t.Fatalf("failed to create presence: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Del(context.Background(), "ws:room:"+roomID+":presence")
+	})
+
+	return presence
+}
+
+func TestRedisPresence_ScoresConnectionsByExpiry(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+	ctx := context.Background()
+
+	roomID := uuid.NewString()
+	presence := newTestRedisPresence(t, client, roomID, 30*time.Second)
+
+	firstTab := ws.PresenceConn{RoomID: roomID, ConnID: "conn-1", IdentityType: ws.IdentityTypeUser, IdentityID: "user:1", UserID: "1", ParticipantID: "participant-1"}
+	secondTab := firstTab
+	secondTab.ConnID = "conn-2"
+
+	before := time.Now()
+	if joined, err := presence.Join(ctx, firstTab); err != nil || !joined {
+		t.Fatalf("expected the first connection to join, got %v (%v)", joined, err)
+	}
+	if joined, err := presence.Join(ctx, secondTab); err != nil || joined {
+		t.Fatalf("expected the second connection not to join again, got %v (%v)", joined, err)
+	}
+
+	entries := client.ZRangeWithScores(ctx, "ws:room:"+roomID+":presence", 0, -1).Val()
+	if len(entries) != 2 {
+		t.Fatalf("expected both connections stored, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		expiresAt := time.UnixMilli(int64(entry.Score))
+		if expiresAt.Before(before.Add(29*time.Second)) || expiresAt.After(time.Now().Add(30*time.Second)) {
+			t.Fatalf("expected the connection to be scored by its expiry, got %v", expiresAt)
+		}
+	}
+
+	if ids, err := presence.OnlineParticipantIDs(ctx, roomID); err != nil || len(ids) != 1 || ids[0] != "participant-1" {
+		t.Fatalf("expected the participant online once, got %v (%v)", ids, err)
+	}
+
+	if left, err := presence.Leave(ctx, firstTab); err != nil || left {
+		t.Fatalf("expected the participant to stay with another connection, got %v (%v)", left, err)
+	}
+	if left, err := presence.Leave(ctx, secondTab); err != nil || !left {
+		t.Fatalf("expected the participant to leave with the last connection, got %v (%v)", left, err)
+	}
+}
+
+func TestRedisPresence_ExpiresConnectionsThatAreNotRefreshed(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+	ctx := context.Background()
+
+	roomID := uuid.NewString()
+	presence := newTestRedisPresence(t, client, roomID, 300*time.Millisecond)
+
+	alive := ws.PresenceConn{RoomID: roomID, ConnID: "conn-alive", IdentityType: ws.IdentityTypeUser, IdentityID: "user:alive", UserID: "alive", ParticipantID: "participant-alive"}
+	dead := ws.PresenceConn{RoomID: roomID, ConnID: "conn-dead", IdentityType: ws.IdentityTypeGuest, IdentityID: "guest:participant-dead", ParticipantID: "participant-dead"}
+	for _, conn := range []ws.PresenceConn{alive, dead} {
+		if _, err := presence.Join(ctx, conn); err != nil {
+			t.Fatalf("failed to join: %v", err)
+		}
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if err := presence.Refresh(ctx, []ws.PresenceConn{alive}); err != nil {
+		t.Fatalf("failed to refresh: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	if ids, err := presence.OnlineParticipantIDs(ctx, roomID); err != nil || len(ids) != 1 || ids[0] != "participant-alive" {
+		t.Fatalf("expected only the refreshed participant online, got %v (%v)", ids, err)
+	}
+
+	expired, err := presence.Expire(ctx, roomID)
+	if err != nil || len(expired) != 1 || expired[0] != dead {
+		t.Fatalf("expected the unrefreshed connection to expire, got %+v (%v)", expired, err)
+	}
+	if expired, err := presence.Expire(ctx, roomID); err != nil || len(expired) != 0 {
+		t.Fatalf("expected an expired connection to be reported once, got %+v (%v)", expired, err)
+	}
+}
+
+func TestRedisPresence_RefreshDoesNotRestoreLeftConnections(t *testing.T) {
+	client := testutils.SetupTestRedis(t)
+	ctx := context.Background()
+
+	roomID := uuid.NewString()
+	presence := newTestRedisPresence(t, client, roomID, 30*time.Second)
+
+	conn := ws.PresenceConn{RoomID: roomID, ConnID: "conn-1", IdentityType: ws.IdentityTypeUser, IdentityID: "user:1", UserID: "1", ParticipantID: "participant-1"}
+	if _, err := presence.Join(ctx, conn); err != nil {
+		t.Fatalf("failed to join: %v", err)
+	}
+	if _, err := presence.Leave(ctx, conn); err != nil {
+		t.Fatalf("failed to leave: %v", err)
+	}
+	if err := presence.Refresh(ctx, []ws.PresenceConn{conn}); err != nil {
+		t.Fatalf("failed to refresh: %v", err)
+	}
+
+	if count := client.ZCard(ctx, "ws:room:"+roomID+":presence").Val(); count != 0 {
+		t.Fatalf("expected a refresh racing a leave not to bring the connection back, got %d", count)
+	}
+}
@@ -0,0 +1,155 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultEventLogSize = 200
+	defaultEventLogTTL  = 24 * time.Hour
+)
+
+// publishRoomEventScript numbers, stores and publishes a room event in one
+// step, so events reach subscribers in the order of their sequence numbers.
+// The event comes in without a seq and the script prepends it to the JSON
+// object instead of decoding and re-encoding the event.
+var publishRoomEventScript = redis.NewScript(`
+local seq = redis.call('INCR', KEYS[1])
+local data = '{"seq":' .. seq .. ',' .. string.sub(ARGV[1], 2)
+redis.call('ZADD', KEYS[2], seq, data)
+redis.call('ZREMRANGEBYRANK', KEYS[2], 0, -tonumber(ARGV[2]) - 1)
+redis.call('EXPIRE', KEYS[1], ARGV[3])
+redis.call('EXPIRE', KEYS[2], ARGV[3])
+redis.call('PUBLISH', ARGV[4], data)
+return seq
+`)
+
+// RedisEventLog keeps the latest events of every room in Redis: a counter
+// hands out the sequence numbers and a sorted set scored by sequence holds the
+// events. Both keys expire once the room has been quiet for the TTL.
+type RedisEventLog struct {
+	client *redis.Client
+	size   int64
+	ttl    time.Duration
+}
+
+type RedisEventLogDeps struct {
+	Client *redis.Client
+	Size   int
+	TTL    time.Duration
+}
+
+func NewRedisEventLog(deps RedisEventLogDeps) (*RedisEventLog, error) {
+	if deps.Client == nil {
+		return nil, errors.New("redis client is required")
+	}
+
+	size := int64(deps.Size)
+	if size <= 0 {
+		size = defaultEventLogSize
+	}
+	ttl := deps.TTL
+	if ttl <= 0 {
+		ttl = defaultEventLogTTL
+	}
+
+	return &RedisEventLog{
+		client: deps.Client,
+		size:   size,
+		ttl:    ttl,
+	}, nil
+}
+
+func (l *RedisEventLog) Publish(ctx context.Context, channel string, event Event) error {
+	if event.RoomID == "" {
+		return errors.New("roomID is required")
+	}
+
+	event.Seq = 0
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ttlSeconds := int64(l.ttl / time.Second)
+	if ttlSeconds <= 0 {
+		ttlSeconds = 1
+	}
+
+	return publishRoomEventScript.Run(
+		ctx,
+		l.client,
+		[]string{eventLogSeqKey(event.RoomID), eventLogEventsKey(event.RoomID)},
+		string(data),
+		l.size,
+		ttlSeconds,
+		channel,
+	).Err()
+}
+
+func (l *RedisEventLog) LastSeq(ctx context.Context, roomID string) (int64, error) {
+	seq, err := l.client.Get(ctx, eventLogSeqKey(roomID)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return seq, nil
+}
+
+// Since only replays an unbroken run up to the latest sequence number. A run
+// with holes, because older events were trimmed, is reported as not
+// replayable.
+func (l *RedisEventLog) Since(ctx context.Context, roomID string, lastSeq int64) ([]Event, bool, error) {
+	head, err := l.LastSeq(ctx, roomID)
+	if err != nil {
+		return nil, false, err
+	}
+	if lastSeq > head {
+		return nil, false, nil
+	}
+	if lastSeq == head {
+		return []Event{}, true, nil
+	}
+
+	members, err := l.client.ZRangeByScore(ctx, eventLogEventsKey(roomID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(lastSeq, 10),
+		Max: strconv.FormatInt(head, 10),
+	}).Result()
+	if err != nil {
+		return nil, false, err
+	}
+	if int64(len(members)) != head-lastSeq {
+		return nil, false, nil
+	}
+
+	events := make([]Event, 0, len(members))
+	for i, member := range members {
+		event := Event{}
+		if err := json.Unmarshal([]byte(member), &event); err != nil {
+			return nil, false, err
+		}
+		if event.Seq != lastSeq+int64(i)+1 {
+			return nil, false, nil
+		}
+		events = append(events, event)
+	}
+
+	return events, true, nil
+}
+
+func eventLogSeqKey(roomID string) string {
+	return "ws:room:" + roomID + ":seq"
+}
+
+func eventLogEventsKey(roomID string) string {
+	return "ws:room:" + roomID + ":events"
+}
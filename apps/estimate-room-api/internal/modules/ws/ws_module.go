@@ -29,6 +29,8 @@ type WsModuleDeps struct {
 	AuthService          oauth2.Oauth2SessionAuthService
 	TokenKey             string
 	Server               PubSub
+	EventLog             EventLog
+	Presence             Presence
 	OriginPatterns       []string
 	MessageRatePerMinute int
 }
@@ -36,6 +38,12 @@ type WsModuleDeps struct {
 func NewWsModule(deps WsModuleDeps) *WsModule {
 	service := NewService(deps.Server, defaultChannel)
 	service.SetOriginPatterns(deps.OriginPatterns)
+	if deps.EventLog != nil {
+		service.SetEventLog(deps.EventLog)
+	}
+	if deps.Presence != nil {
+		service.SetPresence(deps.Presence)
+	}
 	messageRatePerMinute := deps.MessageRatePerMinute
 	if messageRatePerMinute <= 0 {
 		messageRatePerMinute = 120
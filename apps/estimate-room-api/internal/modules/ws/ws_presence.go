@@ -0,0 +1,259 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultPresenceTTL = 30 * time.Second
+
+// RedisPresence keeps the room connections of every instance in Redis, one
+// sorted set per room scored by the time each connection expires. Expired
+// connections are never reported as online and stay stored until Expire
+// removes them, so their leave is reported exactly once.
+type RedisPresence struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+type RedisPresenceDeps struct {
+	Client *redis.Client
+	TTL    time.Duration
+}
+
+// presenceMember is stored as the sorted set member, so it holds exactly the
+// fields a connection was joined with.
+type presenceMember struct {
+	ConnID        string `json:"connId"`
+	IdentityType  string `json:"identityType,omitempty"`
+	IdentityID    string `json:"identityId"`
+	UserID        string `json:"userId,omitempty"`
+	ParticipantID string `json:"participantId,omitempty"`
+}
+
+func NewRedisPresence(deps RedisPresenceDeps) (*RedisPresence, error) {
+	if deps.Client == nil {
+		return nil, errors.New("redis client is required")
+	}
+
+	ttl := deps.TTL
+	if ttl <= 0 {
+		ttl = defaultPresenceTTL
+	}
+
+	return &RedisPresence{
+		client: deps.Client,
+		ttl:    ttl,
+	}, nil
+}
+
+func (p *RedisPresence) Join(ctx context.Context, conn PresenceConn) (bool, error) {
+	if conn.RoomID == "" {
+		return false, errors.New("roomID is required")
+	}
+
+	member, err := encodePresenceMember(conn)
+	if err != nil {
+		return false, err
+	}
+
+	key := presenceKey(conn.RoomID)
+	now := time.Now()
+
+	var members *redis.StringSliceCmd
+	_, err = p.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.Add(p.ttl).UnixMilli()), Member: member})
+		pipe.Expire(ctx, key, p.ttl)
+		members = pipe.ZRangeByScore(ctx, key, livePresenceRange(now))
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return countIdentityMembers(members.Val(), conn.IdentityID) == 1, nil
+}
+
+func (p *RedisPresence) Leave(ctx context.Context, conn PresenceConn) (bool, error) {
+	if conn.RoomID == "" {
+		return false, errors.New("roomID is required")
+	}
+
+	member, err := encodePresenceMember(conn)
+	if err != nil {
+		return false, err
+	}
+
+	key := presenceKey(conn.RoomID)
+
+	var members *redis.StringSliceCmd
+	_, err = p.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, key, member)
+		members = pipe.ZRangeByScore(ctx, key, livePresenceRange(time.Now()))
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return countIdentityMembers(members.Val(), conn.IdentityID) == 0, nil
+}
+
+// Refresh only extends connections that are still stored, so a heartbeat
+// racing a leave cannot bring the connection back.
+func (p *RedisPresence) Refresh(ctx context.Context, conns []PresenceConn) error {
+	if len(conns) == 0 {
+		return nil
+	}
+
+	expiresAt := float64(time.Now().Add(p.ttl).UnixMilli())
+	rooms := make(map[string]struct{})
+
+	_, err := p.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, conn := range conns {
+			if conn.RoomID == "" {
+				continue
+			}
+
+			member, err := encodePresenceMember(conn)
+			if err != nil {
+				return err
+			}
+
+			pipe.ZAddXX(ctx, presenceKey(conn.RoomID), redis.Z{Score: expiresAt, Member: member})
+			rooms[conn.RoomID] = struct{}{}
+		}
+		for roomID := range rooms {
+			pipe.Expire(ctx, presenceKey(roomID), p.ttl)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// Expire reads and removes the expired connections in one transaction, so
+// when several instances sweep the same room only one of them gets them.
+func (p *RedisPresence) Expire(ctx context.Context, roomID string) ([]PresenceConn, error) {
+	if roomID == "" {
+		return nil, errors.New("roomID is required")
+	}
+
+	key := presenceKey(roomID)
+	now := presenceScore(time.Now())
+
+	var expired, live *redis.StringSliceCmd
+	_, err := p.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		expired = pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: "-inf", Max: now})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", now)
+		live = pipe.ZRange(ctx, key, 0, -1)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	left := make([]PresenceConn, 0)
+	seen := make(map[string]struct{})
+	for _, raw := range expired.Val() {
+		member := presenceMember{}
+		if err := json.Unmarshal([]byte(raw), &member); err != nil {
+			continue
+		}
+		if _, ok := seen[member.IdentityID]; ok {
+			continue
+		}
+		seen[member.IdentityID] = struct{}{}
+		if countIdentityMembers(live.Val(), member.IdentityID) > 0 {
+			continue
+		}
+
+		left = append(left, PresenceConn{
+			RoomID:        roomID,
+			ConnID:        member.ConnID,
+			IdentityType:  IdentityType(member.IdentityType),
+			IdentityID:    member.IdentityID,
+			UserID:        member.UserID,
+			ParticipantID: member.ParticipantID,
+		})
+	}
+
+	return left, nil
+}
+
+func (p *RedisPresence) OnlineParticipantIDs(ctx context.Context, roomID string) ([]string, error) {
+	members, err := p.client.ZRangeByScore(ctx, presenceKey(roomID), livePresenceRange(time.Now())).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(members))
+	ids := make([]string, 0, len(members))
+	for _, raw := range members {
+		member := presenceMember{}
+		if err := json.Unmarshal([]byte(raw), &member); err != nil {
+			return nil, err
+		}
+		if member.ParticipantID == "" {
+			continue
+		}
+		if _, ok := seen[member.ParticipantID]; ok {
+			continue
+		}
+		seen[member.ParticipantID] = struct{}{}
+		ids = append(ids, member.ParticipantID)
+	}
+
+	return ids, nil
+}
+
+func encodePresenceMember(conn PresenceConn) (string, error) {
+	data, err := json.Marshal(presenceMember{
+		ConnID:        conn.ConnID,
+		IdentityType:  string(conn.IdentityType),
+		IdentityID:    conn.IdentityID,
+		UserID:        conn.UserID,
+		ParticipantID: conn.ParticipantID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func countIdentityMembers(members []string, identityID string) int {
+	count := 0
+	for _, raw := range members {
+		member := presenceMember{}
+		if err := json.Unmarshal([]byte(raw), &member); err != nil {
+			continue
+		}
+		if member.IdentityID == identityID {
+			count++
+		}
+	}
+
+	return count
+}
+
+// livePresenceRange selects the connections that have not expired at now.
+func livePresenceRange(now time.Time) *redis.ZRangeBy {
+	return &redis.ZRangeBy{
+		Min: "(" + presenceScore(now),
+		Max: "+inf",
+	}
+}
+
+func presenceScore(at time.Time) string {
+	return strconv.FormatInt(at.UnixMilli(), 10)
+}
+
+func presenceKey(roomID string) string {
+	return "ws:room:" + roomID + ":presence"
+}
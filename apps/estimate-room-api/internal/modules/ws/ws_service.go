@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"sort"
 	"strings"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/coder/websocket"
 	"github.com/google/uuid"
+	apperrors "github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/logger"
 	"github.com/master-bogdan/estimate-room-api/internal/pkg/metrics"
 )
@@ -19,6 +21,23 @@ import (
 const (
 	pingInterval = 30 * time.Second
 	pingTimeout  = 10 * time.Second
+
+	// presenceHeartbeatInterval must stay well below the presence TTL so a
+	// live connection is refreshed several times before it could expire.
+	presenceHeartbeatInterval = 10 * time.Second
+
+	// eventTypeDisconnect travels over the pub/sub channel only; every
+	// instance closes its own matching connections instead of forwarding it.
+	eventTypeDisconnect = "WS_DISCONNECT"
+	// eventTypeRoomUser wraps an event addressed to one user's connections in
+	// a room; every instance unwraps it and delivers to its own connections.
+	eventTypeRoomUser = "WS_ROOM_USER"
+	// eventTypeIdentity wraps an event addressed to every connection of an
+	// identity, wherever it is connected.
+	eventTypeIdentity = "WS_IDENTITY"
+	// eventTypeIdentityConnected tells every instance to close the other
+	// connections of an identity that has just connected.
+	eventTypeIdentityConnected = "WS_IDENTITY_CONNECTED"
 )
 
 type Client struct {
@@ -32,6 +51,10 @@ type Client struct {
 	Send          chan []byte
 	MessageWindow time.Time
 	MessageCount  int
+	// joined is the connection as recorded in the presence of RoomID. It
+	// keeps the participant the client joined as, which may change before
+	// the client leaves.
+	joined PresenceConn
 }
 
 type Service struct {
@@ -43,8 +66,12 @@ type Service struct {
 	register        chan *Client
 	unregister      chan *Client
 	mu              sync.RWMutex
+	subMu           sync.Mutex
+	roomSubscribed  map[string]bool
 	server          PubSub
 	channel         string
+	eventLog        EventLog
+	presence        Presence
 	originPatterns  []string
 	maxMessages     int
 	messageWindow   time.Duration
@@ -59,6 +86,7 @@ func NewService(server PubSub, channel string) *Service {
 		identityClients: make(map[string]map[*Client]bool),
 		roomClients:     make(map[string]map[*Client]bool),
 		roomPresence:    make(map[string]map[string]int),
+		roomSubscribed:  make(map[string]bool),
 		register:        make(chan *Client),
 		unregister:      make(chan *Client),
 		server:          server,
@@ -91,6 +119,53 @@ func (s *Service) SetOriginPatterns(patterns []string) {
 	s.mu.Unlock()
 }
 
+// SetEventLog enables sequence numbers and replay for room broadcasts.
+func (s *Service) SetEventLog(eventLog EventLog) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.eventLog = eventLog
+	s.mu.Unlock()
+}
+
+// SetPresence computes room joins, leaves and online participants across
+// every instance sharing the presence instead of from local connections only.
+func (s *Service) SetPresence(presence Presence) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	s.presence = presence
+	s.mu.Unlock()
+}
+
+// Start keeps this instance's room connections alive in the presence until
+// ctx is done, and reports the participants of its rooms whose connections
+// expired on an instance that stopped without leaving.
+func (s *Service) Start(ctx context.Context) {
+	if s == nil || ctx == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(presenceHeartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.refreshPresence(ctx)
+				s.expirePresence(ctx)
+			}
+		}
+	}()
+}
+
 func (s *Service) SetInboundRateLimit(maxMessages int, window time.Duration) {
 	if s == nil || maxMessages <= 0 || window <= 0 {
 		return
@@ -134,11 +209,14 @@ func (s *Service) run() {
 
 		case client := <-s.unregister:
 			var disconnectInfo *DisconnectInfo
+			var left PresenceConn
 			removed := false
 
 			s.mu.Lock()
+			presence := s.presence
 			if _, ok := s.clients[client]; ok {
 				removed = true
+				left = client.joined
 				roomID, presenceLeft := s.removeClientFromRoomLocked(client)
 
 				delete(s.clients, client)
@@ -166,7 +244,7 @@ func (s *Service) run() {
 			}
 			s.logDisconnect(clientInfo(client))
 			if disconnectInfo != nil {
-				s.dispatchDisconnect(*disconnectInfo)
+				go s.leaveRoom(presence, left, *disconnectInfo)
 			}
 		}
 	}
@@ -181,6 +259,18 @@ func (s *Service) Subscribe(eventType string, handler EventHandler) {
 	s.mu.Unlock()
 }
 
+// HandleCommand subscribes a command handler and answers every command it
+// handles with an ACK or an ERROR to the sending connection.
+func (s *Service) HandleCommand(eventType string, handler CommandHandler) {
+	if handler == nil {
+		return
+	}
+
+	s.Subscribe(eventType, func(info ClientInfo, event Event) {
+		s.replyToCommand(info, event, handler(info, event))
+	})
+}
+
 func (s *Service) SubscribeDisconnect(handler DisconnectHandler) {
 	if handler == nil {
 		return
@@ -208,17 +298,19 @@ func (s *Service) JoinRoom(connID, roomID string) (JoinRoomResult, error) {
 	result := JoinRoomResult{RoomID: trimmedRoomID}
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	client, ok := s.connClients[trimmedConnID]
 	if !ok || client == nil {
+		s.mu.Unlock()
 		return JoinRoomResult{}, errors.New("connection not found")
 	}
 
 	if client.RoomID == trimmedRoomID {
+		s.mu.Unlock()
 		return result, nil
 	}
 
+	presence := s.presence
+	previous := client.joined
 	if client.RoomID != "" {
 		prevRoomID, prevLeft := s.removeClientFromRoomLocked(client)
 		result.PreviousRoomID = prevRoomID
@@ -239,6 +331,23 @@ func (s *Service) JoinRoom(connID, roomID string) (JoinRoomResult, error) {
 	}
 
 	client.RoomID = trimmedRoomID
+	client.joined = PresenceConn{
+		RoomID:        trimmedRoomID,
+		ConnID:        client.ConnID,
+		IdentityType:  client.IdentityType,
+		IdentityID:    client.IdentityID,
+		UserID:        client.UserID,
+		ParticipantID: client.ParticipantID,
+	}
+	joined := client.joined
+	s.mu.Unlock()
+
+	s.syncRoomSubscription(trimmedRoomID)
+	if result.PreviousRoomID != "" {
+		s.syncRoomSubscription(result.PreviousRoomID)
+		result.PreviousLeft = s.leavePresence(presence, previous, result.PreviousLeft)
+	}
+	result.Joined = s.joinPresence(presence, joined, result.Joined)
 
 	return result, nil
 }
@@ -298,6 +407,8 @@ func (s *Service) SendToConnection(connID string, event Event) error {
 	return errors.New("connection is not writable")
 }
 
+// SendToIdentity delivers the event to every connection of the identity, on
+// this and every other instance sharing the pub/sub channel.
 func (s *Service) SendToIdentity(identityID string, event Event) error {
 	trimmedIdentityID := strings.TrimSpace(identityID)
 	if trimmedIdentityID == "" {
@@ -310,9 +421,30 @@ func (s *Service) SendToIdentity(identityID string, event Event) error {
 		return err
 	}
 
+	if s.server == nil {
+		s.sendIdentityRaw(trimmedIdentityID, data)
+		return nil
+	}
+
+	payload, err := json.Marshal(identityPayload{
+		IdentityID: trimmedIdentityID,
+		Event:      data,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.server.Publish(s.channel, Event{
+		Type:      eventTypeIdentity,
+		Payload:   payload,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+func (s *Service) sendIdentityRaw(identityID string, data []byte) {
 	unwritable := make([]*Client, 0)
 	s.mu.RLock()
-	identityMembers := s.identityClients[trimmedIdentityID]
+	identityMembers := s.identityClients[identityID]
 	for client := range identityMembers {
 		select {
 		case client.Send <- data:
@@ -325,8 +457,6 @@ func (s *Service) SendToIdentity(identityID string, event Event) error {
 	for _, client := range unwritable {
 		s.unregister <- client
 	}
-
-	return nil
 }
 
 func (s *Service) SendToUser(userID string, event Event) error {
@@ -339,12 +469,27 @@ func (s *Service) SendToUser(userID string, event Event) error {
 	return s.SendToIdentity("user:"+trimmedUserID, event)
 }
 
+// GetRoomOnlineParticipantIDs lists the participants connected to the room
+// on any instance, or on this one when there is no presence or it fails.
 func (s *Service) GetRoomOnlineParticipantIDs(roomID string) []string {
 	trimmedRoomID := strings.TrimSpace(roomID)
 	if trimmedRoomID == "" {
 		return nil
 	}
 
+	s.mu.RLock()
+	presence := s.presence
+	s.mu.RUnlock()
+
+	if presence != nil {
+		ids, err := presence.OnlineParticipantIDs(context.Background(), trimmedRoomID)
+		if err == nil {
+			sort.Strings(ids)
+			return ids
+		}
+		logger.L().Warn(wsLog("Failed to read room presence"), "err", err, "room_id", trimmedRoomID)
+	}
+
 	s.mu.RLock()
 	roomMembers := s.roomClients[trimmedRoomID]
 	idsMap := make(map[string]struct{}, len(roomMembers))
@@ -388,7 +533,7 @@ func (s *Service) Connect(w http.ResponseWriter, r *http.Request, identity Conne
 		Send:          make(chan []byte, 256),
 	}
 
-	s.disconnectIdentityConnections(identityID)
+	s.disconnectIdentityConnections(identityID, connID)
 
 	s.register <- client
 
@@ -482,6 +627,29 @@ func (s *Service) writeHandler(client *Client) {
 func (s *Service) broadcastRaw(data []byte) {
 	event := Event{}
 	if err := json.Unmarshal(data, &event); err == nil {
+		if event.Type == eventTypeDisconnect {
+			payload := disconnectPayload{}
+			if err := json.Unmarshal(event.Payload, &payload); err == nil {
+				s.closeParticipantConnections(event.RoomID, payload.ParticipantID, payload.Reason)
+			}
+			return
+		}
+		if event.Type == eventTypeRoomUser {
+			s.sendRoomUserRaw(event.RoomID, event.UserID, event.Payload)
+			return
+		}
+		if event.Type == eventTypeIdentity || event.Type == eventTypeIdentityConnected {
+			payload := identityPayload{}
+			if err := json.Unmarshal(event.Payload, &payload); err == nil {
+				if event.Type == eventTypeIdentity {
+					s.sendIdentityRaw(payload.IdentityID, payload.Event)
+				} else {
+					s.closeIdentityConnections(payload.IdentityID, payload.ConnID)
+				}
+			}
+			return
+		}
+
 		roomID := strings.TrimSpace(event.RoomID)
 		if roomID != "" {
 			s.broadcastRoomRaw(roomID, data)
@@ -531,28 +699,289 @@ func (s *Service) Broadcast(message any) error {
 	switch v := message.(type) {
 	case Event:
 		s.normalizeOutgoingEvent(&v)
-		return s.server.Publish(s.channel, v)
+		return s.publishEvent(v)
 	case *Event:
 		if v == nil {
 			return errors.New("ws event is nil")
 		}
 		s.normalizeOutgoingEvent(v)
-		return s.server.Publish(s.channel, v)
+		return s.publishEvent(*v)
 	}
 
 	return s.server.Publish(s.channel, message)
 }
 
-func (s *Service) disconnectIdentityConnections(identityID string) {
+// RoomChannel names the pub/sub channel carrying the room's events. Only
+// instances with a client in the room subscribe to it.
+func RoomChannel(channel, roomID string) string {
+	return channel + ":room:" + roomID
+}
+
+func (s *Service) eventChannel(event Event) string {
+	if event.RoomID == "" {
+		return s.channel
+	}
+	return RoomChannel(s.channel, event.RoomID)
+}
+
+// syncRoomSubscription subscribes the room channel while the room has local
+// clients and drops it once it has none. It runs after mu is released and
+// reads the room's clients again under subMu, so joins and leaves racing each
+// other always settle on the subscription the room needs. Events published
+// before the subscription is in place are not delivered here; joining clients
+// catch up through the snapshot or ROOMS_RESUME.
+func (s *Service) syncRoomSubscription(roomID string) {
+	if s.server == nil || roomID == "" {
+		return
+	}
+
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	s.mu.RLock()
+	wanted := len(s.roomClients[roomID]) > 0
+	s.mu.RUnlock()
+
+	if wanted == s.roomSubscribed[roomID] {
+		return
+	}
+
+	channel := RoomChannel(s.channel, roomID)
+	if wanted {
+		s.roomSubscribed[roomID] = true
+		s.server.Subscribe(channel, func(data []byte) {
+			s.broadcastRaw(data)
+		})
+		return
+	}
+
+	delete(s.roomSubscribed, roomID)
+	s.server.Unsubscribe(channel)
+}
+
+// publishEvent hands room events to the event log, which numbers and
+// publishes them together so they go out in sequence order.
+func (s *Service) publishEvent(event Event) error {
+	channel := s.eventChannel(event)
+
+	s.mu.RLock()
+	eventLog := s.eventLog
+	s.mu.RUnlock()
+
+	if eventLog == nil || event.RoomID == "" {
+		return s.server.Publish(channel, event)
+	}
+
+	return eventLog.Publish(context.Background(), channel, event)
+}
+
+// RoomSeq reports the latest sequence number of the room's broadcasts, or 0
+// when there is no event log.
+func (s *Service) RoomSeq(roomID string) int64 {
+	trimmedRoomID := strings.TrimSpace(roomID)
+
+	s.mu.RLock()
+	eventLog := s.eventLog
+	s.mu.RUnlock()
+
+	if eventLog == nil || trimmedRoomID == "" {
+		return 0
+	}
+
+	seq, err := eventLog.LastSeq(context.Background(), trimmedRoomID)
+	if err != nil {
+		logger.L().Warn(wsLog("Failed to read room sequence"), "err", err, "room_id", trimmedRoomID)
+		return 0
+	}
+
+	return seq
+}
+
+// RoomEventsSince returns the room broadcasts numbered after lastSeq, and
+// false when they cannot all be replayed.
+func (s *Service) RoomEventsSince(roomID string, lastSeq int64) ([]Event, bool) {
+	trimmedRoomID := strings.TrimSpace(roomID)
+
+	s.mu.RLock()
+	eventLog := s.eventLog
+	s.mu.RUnlock()
+
+	if eventLog == nil || trimmedRoomID == "" || lastSeq <= 0 {
+		return nil, false
+	}
+
+	events, ok, err := eventLog.Since(context.Background(), trimmedRoomID, lastSeq)
+	if err != nil {
+		logger.L().Warn(wsLog("Failed to read room events"), "err", err, "room_id", trimmedRoomID, "last_seq", lastSeq)
+		return nil, false
+	}
+
+	return events, ok
+}
+
+// disconnectIdentityConnections closes the identity's connections other than
+// connID, on this and every other instance sharing the pub/sub channel.
+func (s *Service) disconnectIdentityConnections(identityID, connID string) {
+	if s.server == nil {
+		s.closeIdentityConnections(identityID, connID)
+		return
+	}
+
+	payload, err := json.Marshal(identityPayload{
+		IdentityID: identityID,
+		ConnID:     connID,
+	})
+	if err == nil {
+		err = s.server.Publish(s.channel, Event{
+			Type:      eventTypeIdentityConnected,
+			Payload:   payload,
+			Timestamp: time.Now().UTC(),
+		})
+	}
+	if err != nil {
+		logger.L().Warn(wsLog("Failed to announce identity connection"), "err", err, "identity_id", identityID, "conn_id", connID)
+		s.closeIdentityConnections(identityID, connID)
+	}
+}
+
+func (s *Service) closeIdentityConnections(identityID, connID string) {
 	s.mu.RLock()
 	existing := make([]*Client, 0, len(s.identityClients[identityID]))
 	for client := range s.identityClients[identityID] {
-		existing = append(existing, client)
+		if client.ConnID != connID {
+			existing = append(existing, client)
+		}
 	}
 	s.mu.RUnlock()
 
 	for _, client := range existing {
-		_ = client.Conn.Close(websocket.StatusPolicyViolation, "another connection opened")
+		if client.Conn == nil {
+			s.unregister <- client
+			continue
+		}
+		go client.Conn.Close(websocket.StatusPolicyViolation, "another connection opened")
+	}
+}
+
+// DisconnectParticipant closes every connection bound to the room
+// participant, on this and every other instance sharing the pub/sub channel.
+// It goes over the shared channel rather than the room's, since a guest may
+// be connected on an instance without having joined the room.
+func (s *Service) DisconnectParticipant(roomID, participantID, reason string) error {
+	trimmedRoomID := strings.TrimSpace(roomID)
+	trimmedParticipantID := strings.TrimSpace(participantID)
+	if trimmedRoomID == "" || trimmedParticipantID == "" {
+		return errors.New("roomID and participantID are required")
+	}
+
+	if s.server == nil {
+		s.closeParticipantConnections(trimmedRoomID, trimmedParticipantID, reason)
+		return nil
+	}
+
+	payload, err := json.Marshal(disconnectPayload{
+		ParticipantID: trimmedParticipantID,
+		Reason:        reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.server.Publish(s.channel, Event{
+		Type:      eventTypeDisconnect,
+		RoomID:    trimmedRoomID,
+		Payload:   payload,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// SendToRoomUser delivers the event to the user's connections joined to the
+// room, on this and every other instance subscribed to the room's channel.
+func (s *Service) SendToRoomUser(roomID, userID string, event Event) error {
+	trimmedRoomID := strings.TrimSpace(roomID)
+	trimmedUserID := strings.TrimSpace(userID)
+	if trimmedRoomID == "" || trimmedUserID == "" {
+		return errors.New("roomID and userID are required")
+	}
+
+	event.RoomID = trimmedRoomID
+	s.normalizeOutgoingEvent(&event)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if s.server == nil {
+		s.sendRoomUserRaw(trimmedRoomID, trimmedUserID, data)
+		return nil
+	}
+
+	return s.server.Publish(RoomChannel(s.channel, trimmedRoomID), Event{
+		Type:      eventTypeRoomUser,
+		RoomID:    trimmedRoomID,
+		UserID:    trimmedUserID,
+		Payload:   data,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+func (s *Service) sendRoomUserRaw(roomID, userID string, data []byte) {
+	if strings.TrimSpace(userID) == "" {
+		return
+	}
+
+	s.mu.RLock()
+	clients := make([]*Client, 0)
+	for client := range s.roomClients[roomID] {
+		if client.UserID == userID {
+			clients = append(clients, client)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, client := range clients {
+		select {
+		case client.Send <- data:
+		default:
+			s.unregister <- client
+		}
+	}
+}
+
+type disconnectPayload struct {
+	ParticipantID string `json:"participantId"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type identityPayload struct {
+	IdentityID string          `json:"identityId"`
+	ConnID     string          `json:"connId,omitempty"`
+	Event      json.RawMessage `json:"event,omitempty"`
+}
+
+// closeParticipantConnections matches guest connections by identity as well,
+// since a guest may be connected without having joined the room yet.
+func (s *Service) closeParticipantConnections(roomID, participantID, reason string) {
+	if strings.TrimSpace(participantID) == "" {
+		return
+	}
+
+	s.mu.RLock()
+	matched := make([]*Client, 0)
+	for client := range s.clients {
+		boundToRoom := client.ParticipantID == participantID && (client.RoomID == "" || client.RoomID == roomID)
+		if boundToRoom || client.IdentityID == "guest:"+participantID {
+			matched = append(matched, client)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, client := range matched {
+		if client.Conn == nil {
+			s.unregister <- client
+			continue
+		}
+		go client.Conn.Close(websocket.StatusPolicyViolation, reason)
 	}
 }
 
@@ -561,11 +990,92 @@ func (s *Service) dispatchEvent(info ClientInfo, event Event) {
 	handlers := append([]EventHandler(nil), s.subscriptions[event.Type]...)
 	s.mu.RUnlock()
 
+	if len(handlers) == 0 {
+		s.replyToCommand(info, event, fmt.Errorf("%w: unknown event type", apperrors.ErrBadRequest))
+		return
+	}
+
 	for _, handler := range handlers {
 		handler(info, event)
 	}
 }
 
+// replyToCommand answers on the command's connection only. A broadcast the
+// command caused travels over pub/sub and may reach the sender after the ACK.
+func (s *Service) replyToCommand(info ClientInfo, command Event, err error) {
+	reply := Event{
+		Type:          EventTypeAck,
+		RoomID:        command.RoomID,
+		CorrelationID: command.CorrelationID,
+	}
+
+	var payload any = AckPayload{Command: command.Type}
+	if err != nil {
+		reply.Type = EventTypeError
+		payload = newErrorPayload(command.Type, err)
+	}
+
+	data, marshalErr := json.Marshal(payload)
+	if marshalErr != nil {
+		s.logError(info, marshalErr)
+		return
+	}
+	reply.Payload = data
+
+	if sendErr := s.SendToConnection(info.ConnID, reply); sendErr != nil {
+		logger.L().Debug(wsLog("Command reply dropped"), "conn_id", info.ConnID, "command", command.Type, "err", sendErr)
+	}
+}
+
+// newErrorPayload keeps the detail of an apperrors error and hides the cause
+// of anything else behind a generic internal error.
+func newErrorPayload(command string, err error) ErrorPayload {
+	code := errorCode(err)
+	if code == errorCodeInternal {
+		httpErr := apperrors.CreateHttpError(apperrors.ErrInternal)
+		return ErrorPayload{
+			Command: command,
+			Code:    code,
+			Status:  httpErr.Status,
+			Detail:  httpErr.Detail,
+		}
+	}
+
+	httpErr := apperrors.CreateHttpError(err)
+	detail := err.Error()
+	if unwrapped := errors.Unwrap(err); unwrapped != nil {
+		detail = strings.TrimPrefix(detail, unwrapped.Error()+": ")
+	}
+
+	return ErrorPayload{
+		Command: command,
+		Code:    code,
+		Status:  httpErr.Status,
+		Detail:  detail,
+	}
+}
+
+const errorCodeInternal = "INTERNAL"
+
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, apperrors.ErrBadRequest):
+		return "BAD_REQUEST"
+	case errors.Is(err, apperrors.ErrUnauthorized):
+		return "UNAUTHORIZED"
+	case errors.Is(err, apperrors.ErrForbidden):
+		return "FORBIDDEN"
+	case errors.Is(err, apperrors.ErrNotFound), errors.Is(err, apperrors.ErrUserNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, apperrors.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, apperrors.ErrServiceUnavailable):
+		return "SERVICE_UNAVAILABLE"
+	default:
+		return errorCodeInternal
+	}
+}
+
 func (s *Service) dispatchDisconnect(info DisconnectInfo) {
 	s.mu.RLock()
 	handlers := append([]DisconnectHandler(nil), s.disconnects...)
@@ -676,6 +1186,7 @@ func (s *Service) normalizeIncomingEvent(client *Client, event *Event) {
 
 	event.Type = strings.TrimSpace(event.Type)
 	event.RoomID = strings.TrimSpace(event.RoomID)
+	event.CorrelationID = strings.TrimSpace(event.CorrelationID)
 	event.UserID = clientEventUserID(client)
 	if event.Timestamp.IsZero() {
 		event.Timestamp = time.Now().UTC()
@@ -757,5 +1268,106 @@ func (s *Service) removeClientFromRoomLocked(client *Client) (string, bool) {
 	}
 
 	client.RoomID = ""
+	client.joined = PresenceConn{}
 	return roomID, presenceLeft
 }
+
+// joinPresence reports whether the connection is the identity's first in the
+// room across the cluster, falling back to the local answer when there is no
+// presence or it fails.
+func (s *Service) joinPresence(presence Presence, conn PresenceConn, localJoined bool) bool {
+	if presence == nil {
+		return localJoined
+	}
+
+	joined, err := presence.Join(context.Background(), conn)
+	if err != nil {
+		logger.L().Warn(wsLog("Failed to record room presence"), "err", err, "room_id", conn.RoomID, "conn_id", conn.ConnID)
+		return localJoined
+	}
+
+	return joined
+}
+
+// leavePresence reports whether the identity has left the room across the
+// cluster, falling back to the local answer when there is no presence or it
+// fails.
+func (s *Service) leavePresence(presence Presence, conn PresenceConn, localLeft bool) bool {
+	if presence == nil || conn.RoomID == "" {
+		return localLeft
+	}
+
+	left, err := presence.Leave(context.Background(), conn)
+	if err != nil {
+		logger.L().Warn(wsLog("Failed to remove room presence"), "err", err, "room_id", conn.RoomID, "conn_id", conn.ConnID)
+		return localLeft
+	}
+
+	return left
+}
+
+// leaveRoom finishes a disconnect outside the hub loop, since dropping the
+// room subscription and the presence both wait on Redis.
+func (s *Service) leaveRoom(presence Presence, left PresenceConn, info DisconnectInfo) {
+	s.syncRoomSubscription(info.RoomID)
+	info.PresenceLeft = s.leavePresence(presence, left, info.PresenceLeft)
+	s.dispatchDisconnect(info)
+}
+
+func (s *Service) refreshPresence(ctx context.Context) {
+	s.mu.RLock()
+	presence := s.presence
+	conns := make([]PresenceConn, 0)
+	for _, members := range s.roomClients {
+		for client := range members {
+			conns = append(conns, client.joined)
+		}
+	}
+	s.mu.RUnlock()
+
+	if presence == nil || len(conns) == 0 {
+		return
+	}
+
+	if err := presence.Refresh(ctx, conns); err != nil {
+		logger.L().Warn(wsLog("Failed to refresh room presence"), "err", err, "connections", len(conns))
+	}
+}
+
+// expirePresence sweeps the rooms this instance has clients in and reports a
+// disconnect for every identity whose last connection expired.
+func (s *Service) expirePresence(ctx context.Context) {
+	s.mu.RLock()
+	presence := s.presence
+	roomIDs := make([]string, 0, len(s.roomClients))
+	for roomID := range s.roomClients {
+		roomIDs = append(roomIDs, roomID)
+	}
+	s.mu.RUnlock()
+
+	if presence == nil {
+		return
+	}
+
+	for _, roomID := range roomIDs {
+		expired, err := presence.Expire(ctx, roomID)
+		if err != nil {
+			logger.L().Warn(wsLog("Failed to expire room presence"), "err", err, "room_id", roomID)
+			continue
+		}
+
+		for _, conn := range expired {
+			s.dispatchDisconnect(DisconnectInfo{
+				Client: ClientInfo{
+					ConnID:        conn.ConnID,
+					IdentityType:  conn.IdentityType,
+					IdentityID:    conn.IdentityID,
+					UserID:        conn.UserID,
+					ParticipantID: conn.ParticipantID,
+				},
+				RoomID:       roomID,
+				PresenceLeft: true,
+			})
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package ws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// BenchmarkServiceFanOut publishes one event to each of N rooms while this
+// instance hosts a client in only one of them. On the shared channel every
+// event reaches the instance and is decoded before being dropped; on room
+// channels only the local room's event arrives.
+func BenchmarkServiceFanOut(b *testing.B) {
+	for _, rooms := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("shared_channel/rooms=%d", rooms), func(b *testing.B) {
+			benchmarkFanOut(b, rooms, func(service *Service, event Event) error {
+				return service.server.Publish(service.channel, event)
+			})
+		})
+		b.Run(fmt.Sprintf("room_channels/rooms=%d", rooms), func(b *testing.B) {
+			benchmarkFanOut(b, rooms, func(service *Service, event Event) error {
+				return service.Broadcast(event)
+			})
+		})
+	}
+}
+
+func benchmarkFanOut(b *testing.B, rooms int, publish func(*Service, Event) error) {
+	pubSub := newChannelPubSub()
+	service := NewService(pubSub, "bench")
+
+	client := &Client{
+		ConnID:       "conn-bench",
+		IdentityType: IdentityTypeUser,
+		IdentityID:   "user:bench",
+		UserID:       "bench",
+		Send:         make(chan []byte, 256),
+	}
+	service.register <- client
+	if _, err := service.JoinRoom(client.ConnID, "room-0"); err != nil {
+		b.Fatalf("failed to join room: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range client.Send {
+		}
+	}()
+
+	payload, err := json.Marshal(map[string]any{
+		"taskId":        "7b0d5f8e-2f7c-4c47-9a43-3c8f3a9d1e20",
+		"participantId": "1f6e2f0a-7a7e-4b55-8d2c-6a8c9d0e4b11",
+		"roundNumber":   1,
+		"voted":         true,
+	})
+	if err != nil {
+		b.Fatalf("failed to marshal payload: %v", err)
+	}
+
+	events := make([]Event, rooms)
+	for i := range events {
+		events[i] = Event{Type: "ROOMS_VOTE_STATUS_CHANGED", RoomID: "room-" + strconv.Itoa(i), Payload: payload}
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			if err := publish(service, event); err != nil {
+				b.Fatalf("failed to publish: %v", err)
+			}
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(pubSub.delivered)/float64(b.N), "deliveries/op")
+
+	service.unregister <- client
+	<-done
+}
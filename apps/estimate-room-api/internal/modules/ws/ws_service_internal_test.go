@@ -1,9 +1,15 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
+
+	apperrors "github.com/master-bogdan/estimate-room-api/internal/pkg/apperrors"
 )
 
 func TestServiceSendToUser_DeliversToMatchingIdentity(t *testing.T) {
@@ -43,6 +49,67 @@ func TestServiceSendToUser_DeliversToMatchingIdentity(t *testing.T) {
 	service.unregister <- client
 }
 
+func TestServiceSendToRoomUser_DeliversOnlyToUserConnectionsInRoom(t *testing.T) {
+	service := NewService(nil, "test")
+
+	inRoom := &Client{
+		ConnID:       "conn-in-room",
+		IdentityType: IdentityTypeUser,
+		IdentityID:   "user:user-123",
+		UserID:       "user-123",
+		Send:         make(chan []byte, 1),
+	}
+	otherRoom := &Client{
+		ConnID:       "conn-other-room",
+		IdentityType: IdentityTypeUser,
+		IdentityID:   "user:user-123",
+		UserID:       "user-123",
+		Send:         make(chan []byte, 1),
+	}
+	otherUser := &Client{
+		ConnID:       "conn-other-user",
+		IdentityType: IdentityTypeUser,
+		IdentityID:   "user:user-456",
+		UserID:       "user-456",
+		Send:         make(chan []byte, 1),
+	}
+
+	for _, client := range []*Client{inRoom, otherRoom, otherUser} {
+		service.register <- client
+		waitForRegisteredClient(t, service, client.ConnID)
+	}
+	for connID, roomID := range map[string]string{inRoom.ConnID: "room-1", otherRoom.ConnID: "room-2", otherUser.ConnID: "room-1"} {
+		if _, err := service.JoinRoom(connID, roomID); err != nil {
+			t.Fatalf("failed to join %s: %v", connID, err)
+		}
+	}
+
+	if err := service.SendToRoomUser("room-1", "user-123", Event{Type: "PRIVATE"}); err != nil {
+		t.Fatalf("expected send to room user to succeed: %v", err)
+	}
+
+	select {
+	case raw := <-inRoom.Send:
+		event := Event{}
+		if err := json.Unmarshal(raw, &event); err != nil {
+			t.Fatalf("failed to decode event: %v", err)
+		}
+		if event.Type != "PRIVATE" || event.RoomID != "room-1" {
+			t.Fatalf("unexpected event %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected event to be delivered to the user's room connection")
+	}
+
+	if len(otherRoom.Send) != 0 || len(otherUser.Send) != 0 {
+		t.Fatal("expected no delivery outside the user's room connections")
+	}
+
+	for _, client := range []*Client{inRoom, otherRoom, otherUser} {
+		service.unregister <- client
+	}
+}
+
 func TestServiceSendToIdentity_IgnoresMissingIdentity(t *testing.T) {
 	service := NewService(nil, "test")
 
@@ -93,6 +160,490 @@ func TestServiceSendToIdentity_UnregistersUnwritableClient(t *testing.T) {
 	waitForUnregisteredClient(t, service, client.ConnID)
 }
 
+func TestServiceDisconnectParticipant_DropsOnlyMatchingConnections(t *testing.T) {
+	service := NewService(nil, "test")
+
+	kicked := &Client{
+		ConnID:        "conn-kicked",
+		IdentityType:  IdentityTypeGuest,
+		IdentityID:    "guest:participant-kicked",
+		ParticipantID: "participant-kicked",
+		Send:          make(chan []byte, 1),
+	}
+	other := &Client{
+		ConnID:        "conn-other",
+		IdentityType:  IdentityTypeUser,
+		IdentityID:    "user:user-other",
+		UserID:        "user-other",
+		ParticipantID: "participant-other",
+		Send:          make(chan []byte, 1),
+	}
+
+	service.register <- kicked
+	service.register <- other
+	waitForRegisteredClient(t, service, kicked.ConnID)
+	waitForRegisteredClient(t, service, other.ConnID)
+
+	if err := service.DisconnectParticipant("room-1", "participant-kicked", "removed"); err != nil {
+		t.Fatalf("expected disconnect to succeed: %v", err)
+	}
+
+	waitForUnregisteredClient(t, service, kicked.ConnID)
+
+	service.mu.RLock()
+	_, ok := service.connClients[other.ConnID]
+	service.mu.RUnlock()
+	if !ok {
+		t.Fatal("expected unrelated connection to stay registered")
+	}
+
+	service.unregister <- other
+}
+
+type recordingPubSub struct {
+	published []Event
+}
+
+func (p *recordingPubSub) Subscribe(string, func([]byte)) {}
+
+func (p *recordingPubSub) Unsubscribe(string) {}
+
+func (p *recordingPubSub) Publish(_ string, message any) error {
+	event, ok := message.(Event)
+	if !ok {
+		return errors.New("unexpected message type")
+	}
+	p.published = append(p.published, event)
+	return nil
+}
+
+// channelPubSub delivers each message synchronously to the subscribers of
+// its channel, like Redis does for the instances subscribed to it.
+type channelPubSub struct {
+	mu            sync.Mutex
+	subscriptions map[string][]func([]byte)
+	delivered     int
+}
+
+func newChannelPubSub() *channelPubSub {
+	return &channelPubSub{
+		subscriptions: make(map[string][]func([]byte)),
+	}
+}
+
+func (p *channelPubSub) Subscribe(channel string, onMessage func([]byte)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.subscriptions[channel] = append(p.subscriptions[channel], onMessage)
+}
+
+func (p *channelPubSub) Unsubscribe(channel string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.subscriptions, channel)
+}
+
+func (p *channelPubSub) Publish(channel string, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	subscribers := append([]func([]byte){}, p.subscriptions[channel]...)
+	p.delivered += len(subscribers)
+	p.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(data)
+	}
+
+	return nil
+}
+
+func (p *channelPubSub) subscriberCount(channel string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.subscriptions[channel])
+}
+
+type sliceEventLog struct {
+	pubSub PubSub
+	events []Event
+}
+
+func (l *sliceEventLog) Publish(_ context.Context, channel string, event Event) error {
+	event.Seq = int64(len(l.events) + 1)
+	l.events = append(l.events, event)
+	return l.pubSub.Publish(channel, event)
+}
+
+func (l *sliceEventLog) LastSeq(context.Context, string) (int64, error) {
+	return int64(len(l.events)), nil
+}
+
+func (l *sliceEventLog) Since(_ context.Context, _ string, lastSeq int64) ([]Event, bool, error) {
+	if lastSeq > int64(len(l.events)) {
+		return nil, false, nil
+	}
+	return l.events[lastSeq:], true, nil
+}
+
+func TestServiceBroadcast_NumbersRoomEventsThroughEventLog(t *testing.T) {
+	pubSub := &recordingPubSub{}
+	service := NewService(pubSub, "test")
+
+	if _, ok := service.RoomEventsSince("room-1", 1); ok {
+		t.Fatal("expected no replay without an event log")
+	}
+
+	service.SetEventLog(&sliceEventLog{pubSub: pubSub})
+
+	for _, event := range []Event{
+		{Type: "ROOM_FIRST", RoomID: "room-1"},
+		{Type: "GLOBAL"},
+		{Type: "ROOM_SECOND", RoomID: "room-1"},
+	} {
+		if err := service.Broadcast(event); err != nil {
+			t.Fatalf("expected broadcast to succeed: %v", err)
+		}
+	}
+
+	seqs := []int64{pubSub.published[0].Seq, pubSub.published[1].Seq, pubSub.published[2].Seq}
+	if seqs[0] != 1 || seqs[1] != 0 || seqs[2] != 2 {
+		t.Fatalf("expected only room events to be numbered, got %v", seqs)
+	}
+	if seq := service.RoomSeq("room-1"); seq != 2 {
+		t.Fatalf("expected room sequence 2, got %d", seq)
+	}
+
+	missed, ok := service.RoomEventsSince("room-1", 1)
+	if !ok || len(missed) != 1 || missed[0].Type != "ROOM_SECOND" {
+		t.Fatalf("expected the second room event to be replayed, got %+v (ok=%v)", missed, ok)
+	}
+	if _, ok := service.RoomEventsSince("room-1", 0); ok {
+		t.Fatal("expected a client without a sequence number to get no replay")
+	}
+}
+
+func TestServiceJoinRoom_SubscribesRoomChannelWhileClientsAreInRoom(t *testing.T) {
+	pubSub := newChannelPubSub()
+	service := NewService(pubSub, "test")
+	roomChannel := RoomChannel("test", "room-1")
+
+	first := &Client{ConnID: "conn-first", IdentityType: IdentityTypeUser, IdentityID: "user:first", UserID: "first", Send: make(chan []byte, 1)}
+	second := &Client{ConnID: "conn-second", IdentityType: IdentityTypeUser, IdentityID: "user:second", UserID: "second", Send: make(chan []byte, 1)}
+	for _, client := range []*Client{first, second} {
+		service.register <- client
+		waitForRegisteredClient(t, service, client.ConnID)
+		if _, err := service.JoinRoom(client.ConnID, "room-1"); err != nil {
+			t.Fatalf("failed to join %s: %v", client.ConnID, err)
+		}
+	}
+
+	if count := pubSub.subscriberCount(roomChannel); count != 1 {
+		t.Fatalf("expected one subscription to the room channel, got %d", count)
+	}
+
+	if err := service.Broadcast(Event{Type: "ROOM_EVENT", RoomID: "room-1"}); err != nil {
+		t.Fatalf("expected broadcast to succeed: %v", err)
+	}
+	if err := service.Broadcast(Event{Type: "OTHER_ROOM_EVENT", RoomID: "room-2"}); err != nil {
+		t.Fatalf("expected broadcast to succeed: %v", err)
+	}
+	if len(first.Send) != 1 || len(second.Send) != 1 || pubSub.delivered != 1 {
+		t.Fatalf("expected only the room's event to reach this instance, got %d deliveries", pubSub.delivered)
+	}
+
+	service.unregister <- first
+	waitForUnregisteredClient(t, service, first.ConnID)
+	if pubSub.subscriberCount(roomChannel) != 1 {
+		t.Fatal("expected the room channel to stay subscribed while a client is in the room")
+	}
+
+	service.unregister <- second
+	waitForUnregisteredClient(t, service, second.ConnID)
+	waitForSubscriberCount(t, pubSub, roomChannel, 0)
+}
+
+func TestServiceJoinRoom_KeepsRoomChannelWhenJoinRacesLastLeave(t *testing.T) {
+	pubSub := newChannelPubSub()
+	service := NewService(pubSub, "test")
+	roomChannel := RoomChannel("test", "room-1")
+
+	leaving := &Client{ConnID: "conn-leaving", IdentityType: IdentityTypeUser, IdentityID: "user:leaving", UserID: "leaving", Send: make(chan []byte, 1)}
+	service.register <- leaving
+	waitForRegisteredClient(t, service, leaving.ConnID)
+	if _, err := service.JoinRoom(leaving.ConnID, "room-1"); err != nil {
+		t.Fatalf("failed to join: %v", err)
+	}
+
+	joining := &Client{ConnID: "conn-joining", IdentityType: IdentityTypeUser, IdentityID: "user:joining", UserID: "joining", Send: make(chan []byte, 1)}
+	service.register <- joining
+	waitForRegisteredClient(t, service, joining.ConnID)
+
+	service.unregister <- leaving
+	waitForUnregisteredClient(t, service, leaving.ConnID)
+	if _, err := service.JoinRoom(joining.ConnID, "room-1"); err != nil {
+		t.Fatalf("failed to join: %v", err)
+	}
+	// The leave's subscription change may run only after the join, as here.
+	service.syncRoomSubscription("room-1")
+
+	if count := pubSub.subscriberCount(roomChannel); count != 1 {
+		t.Fatalf("expected the room channel to stay subscribed for the joined client, got %d subscriptions", count)
+	}
+}
+
+// mapPresence is an in-memory presence shared by services standing in for
+// separate instances.
+type mapPresence struct {
+	mu      sync.Mutex
+	rooms   map[string]map[string]PresenceConn
+	expired map[string][]PresenceConn
+}
+
+func newMapPresence() *mapPresence {
+	return &mapPresence{
+		rooms:   make(map[string]map[string]PresenceConn),
+		expired: make(map[string][]PresenceConn),
+	}
+}
+
+func (p *mapPresence) Join(_ context.Context, conn PresenceConn) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.rooms[conn.RoomID] == nil {
+		p.rooms[conn.RoomID] = make(map[string]PresenceConn)
+	}
+	p.rooms[conn.RoomID][conn.ConnID] = conn
+
+	return p.identityConnsLocked(conn.RoomID, conn.IdentityID) == 1, nil
+}
+
+func (p *mapPresence) Leave(_ context.Context, conn PresenceConn) (bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.rooms[conn.RoomID], conn.ConnID)
+
+	return p.identityConnsLocked(conn.RoomID, conn.IdentityID) == 0, nil
+}
+
+func (p *mapPresence) Refresh(context.Context, []PresenceConn) error {
+	return nil
+}
+
+func (p *mapPresence) Expire(_ context.Context, roomID string) ([]PresenceConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	expired := p.expired[roomID]
+	delete(p.expired, roomID)
+
+	return expired, nil
+}
+
+func (p *mapPresence) OnlineParticipantIDs(_ context.Context, roomID string) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ids := make([]string, 0)
+	for _, conn := range p.rooms[roomID] {
+		ids = append(ids, conn.ParticipantID)
+	}
+
+	return ids, nil
+}
+
+func (p *mapPresence) identityConnsLocked(roomID, identityID string) int {
+	count := 0
+	for _, conn := range p.rooms[roomID] {
+		if conn.IdentityID == identityID {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestServicePresence_ComputesJoinsAndLeavesAcrossInstances(t *testing.T) {
+	pubSub := newChannelPubSub()
+	presence := newMapPresence()
+
+	left := make(chan DisconnectInfo, 2)
+	instances := make([]*Service, 0, 2)
+	for range 2 {
+		service := NewService(pubSub, "test")
+		service.SetPresence(presence)
+		service.SubscribeDisconnect(func(info DisconnectInfo) {
+			left <- info
+		})
+		instances = append(instances, service)
+	}
+	first, second := instances[0], instances[1]
+
+	firstTab := &Client{ConnID: "conn-first-tab", IdentityType: IdentityTypeUser, IdentityID: "user:user-1", UserID: "user-1", ParticipantID: "participant-1", Send: make(chan []byte, 1)}
+	secondTab := &Client{ConnID: "conn-second-tab", IdentityType: IdentityTypeUser, IdentityID: "user:user-1", UserID: "user-1", ParticipantID: "participant-1", Send: make(chan []byte, 1)}
+
+	first.register <- firstTab
+	waitForRegisteredClient(t, first, firstTab.ConnID)
+	result, err := first.JoinRoom(firstTab.ConnID, "room-1")
+	if err != nil || !result.Joined {
+		t.Fatalf("expected the first connection to join the room, got %+v (%v)", result, err)
+	}
+
+	if ids := second.GetRoomOnlineParticipantIDs("room-1"); len(ids) != 1 || ids[0] != "participant-1" {
+		t.Fatalf("expected the other instance to see the participant online, got %v", ids)
+	}
+
+	second.register <- secondTab
+	waitForRegisteredClient(t, second, secondTab.ConnID)
+	result, err = second.JoinRoom(secondTab.ConnID, "room-1")
+	if err != nil || result.Joined {
+		t.Fatalf("expected a connection on another instance not to join again, got %+v (%v)", result, err)
+	}
+
+	if err := first.SendToIdentity("user:user-1", Event{Type: "IDENTITY_EVENT"}); err != nil {
+		t.Fatalf("expected identity send to succeed: %v", err)
+	}
+	if len(firstTab.Send) != 1 || len(secondTab.Send) != 1 {
+		t.Fatal("expected the identity event to reach the connections on both instances")
+	}
+
+	first.unregister <- firstTab
+	if info := <-left; info.PresenceLeft {
+		t.Fatal("expected the participant to stay in the room while connected elsewhere")
+	}
+
+	second.unregister <- secondTab
+	if info := <-left; !info.PresenceLeft {
+		t.Fatal("expected the participant to leave with their last connection in the cluster")
+	}
+	if ids := first.GetRoomOnlineParticipantIDs("room-1"); len(ids) != 0 {
+		t.Fatalf("expected no participant online, got %v", ids)
+	}
+}
+
+func TestServicePresence_ReportsConnectionsExpiredOnAnotherInstance(t *testing.T) {
+	presence := newMapPresence()
+	service := NewService(newChannelPubSub(), "test")
+	service.SetPresence(presence)
+
+	left := make(chan DisconnectInfo, 1)
+	service.SubscribeDisconnect(func(info DisconnectInfo) {
+		left <- info
+	})
+
+	client := &Client{ConnID: "conn-local", IdentityType: IdentityTypeUser, IdentityID: "user:local", UserID: "local", ParticipantID: "participant-local", Send: make(chan []byte, 1)}
+	service.register <- client
+	waitForRegisteredClient(t, service, client.ConnID)
+	if _, err := service.JoinRoom(client.ConnID, "room-1"); err != nil {
+		t.Fatalf("failed to join: %v", err)
+	}
+
+	presence.mu.Lock()
+	presence.expired["room-1"] = []PresenceConn{{
+		RoomID:        "room-1",
+		ConnID:        "conn-dead",
+		IdentityType:  IdentityTypeUser,
+		IdentityID:    "user:dead",
+		UserID:        "dead",
+		ParticipantID: "participant-dead",
+	}}
+	presence.mu.Unlock()
+
+	service.expirePresence(context.Background())
+
+	select {
+	case info := <-left:
+		if !info.PresenceLeft || info.RoomID != "room-1" || info.Client.ParticipantID != "participant-dead" || info.Client.UserID != "dead" {
+			t.Fatalf("expected the expired participant to leave the room, got %+v", info)
+		}
+	default:
+		t.Fatal("expected a disconnect for the expired connection")
+	}
+}
+
+func TestServiceHandleCommand_RepliesWithAckOrError(t *testing.T) {
+	service := NewService(nil, "test")
+
+	client := &Client{
+		ConnID:       "conn-commands",
+		IdentityType: IdentityTypeUser,
+		IdentityID:   "user:user-commands",
+		UserID:       "user-commands",
+		Send:         make(chan []byte, 4),
+	}
+	service.register <- client
+	waitForRegisteredClient(t, service, client.ConnID)
+
+	service.HandleCommand("OK_COMMAND", func(ClientInfo, Event) error { return nil })
+	service.HandleCommand("DENIED_COMMAND", func(ClientInfo, Event) error {
+		return fmt.Errorf("%w: only the facilitator can do that", apperrors.ErrForbidden)
+	})
+	service.HandleCommand("BROKEN_COMMAND", func(ClientInfo, Event) error {
+		return errors.New("connection refused by db at 10.0.0.1")
+	})
+
+	readReply := func() Event {
+		t.Helper()
+		select {
+		case raw := <-client.Send:
+			event := Event{}
+			if err := json.Unmarshal(raw, &event); err != nil {
+				t.Fatalf("failed to decode reply: %v", err)
+			}
+			return event
+		case <-time.After(time.Second):
+			t.Fatal("expected a reply to the command")
+			return Event{}
+		}
+	}
+	info := clientInfo(client)
+
+	service.dispatchEvent(info, Event{Type: "OK_COMMAND", RoomID: "room-1", CorrelationID: "c-1"})
+	ack := readReply()
+	if ack.Type != EventTypeAck || ack.CorrelationID != "c-1" || ack.RoomID != "room-1" {
+		t.Fatalf("unexpected acknowledgement %+v", ack)
+	}
+
+	service.dispatchEvent(info, Event{Type: "DENIED_COMMAND", CorrelationID: "c-2"})
+	denied := readReply()
+	payload := ErrorPayload{}
+	if err := json.Unmarshal(denied.Payload, &payload); err != nil {
+		t.Fatalf("failed to decode error payload: %v", err)
+	}
+	if denied.Type != EventTypeError || denied.CorrelationID != "c-2" {
+		t.Fatalf("unexpected error event %+v", denied)
+	}
+	if payload.Command != "DENIED_COMMAND" || payload.Code != "FORBIDDEN" || payload.Status != 403 || payload.Detail != "only the facilitator can do that" {
+		t.Fatalf("unexpected error payload %+v", payload)
+	}
+
+	service.dispatchEvent(info, Event{Type: "BROKEN_COMMAND"})
+	if err := json.Unmarshal(readReply().Payload, &payload); err != nil {
+		t.Fatalf("failed to decode error payload: %v", err)
+	}
+	if payload.Code != "INTERNAL" || payload.Detail != "internal server error" {
+		t.Fatalf("expected the internal cause to be hidden, got %+v", payload)
+	}
+
+	service.dispatchEvent(info, Event{Type: "NO_SUCH_COMMAND"})
+	if err := json.Unmarshal(readReply().Payload, &payload); err != nil {
+		t.Fatalf("failed to decode error payload: %v", err)
+	}
+	if payload.Code != "BAD_REQUEST" || payload.Detail != "unknown event type" {
+		t.Fatalf("unexpected unknown command error %+v", payload)
+	}
+
+	service.unregister <- client
+}
+
 func TestServiceAllowIncomingMessage_EnforcesConfiguredRateLimit(t *testing.T) {
 	service := NewService(nil, "test")
 	service.SetInboundRateLimit(2, time.Minute)
@@ -110,6 +661,20 @@ func TestServiceAllowIncomingMessage_EnforcesConfiguredRateLimit(t *testing.T) {
 	}
 }
 
+func waitForSubscriberCount(t *testing.T, pubSub *channelPubSub, channel string, want int) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if pubSub.subscriberCount(channel) == want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("timed out waiting for %d subscriptions to %s, got %d", want, channel, pubSub.subscriberCount(channel))
+}
+
 func waitForRegisteredClient(t *testing.T, service *Service, connID string) {
 	t.Helper()
 
@@ -1,12 +1,15 @@
 package ws
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 )
 
 const (
 	EventTypeHello = "HELLO"
+	EventTypeAck   = "ACK"
+	EventTypeError = "ERROR"
 )
 
 type IdentityType string
@@ -17,11 +20,13 @@ const (
 )
 
 type Event struct {
-	Type      string          `json:"type"`
-	Payload   json.RawMessage `json:"payload"`
-	RoomID    string          `json:"roomId,omitempty"`
-	UserID    string          `json:"userId,omitempty"`
-	Timestamp time.Time       `json:"timestamp"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	RoomID        string          `json:"roomId,omitempty"`
+	UserID        string          `json:"userId,omitempty"`
+	Seq           int64           `json:"seq,omitempty"`
+	CorrelationID string          `json:"correlationId,omitempty"`
+	Timestamp     time.Time       `json:"timestamp"`
 }
 
 type ConnectIdentity struct {
@@ -40,6 +45,24 @@ type ClientInfo struct {
 
 type EventHandler func(ClientInfo, Event)
 
+// CommandHandler handles a client command. The sender gets an ACK when it
+// returns nil and an ERROR describing the error otherwise, both carrying the
+// command's correlation ID.
+type CommandHandler func(ClientInfo, Event) error
+
+type AckPayload struct {
+	Command string `json:"command"`
+}
+
+// ErrorPayload reports a rejected command with the apperrors category of the
+// failure: code is e.g. FORBIDDEN and status the matching HTTP status.
+type ErrorPayload struct {
+	Command string `json:"command"`
+	Code    string `json:"code"`
+	Status  int    `json:"status"`
+	Detail  string `json:"detail"`
+}
+
 type DisconnectInfo struct {
 	Client       ClientInfo
 	RoomID       string
@@ -50,5 +73,50 @@ type DisconnectHandler func(DisconnectInfo)
 
 type PubSub interface {
 	Subscribe(channel string, onMessage func([]byte))
+	Unsubscribe(channel string)
 	Publish(channel string, message any) error
 }
+
+// EventLog numbers the events broadcast to a room and keeps the most recent
+// ones, so a reconnecting client can replay what it missed.
+type EventLog interface {
+	// Publish assigns the next sequence number of the event's room, stores
+	// the numbered event and publishes it on channel in a single step.
+	Publish(ctx context.Context, channel string, event Event) error
+	// LastSeq reports the latest sequence number assigned in the room.
+	LastSeq(ctx context.Context, roomID string) (int64, error)
+	// Since returns the stored events after lastSeq in order, and false when
+	// any of them is no longer stored.
+	Since(ctx context.Context, roomID string, lastSeq int64) ([]Event, bool, error)
+}
+
+// PresenceConn is a connection joined to a room, as recorded in the
+// cluster-wide presence.
+type PresenceConn struct {
+	RoomID        string
+	ConnID        string
+	IdentityType  IdentityType
+	IdentityID    string
+	UserID        string
+	ParticipantID string
+}
+
+// Presence tracks the room connections of every instance. Each instance keeps
+// its own connections alive with Refresh, so the connections of an instance
+// that stops without leaving expire and are picked up by Expire.
+type Presence interface {
+	// Join records the connection and reports whether it is the identity's
+	// only live connection in the room.
+	Join(ctx context.Context, conn PresenceConn) (bool, error)
+	// Leave removes the connection and reports whether the identity has no
+	// live connection left in the room.
+	Leave(ctx context.Context, conn PresenceConn) (bool, error)
+	// Refresh extends the connections that are still recorded.
+	Refresh(ctx context.Context, conns []PresenceConn) error
+	// Expire removes the room's expired connections and returns one of them
+	// for each identity left without a live connection in the room.
+	Expire(ctx context.Context, roomID string) ([]PresenceConn, error)
+	// OnlineParticipantIDs lists the participants with a live connection in
+	// the room.
+	OnlineParticipantIDs(ctx context.Context, roomID string) ([]string, error)
+}
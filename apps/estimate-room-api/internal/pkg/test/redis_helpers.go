@@ -0,0 +1,34 @@
+package testutils
+
+import (
+	"os"
+	"testing"
+
+	redisdb "github.com/master-bogdan/estimate-room-api/internal/infra/db/redis"
+	"github.com/redis/go-redis/v9"
+)
+
+// SetupTestRedis connects to the test Redis. Tests keep to keys of their own
+// rooms, so the database is never flushed.
+func SetupTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+
+	redisURL := os.Getenv("TEST_REDIS_URL")
+	if redisURL == "" {
+		redisURL = os.Getenv("REDIS_URL")
+	}
+	if redisURL == "" {
+		t.Skip("TEST_REDIS_URL or REDIS_URL is required")
+		return nil
+	}
+
+	client, err := redisdb.Connect(redisURL)
+	if err != nil {
+		t.Fatalf("failed to connect to redis: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return client
+}
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS decks_team_id_idx;
+DROP INDEX IF EXISTS decks_owner_user_id_idx;
+
+ALTER TABLE "user_settings" DROP CONSTRAINT IF EXISTS "user_settings_default_deck_id_fkey";
+ALTER TABLE "user_settings" ADD CONSTRAINT "user_settings_default_deck_id_fkey"
+  FOREIGN KEY ("default_deck_id") REFERENCES "decks" ("deck_id");
+
+DELETE FROM "decks" WHERE "owner_user_id" IS NOT NULL OR "team_id" IS NOT NULL;
+
+ALTER TABLE "decks" DROP CONSTRAINT IF EXISTS "decks_single_owner_check";
+ALTER TABLE "decks" DROP COLUMN IF EXISTS "updated_at";
+ALTER TABLE "decks" DROP COLUMN IF EXISTS "team_id";
+ALTER TABLE "decks" DROP COLUMN IF EXISTS "owner_user_id";
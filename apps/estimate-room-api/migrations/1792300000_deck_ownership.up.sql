@@ -0,0 +1,17 @@
+ALTER TABLE "decks" ADD COLUMN "owner_user_id" text;
+ALTER TABLE "decks" ADD COLUMN "team_id" text;
+ALTER TABLE "decks" ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now());
+
+ALTER TABLE "decks" ADD CONSTRAINT "decks_single_owner_check"
+  CHECK (NOT ("owner_user_id" IS NOT NULL AND "team_id" IS NOT NULL));
+
+ALTER TABLE "decks" ADD FOREIGN KEY ("owner_user_id") REFERENCES "users" ("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "decks" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("team_id") ON DELETE CASCADE;
+
+ALTER TABLE "user_settings" DROP CONSTRAINT IF EXISTS "user_settings_default_deck_id_fkey";
+ALTER TABLE "user_settings" ADD CONSTRAINT "user_settings_default_deck_id_fkey"
+  FOREIGN KEY ("default_deck_id") REFERENCES "decks" ("deck_id") ON DELETE SET NULL;
+
+CREATE INDEX "decks_owner_user_id_idx" ON "decks" ("owner_user_id");
+CREATE INDEX "decks_team_id_idx" ON "decks" ("team_id");
@@ -0,0 +1,14 @@
+CREATE TABLE "team_session_rewards" (
+  "room_id" text PRIMARY KEY,
+  "team_id" text NOT NULL,
+  "sessions_total_delta" int NOT NULL DEFAULT 0,
+  "tasks_estimated_delta" int NOT NULL DEFAULT 0,
+  "xp_gained" int NOT NULL DEFAULT 0,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "team_session_rewards" ADD FOREIGN KEY ("room_id") REFERENCES "rooms" ("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "team_session_rewards" ADD FOREIGN KEY ("team_id") REFERENCES "teams" ("team_id") ON DELETE CASCADE;
+
+CREATE INDEX "team_session_rewards_team_id_idx" ON "team_session_rewards" ("team_id");
@@ -0,0 +1,5 @@
+ALTER TABLE "task_rounds" DROP COLUMN IF EXISTS "timer_ends_at";
+
+ALTER TABLE "task_rounds" DROP COLUMN IF EXISTS "timer_duration_seconds";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "options";
@@ -0,0 +1,5 @@
+ALTER TABLE "rooms" ADD COLUMN "options" jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE "task_rounds" ADD COLUMN "timer_duration_seconds" int;
+
+ALTER TABLE "task_rounds" ADD COLUMN "timer_ends_at" timestamptz;
@@ -0,0 +1,20 @@
+UPDATE "room_participants"
+SET "role" = CASE WHEN "user_id" IS NULL THEN 'GUEST'::room_participant_role ELSE 'MEMBER'::room_participant_role END
+WHERE "role" = 'OBSERVER';
+
+ALTER TYPE "room_participant_role" RENAME TO "room_participant_role_old";
+
+CREATE TYPE "room_participant_role" AS ENUM (
+  'ADMIN',
+  'MEMBER',
+  'GUEST'
+);
+
+ALTER TABLE "room_participants" ALTER COLUMN "role" DROP DEFAULT;
+
+ALTER TABLE "room_participants"
+  ALTER COLUMN "role" TYPE "room_participant_role" USING "role"::text::"room_participant_role";
+
+ALTER TABLE "room_participants" ALTER COLUMN "role" SET DEFAULT 'MEMBER';
+
+DROP TYPE "room_participant_role_old";
@@ -0,0 +1 @@
+ALTER TYPE "room_participant_role" ADD VALUE IF NOT EXISTS 'OBSERVER';
@@ -0,0 +1,21 @@
+UPDATE "room_participants"
+SET "role" = 'MEMBER'::room_participant_role
+WHERE "role" = 'FACILITATOR';
+
+ALTER TYPE "room_participant_role" RENAME TO "room_participant_role_old";
+
+CREATE TYPE "room_participant_role" AS ENUM (
+  'ADMIN',
+  'MEMBER',
+  'GUEST',
+  'OBSERVER'
+);
+
+ALTER TABLE "room_participants" ALTER COLUMN "role" DROP DEFAULT;
+
+ALTER TABLE "room_participants"
+  ALTER COLUMN "role" TYPE "room_participant_role" USING "role"::text::"room_participant_role";
+
+ALTER TABLE "room_participants" ALTER COLUMN "role" SET DEFAULT 'MEMBER';
+
+DROP TYPE "room_participant_role_old";
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "tasks_room_id_position_idx";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "position";
@@ -0,0 +1,11 @@
+ALTER TABLE "tasks" ADD COLUMN "position" int NOT NULL DEFAULT 0;
+
+UPDATE "tasks" AS t
+SET "position" = ordered.position
+FROM (
+  SELECT "task_id", ROW_NUMBER() OVER (PARTITION BY "room_id" ORDER BY "created_at", "task_id") - 1 AS position
+  FROM "tasks"
+) AS ordered
+WHERE t."task_id" = ordered."task_id";
+
+CREATE INDEX "tasks_room_id_position_idx" ON "tasks" ("room_id", "position");
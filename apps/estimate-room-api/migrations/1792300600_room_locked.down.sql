@@ -0,0 +1 @@
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "locked_at";
@@ -0,0 +1 @@
+ALTER TABLE "rooms" ADD COLUMN "locked_at" timestamptz;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "vote_changes";
+
+DROP TYPE IF EXISTS "vote_change_action";
@@ -0,0 +1,22 @@
+CREATE TYPE "vote_change_action" AS ENUM (
+  'CAST',
+  'CHANGED',
+  'RETRACTED'
+);
+
+CREATE TABLE "vote_changes" (
+  "vote_change_id" text PRIMARY KEY DEFAULT (gen_random_uuid()::text),
+  "task_id" text NOT NULL,
+  "participant_id" text NOT NULL,
+  "round_number" int NOT NULL,
+  "action" vote_change_action NOT NULL,
+  "previous_value" text,
+  "value" text,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "vote_changes" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("task_id") ON DELETE CASCADE;
+
+ALTER TABLE "vote_changes" ADD FOREIGN KEY ("participant_id") REFERENCES "room_participants" ("room_participants_id") ON DELETE CASCADE;
+
+CREATE INDEX "vote_changes_task_id_round_number_idx" ON "vote_changes" ("task_id", "round_number");
@@ -0,0 +1,18 @@
+CREATE TABLE "chat_messages" (
+  "chat_message_id" text PRIMARY KEY DEFAULT (gen_random_uuid()::text),
+  "room_id" text NOT NULL,
+  "task_id" text,
+  "participant_id" text NOT NULL,
+  "body" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "chat_messages" ADD FOREIGN KEY ("room_id") REFERENCES "rooms" ("room_id") ON DELETE CASCADE;
+
+ALTER TABLE "chat_messages" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("task_id") ON DELETE CASCADE;
+
+ALTER TABLE "chat_messages" ADD FOREIGN KEY ("participant_id") REFERENCES "room_participants" ("room_participants_id") ON DELETE CASCADE;
+
+CREATE INDEX "chat_messages_room_id_created_at_idx" ON "chat_messages" ("room_id", "created_at");
+
+CREATE INDEX "chat_messages_task_id_created_at_idx" ON "chat_messages" ("task_id", "created_at");
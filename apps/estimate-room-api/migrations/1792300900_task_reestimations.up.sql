@@ -0,0 +1,15 @@
+CREATE TABLE "task_reestimations" (
+  "task_reestimation_id" text PRIMARY KEY DEFAULT (gen_random_uuid()::text),
+  "task_id" text NOT NULL,
+  "round_number" int NOT NULL,
+  "previous_status" text NOT NULL,
+  "previous_final_estimate_value" text,
+  "reopened_by_user_id" text NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "task_reestimations" ADD FOREIGN KEY ("task_id") REFERENCES "tasks" ("task_id") ON DELETE CASCADE;
+
+ALTER TABLE "task_reestimations" ADD FOREIGN KEY ("reopened_by_user_id") REFERENCES "users" ("user_id");
+
+CREATE INDEX "task_reestimations_task_id_idx" ON "task_reestimations" ("task_id");
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "votes_task_id_participant_id_round_number_dimension_idx";
+
+DELETE FROM "votes" WHERE "dimension" <> '';
+
+CREATE UNIQUE INDEX "votes_task_id_participant_id_round_number_idx" ON "votes" ("task_id", "participant_id", "round_number");
+
+ALTER TABLE "vote_changes" DROP COLUMN IF EXISTS "dimension";
+
+ALTER TABLE "votes" DROP COLUMN IF EXISTS "dimension";
+
+ALTER TABLE "tasks" DROP COLUMN IF EXISTS "final_dimension_estimates";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "dimensions";
@@ -0,0 +1,11 @@
+ALTER TABLE "rooms" ADD COLUMN "dimensions" jsonb NOT NULL DEFAULT '[]'::jsonb;
+
+ALTER TABLE "tasks" ADD COLUMN "final_dimension_estimates" jsonb;
+
+ALTER TABLE "votes" ADD COLUMN "dimension" text NOT NULL DEFAULT '';
+
+ALTER TABLE "vote_changes" ADD COLUMN "dimension" text NOT NULL DEFAULT '';
+
+DROP INDEX IF EXISTS "votes_task_id_participant_id_round_number_idx";
+
+CREATE UNIQUE INDEX "votes_task_id_participant_id_round_number_dimension_idx" ON "votes" ("task_id", "participant_id", "round_number", "dimension");
@@ -0,0 +1,5 @@
+ALTER TABLE "task_rounds" DROP COLUMN IF EXISTS "reminder_sent_at";
+
+ALTER TABLE "rooms" DROP COLUMN IF EXISTS "mode";
+
+DROP TYPE IF EXISTS "room_mode";
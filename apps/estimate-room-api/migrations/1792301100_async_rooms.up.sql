@@ -0,0 +1,8 @@
+CREATE TYPE "room_mode" AS ENUM (
+  'LIVE',
+  'ASYNC'
+);
+
+ALTER TABLE "rooms" ADD COLUMN "mode" room_mode NOT NULL DEFAULT 'LIVE';
+
+ALTER TABLE "task_rounds" ADD COLUMN "reminder_sent_at" timestamptz;